@@ -0,0 +1,429 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Base_EncodedLen
+//------------------------------------------------------------
+
+func Base_EncodedLen(n int) int {
+	//------------------------------------------------------------
+	if n%4 > 0 {
+		return n/4*5 + n%4 + 1
+	}
+	//------------------------------------------------------------
+	return n / 4 * 5
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base_DecodedLen
+//------------------------------------------------------------
+
+func Base_DecodedLen(n int) int {
+	//------------------------------------------------------------
+	if n%5 > 0 {
+		return n/5*4 + n%5 - 1
+	}
+	//------------------------------------------------------------
+	return n / 5 * 4
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base_AppendEncode
+//------------------------------------------------------------
+
+// Base_AppendEncode appends the Base_encode encoding of src to dst and returns the extended slice
+func Base_AppendEncode(dst, src []byte) []byte {
+	//------------------------------------------------------------
+	dst = growSlice(dst, Base_EncodedLen(len(src)))
+	//------------------------------------------------------------
+	for dataIndex := 0; dataIndex < len(src); dataIndex += 4 {
+		//---------------------------------------------------
+		var group [4]byte
+		length := copy(group[:], src[dataIndex:])
+		//---------------------------------------------------
+		dst = appendBaseGroup(dst, group, length)
+		//---------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return dst
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base_AppendDecode
+//------------------------------------------------------------
+
+// Base_AppendDecode appends the decoded bytes of src to dst and returns the extended slice
+func Base_AppendDecode(dst, src []byte) ([]byte, error) {
+	//------------------------------------------------------------
+	dst = growSlice(dst, Base_DecodedLen(len(src)))
+	//------------------------------------------------------------
+	for dataIndex := 0; dataIndex < len(src); dataIndex += 5 {
+		//---------------------------------------------------
+		group := [5]int{84, 84, 84, 84, 84}
+		length := 0
+		//---------------------------------------------------
+		for ; length < 5 && dataIndex+length < len(src); length++ {
+			value := baseDecodeMap[src[dataIndex+length]]
+			if value == 0xFF {
+				return dst, fmt.Errorf("data contains one or more invalid characters")
+			}
+			group[length] = int(value)
+		}
+		//---------------------------------------------------
+		dst = appendBaseChunk(dst, group, length)
+		//---------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return dst, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// appendBaseGroup
+//------------------------------------------------------------
+
+// appendBaseGroup encodes a group of 4 bytes where partial groups output one more character than the number of input bytes
+func appendBaseGroup(dst []byte, group [4]byte, length int) []byte {
+	//------------------------------------------------------------
+	charCodeSum := int(group[0])<<24 | int(group[1])<<16 | int(group[2])<<8 | int(group[3])
+	//------------------------------------------------------------
+	var chunk [5]byte
+	for subIndex := 4; subIndex >= 0; subIndex -= 1 {
+		chunk[subIndex] = BASE_CHARSET[charCodeSum%85]
+		charCodeSum /= 85
+	}
+	//------------------------------------------------------------
+	return append(dst, chunk[:length+1]...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// appendBaseChunk
+//------------------------------------------------------------
+
+// appendBaseChunk decodes a chunk of 5 characters where partial chunks output one less byte than the number of input characters
+func appendBaseChunk(dst []byte, chunk [5]int, length int) []byte {
+	//------------------------------------------------------------
+	decodedChunk := 52200625*chunk[0] + 614125*chunk[1] + 7225*chunk[2] + 85*chunk[3] + chunk[4]
+	//------------------------------------------------------------
+	group := [4]byte{byte(decodedChunk >> 24), byte(decodedChunk >> 16), byte(decodedChunk >> 8), byte(decodedChunk)}
+	//------------------------------------------------------------
+	return append(dst, group[:length-1]...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Base64_EncodedLen
+//------------------------------------------------------------
+
+func Base64_EncodedLen(n int) int { return base64.StdEncoding.EncodedLen(n) }
+
+//------------------------------------------------------------
+// Base64_DecodedLen
+//------------------------------------------------------------
+
+// Base64_DecodedLen returns the maximum decoded length as padding is not known in advance
+func Base64_DecodedLen(n int) int { return base64.StdEncoding.DecodedLen(n) }
+
+//------------------------------------------------------------
+// Base64_AppendEncode
+//------------------------------------------------------------
+
+func Base64_AppendEncode(dst, src []byte) []byte {
+	return base64.StdEncoding.AppendEncode(dst, src)
+}
+
+//------------------------------------------------------------
+// Base64_AppendDecode
+//------------------------------------------------------------
+
+func Base64_AppendDecode(dst, src []byte) ([]byte, error) {
+	return base64.StdEncoding.AppendDecode(dst, src)
+}
+
+//------------------------------------------------------------
+// Base64url_EncodedLen
+//------------------------------------------------------------
+
+func Base64url_EncodedLen(n int) int { return base64.RawURLEncoding.EncodedLen(n) }
+
+//------------------------------------------------------------
+// Base64url_DecodedLen
+//------------------------------------------------------------
+
+func Base64url_DecodedLen(n int) int { return base64.RawURLEncoding.DecodedLen(n) }
+
+//------------------------------------------------------------
+// Base64url_AppendEncode
+//------------------------------------------------------------
+
+func Base64url_AppendEncode(dst, src []byte) []byte {
+	return base64.RawURLEncoding.AppendEncode(dst, src)
+}
+
+//------------------------------------------------------------
+// Base64url_AppendDecode
+//------------------------------------------------------------
+
+// Base64url_AppendDecode accepts the same input as Base64url_decode, either alphabet with or without padding
+func Base64url_AppendDecode(dst, src []byte) ([]byte, error) {
+	//------------------------------------------------------------
+	stdBytes := make([]byte, len(src), len(src)+2)
+	//------------------------------------------------------------
+	for index, dataByte := range src {
+		switch dataByte {
+		case '-':
+			dataByte = '+'
+		case '_':
+			dataByte = '/'
+		}
+		stdBytes[index] = dataByte
+	}
+	//------------------------------------------------------------
+	switch len(stdBytes) % 4 {
+	case 2:
+		stdBytes = append(stdBytes, "=="...)
+	case 3:
+		stdBytes = append(stdBytes, '=')
+	}
+	//------------------------------------------------------------
+	return base64.StdEncoding.AppendDecode(dst, stdBytes)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Base91_EncodedLen
+//------------------------------------------------------------
+
+// Base91_EncodedLen returns the maximum unescaped length as base91 output length depends on the data
+func Base91_EncodedLen(n int) int { return (n*16 + 12) / 13 }
+
+//------------------------------------------------------------
+// Base91_DecodedLen
+//------------------------------------------------------------
+
+func Base91_DecodedLen(n int) int { return (n*14 + 15) / 16 }
+
+//------------------------------------------------------------
+// Base91_AppendEncode
+//------------------------------------------------------------
+
+func Base91_AppendEncode(dst, src []byte, escapeBool bool) []byte {
+	//------------------------------------------------------------
+	var queue, numBits uint
+	//------------------------------------------------------------
+	dst = growSlice(dst, Base91_EncodedLen(len(src)))
+	//------------------------------------------------------------
+	for _, dataByte := range src {
+		queue |= uint(dataByte) << numBits
+		numBits += 8
+		if numBits > 13 {
+			value := queue & 8191
+			if value > 88 {
+				queue >>= 13
+				numBits -= 13
+			} else {
+				value = queue & 16383
+				queue >>= 14
+				numBits -= 14
+			}
+			dst = appendBase91Char(dst, value%91, escapeBool)
+			dst = appendBase91Char(dst, value/91, escapeBool)
+		}
+	}
+	//------------------------------------------------------------
+	if numBits > 0 {
+		dst = appendBase91Char(dst, queue%91, escapeBool)
+		if numBits > 7 || queue > 90 {
+			dst = appendBase91Char(dst, queue/91, escapeBool)
+		}
+	}
+	//------------------------------------------------------------
+	return dst
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base91_AppendDecode
+//------------------------------------------------------------
+
+func Base91_AppendDecode(dst, src []byte, unescapeBool bool) ([]byte, error) {
+	//------------------------------------------------------------
+	var queue, numBits uint
+	value := -1
+	//------------------------------------------------------------
+	dst = growSlice(dst, Base91_DecodedLen(len(src)))
+	//------------------------------------------------------------
+	for index := 0; index < len(src); index++ {
+		//------------------------------------------------------------
+		dataByte := src[index]
+		//------------------------------------------------------------
+		if unescapeBool && dataByte == '-' && index+1 < len(src) {
+			switch src[index+1] {
+			case 'q':
+				dataByte = '\x22'
+				index++
+			case 'd':
+				dataByte = '\x24'
+				index++
+			case 'g':
+				dataByte = '\x60'
+				index++
+			}
+		}
+		//------------------------------------------------------------
+		decodedByte := base91DecodeMap[dataByte]
+		if decodedByte == 0xFF {
+			return dst, fmt.Errorf("illegal base91 data at input byte %d", index)
+		}
+		//------------------------------------------------------------
+		if value == -1 {
+			value = int(decodedByte)
+			continue
+		}
+		//------------------------------------------------------------
+		value += int(decodedByte) * 91
+		queue |= uint(value) << numBits
+		//------------------------------------------------------------
+		if (value & 8191) > 88 {
+			numBits += 13
+		} else {
+			numBits += 14
+		}
+		//------------------------------------------------------------
+		for ok := true; ok; ok = (numBits > 7) {
+			dst = append(dst, byte(queue))
+			queue >>= 8
+			numBits -= 8
+		}
+		//------------------------------------------------------------
+		value = -1
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if value != -1 {
+		dst = append(dst, byte(queue|uint(value)<<numBits))
+	}
+	//------------------------------------------------------------
+	return dst, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// appendBase91Char
+//------------------------------------------------------------
+
+func appendBase91Char(dst []byte, value uint, escapeBool bool) []byte {
+	//------------------------------------------------------------
+	charByte := base91Charset[value]
+	//------------------------------------------------------------
+	if escapeBool {
+		switch charByte {
+		case '\x22':
+			return append(dst, '-', 'q')
+		case '\x24':
+			return append(dst, '-', 'd')
+		case '\x60':
+			return append(dst, '-', 'g')
+		}
+	}
+	//------------------------------------------------------------
+	return append(dst, charByte)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Hex_EncodedLen
+//------------------------------------------------------------
+
+func Hex_EncodedLen(n int) int { return n * 2 }
+
+//------------------------------------------------------------
+// Hex_DecodedLen
+//------------------------------------------------------------
+
+func Hex_DecodedLen(n int) int { return n / 2 }
+
+//------------------------------------------------------------
+// Hex_AppendEncode
+//------------------------------------------------------------
+
+// Hex_AppendEncode appends upper case hex in the same way as Hex_encode
+func Hex_AppendEncode(dst, src []byte) []byte {
+	//------------------------------------------------------------
+	dst = growSlice(dst, Hex_EncodedLen(len(src)))
+	//------------------------------------------------------------
+	for _, dataByte := range src {
+		dst = append(dst, hexUpperCharset[dataByte>>4], hexUpperCharset[dataByte&0x0F])
+	}
+	//------------------------------------------------------------
+	return dst
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Hex_AppendDecode
+//------------------------------------------------------------
+
+func Hex_AppendDecode(dst, src []byte) ([]byte, error) {
+	return hex.AppendDecode(dst, src)
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// growSlice
+//------------------------------------------------------------
+
+// growSlice ensures dst has capacity for n more bytes without changing its length
+func growSlice(dst []byte, n int) []byte {
+	//------------------------------------------------------------
+	if cap(dst)-len(dst) >= n {
+		return dst
+	}
+	//------------------------------------------------------------
+	newSlice := make([]byte, len(dst), len(dst)+n)
+	copy(newSlice, dst)
+	//------------------------------------------------------------
+	return newSlice
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
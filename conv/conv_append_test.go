@@ -0,0 +1,255 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"bytes"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type appendTestCase struct {
+	name         string
+	appendEncode func(dst, src []byte) []byte
+	appendDecode func(dst, src []byte) ([]byte, error)
+	encodedLen   func(n int) int
+	encodeFunc   func(string) string
+	exactLen     bool
+}
+
+//------------------------------------------------------------
+
+var appendTestCases = []appendTestCase{
+	{"base", Base_AppendEncode, Base_AppendDecode, Base_EncodedLen, Base_encode, true},
+	{"base64", Base64_AppendEncode, Base64_AppendDecode, Base64_EncodedLen, Base64_encode, true},
+	{"base64url", Base64url_AppendEncode, Base64url_AppendDecode, Base64url_EncodedLen, Base64url_encode, true},
+	{
+		"base91",
+		func(dst, src []byte) []byte { return Base91_AppendEncode(dst, src, false) },
+		func(dst, src []byte) ([]byte, error) { return Base91_AppendDecode(dst, src, false) },
+		Base91_EncodedLen,
+		func(dataString string) string { return Base91_encode(dataString, false) },
+		false,
+	},
+	{
+		"base91 escaped",
+		func(dst, src []byte) []byte { return Base91_AppendEncode(dst, src, true) },
+		func(dst, src []byte) ([]byte, error) { return Base91_AppendDecode(dst, src, true) },
+		nil,
+		func(dataString string) string { return Base91_encode(dataString, true) },
+		false,
+	},
+	{"hex", Hex_AppendEncode, Hex_AppendDecode, Hex_EncodedLen, Hex_encode, true},
+}
+
+//------------------------------------------------------------
+// AppendEncode / AppendDecode
+//------------------------------------------------------------
+
+func TestAppendEncodeDecode(t *testing.T) {
+	//--------------------------------------------------
+	prefix := []byte("prefix:")
+	//--------------------------------------------------
+	for _, testCase := range appendTestCases {
+		for _, dataBytes := range streamTestData() {
+			//--------------------------------------------------
+			expectedString := testCase.encodeFunc(string(dataBytes))
+			//--------------------------------------------------
+			encodedBytes := testCase.appendEncode(append([]byte{}, prefix...), dataBytes)
+			//--------------------------------------------------
+			if string(encodedBytes) != string(prefix)+expectedString {
+				t.Errorf("(%s, %d bytes) encodedBytes = %q but should = %q", testCase.name, len(dataBytes), encodedBytes, string(prefix)+expectedString)
+			}
+			//--------------------------------------------------
+			if testCase.encodedLen != nil {
+				encodedLen := testCase.encodedLen(len(dataBytes))
+				if testCase.exactLen && encodedLen != len(expectedString) || encodedLen < len(expectedString) {
+					t.Errorf("(%s, %d bytes) encodedLen = %d but encoded length = %d", testCase.name, len(dataBytes), encodedLen, len(expectedString))
+				}
+			}
+			//--------------------------------------------------
+			decodedBytes, err := testCase.appendDecode(append([]byte{}, prefix...), []byte(expectedString))
+			//--------------------------------------------------
+			if err != nil {
+				t.Errorf("(%s, %d bytes) %v", testCase.name, len(dataBytes), err)
+			} else if !bytes.Equal(decodedBytes, append(append([]byte{}, prefix...), dataBytes...)) {
+				t.Errorf("(%s, %d bytes) decodedBytes = %q but should = %q", testCase.name, len(dataBytes), decodedBytes, dataBytes)
+			}
+			//--------------------------------------------------
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// AppendDecode invalid data
+//------------------------------------------------------------
+
+func TestAppendDecodeInvalid(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		name         string
+		appendDecode func(dst, src []byte) ([]byte, error)
+		dataString   string
+	}{
+		{"base", Base_AppendDecode, "8x_j)~"},
+		{"base64", Base64_AppendDecode, "QUJD?A=="},
+		{"base64url", Base64url_AppendDecode, "QUJD?A"},
+		{"base91", func(dst, src []byte) ([]byte, error) { return Base91_AppendDecode(dst, src, true) }, "fG^F-x"},
+		{"hex", Hex_AppendDecode, "414"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		if _, err := testCase.appendDecode(nil, []byte(testCase.dataString)); err == nil {
+			t.Errorf("(%s) err = nil for %q but should return an error", testCase.name, testCase.dataString)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base64url_AppendDecode / Base64url_decode
+//------------------------------------------------------------
+
+func TestBase64urlAppendDecodeMatchesDecode(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []string{
+		"",
+		"-_-_",
+		"+/+/",
+		"-_+/",
+		"QUJD",
+		"QUJDRA",
+		"QUJDRA==",
+		"QUJDREU",
+		"QUJDREU=",
+		"QUJDRA=",
+		"QUJDRA===",
+		"QUJDR",
+		"QUJD?A",
+		"QUJD\nRA",
+	}
+	//--------------------------------------------------
+	for _, dataString := range testCases {
+		//--------------------------------------------------
+		expectedString, expectedErr := Base64url_decode(dataString)
+		resultBytes, err := Base64url_AppendDecode(nil, []byte(dataString))
+		//--------------------------------------------------
+		if (err == nil) != (expectedErr == nil) {
+			t.Errorf("(%q) err = %v but should = %v", dataString, err, expectedErr)
+		} else if err == nil && string(resultBytes) != expectedString {
+			t.Errorf("(%q) resultBytes = %q but should = %q", dataString, resultBytes, expectedString)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// AppendEncode allocations
+//------------------------------------------------------------
+
+func TestAppendEncodeAllocs(t *testing.T) {
+	//--------------------------------------------------
+	dataBytes := bytes.Repeat([]byte("ABCD\U0001f427"), 64)
+	dst := make([]byte, 0, 4096)
+	//--------------------------------------------------
+	for _, testCase := range appendTestCases {
+		//--------------------------------------------------
+		allocs := testing.AllocsPerRun(100, func() {
+			testCase.appendEncode(dst[:0], dataBytes)
+		})
+		//--------------------------------------------------
+		if allocs != 0 {
+			t.Errorf("(%s) allocs = %v but should = 0", testCase.name, allocs)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+var benchmarkData = bytes.Repeat([]byte("{\"jsonrpc\":\"2.0\",\"method\":\"echo\",\"params\":[\"\U0001f427\"]}"), 8)
+
+//------------------------------------------------------------
+
+func BenchmarkBase_encode(b *testing.B) {
+	b.ReportAllocs()
+	dataString := string(benchmarkData)
+	for i := 0; i < b.N; i++ {
+		Base_encode(dataString)
+	}
+}
+
+func BenchmarkBase_AppendEncode(b *testing.B) {
+	b.ReportAllocs()
+	dst := make([]byte, 0, Base_EncodedLen(len(benchmarkData)))
+	for i := 0; i < b.N; i++ {
+		dst = Base_AppendEncode(dst[:0], benchmarkData)
+	}
+}
+
+//------------------------------------------------------------
+
+func BenchmarkBase64url_encode(b *testing.B) {
+	b.ReportAllocs()
+	dataString := string(benchmarkData)
+	for i := 0; i < b.N; i++ {
+		Base64url_encode(dataString)
+	}
+}
+
+func BenchmarkBase64url_AppendEncode(b *testing.B) {
+	b.ReportAllocs()
+	dst := make([]byte, 0, Base64url_EncodedLen(len(benchmarkData)))
+	for i := 0; i < b.N; i++ {
+		dst = Base64url_AppendEncode(dst[:0], benchmarkData)
+	}
+}
+
+//------------------------------------------------------------
+
+func BenchmarkBase64url_decode(b *testing.B) {
+	b.ReportAllocs()
+	dataString := Base64url_encode(string(benchmarkData))
+	for i := 0; i < b.N; i++ {
+		_, _ = Base64url_decode(dataString)
+	}
+}
+
+func BenchmarkBase64url_AppendDecode(b *testing.B) {
+	b.ReportAllocs()
+	src := []byte(Base64url_encode(string(benchmarkData)))
+	dst := make([]byte, 0, Base64url_DecodedLen(len(src)))
+	for i := 0; i < b.N; i++ {
+		dst, _ = Base64url_AppendDecode(dst[:0], src)
+	}
+}
+
+//------------------------------------------------------------
+
+func BenchmarkHex_encode(b *testing.B) {
+	b.ReportAllocs()
+	dataString := string(benchmarkData)
+	for i := 0; i < b.N; i++ {
+		Hex_encode(dataString)
+	}
+}
+
+func BenchmarkHex_AppendEncode(b *testing.B) {
+	b.ReportAllocs()
+	dst := make([]byte, 0, Hex_EncodedLen(len(benchmarkData)))
+	for i := 0; i < b.N; i++ {
+		dst = Hex_AppendEncode(dst[:0], benchmarkData)
+	}
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,282 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"encoding/ascii85"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+const Z85_CHARSET = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.-:+=^!/*?&<>()[]{}@%$#"
+
+const ascii85Prefix = "<~"
+
+const ascii85Suffix = "~>"
+
+var z85DecodeMap = newDecodeMap(Z85_CHARSET)
+
+//------------------------------------------------------------
+
+func init() {
+	//------------------------------------------------------------
+	mustRegisterCodec(NewCodec("ascii85",
+		func(dataBytes []byte) ([]byte, error) {
+			return []byte(Ascii85_encode(string(dataBytes), false)), nil
+		},
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := Ascii85_decode(string(dataBytes))
+			return []byte(dataString), err
+		},
+	))
+	//------------------------------------------------------------
+	mustRegisterCodec(NewCodec("z85",
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := Z85_encode(string(dataBytes))
+			return []byte(dataString), err
+		},
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := Z85_decode(string(dataBytes))
+			return []byte(dataString), err
+		},
+	))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Ascii85_encode
+//------------------------------------------------------------
+
+// Ascii85_encode encodes dataString using btoa / Adobe Ascii85 where all-zero groups are written as "z"
+//
+// adobeBool wraps the output in the Adobe "<~" and "~>" delimiters
+func Ascii85_encode(dataString string, adobeBool bool) string {
+	//------------------------------------------------------------
+	if dataString == "" && !adobeBool {
+		return dataString
+	}
+	//------------------------------------------------------------
+	outputBytes := make([]byte, ascii85.MaxEncodedLen(len(dataString)))
+	outputLength := ascii85.Encode(outputBytes, []byte(dataString))
+	//------------------------------------------------------------
+	if adobeBool {
+		return ascii85Prefix + string(outputBytes[:outputLength]) + ascii85Suffix
+	}
+	//------------------------------------------------------------
+	return string(outputBytes[:outputLength])
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Ascii85_decode
+//------------------------------------------------------------
+
+// Ascii85_decode decodes Ascii85 data with or without the Adobe "<~" and "~>" delimiters
+//
+// white space is ignored
+func Ascii85_decode(dataString string) (string, error) {
+	//------------------------------------------------------------
+	dataString = strings.TrimSpace(dataString)
+	//------------------------------------------------------------
+	if dataString == "" {
+		return dataString, nil
+	}
+	//------------------------------------------------------------
+	if strings.HasPrefix(dataString, ascii85Prefix) {
+		if !strings.HasSuffix(dataString, ascii85Suffix) {
+			return "", errors.New("ascii85 data is missing ~> terminator")
+		}
+		dataString = dataString[len(ascii85Prefix):]
+	}
+	dataString = strings.TrimSuffix(dataString, ascii85Suffix)
+	//------------------------------------------------------------
+	outputBytes := make([]byte, 4*len(dataString))
+	//------------------------------------------------------------
+	outputLength, _, err := ascii85.Decode(outputBytes, []byte(dataString), true)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return string(outputBytes[:outputLength]), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Z85_encode
+//------------------------------------------------------------
+
+// Z85_encode encodes dataString using ZeroMQ Z85 (RFC 32/Z85)
+//
+// dataString length must be a multiple of 4 bytes
+func Z85_encode(dataString string) (string, error) {
+	//------------------------------------------------------------
+	if dataString == "" {
+		return dataString, nil
+	}
+	//------------------------------------------------------------
+	if len(dataString)%4 != 0 {
+		return "", errors.New("z85 data length must be a multiple of 4")
+	}
+	//------------------------------------------------------------
+	outputBytes := make([]byte, len(dataString)/4*5)
+	outputIndex := 0
+	//------------------------------------------------------------
+	for dataIndex := 0; dataIndex < len(dataString); dataIndex += 4 {
+		//---------------------------------------------------
+		value := uint32(dataString[dataIndex])<<24 | uint32(dataString[dataIndex+1])<<16 | uint32(dataString[dataIndex+2])<<8 | uint32(dataString[dataIndex+3])
+		//---------------------------------------------------
+		for subIndex := 4; subIndex >= 0; subIndex-- {
+			outputBytes[outputIndex+subIndex] = Z85_CHARSET[value%85]
+			value /= 85
+		}
+		//---------------------------------------------------
+		outputIndex += 5
+		//---------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return string(outputBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Z85_decode
+//------------------------------------------------------------
+
+// Z85_decode decodes ZeroMQ Z85 data
+//
+// dataString length must be a multiple of 5 characters
+func Z85_decode(dataString string) (string, error) {
+	//------------------------------------------------------------
+	if dataString == "" {
+		return dataString, nil
+	}
+	//------------------------------------------------------------
+	if len(dataString)%5 != 0 {
+		return "", errors.New("z85 data length must be a multiple of 5")
+	}
+	//------------------------------------------------------------
+	outputBytes := make([]byte, len(dataString)/5*4)
+	outputIndex := 0
+	//------------------------------------------------------------
+	for dataIndex := 0; dataIndex < len(dataString); dataIndex += 5 {
+		//---------------------------------------------------
+		var value uint64
+		//---------------------------------------------------
+		for subIndex := 0; subIndex < 5; subIndex++ {
+			decodedByte := z85DecodeMap[dataString[dataIndex+subIndex]]
+			if decodedByte == 0xFF {
+				return "", fmt.Errorf("illegal z85 data at input byte %d", dataIndex+subIndex)
+			}
+			value = value*85 + uint64(decodedByte)
+		}
+		//---------------------------------------------------
+		if value > 0xFFFFFFFF {
+			return "", fmt.Errorf("illegal z85 data at input byte %d", dataIndex)
+		}
+		//---------------------------------------------------
+		outputBytes[outputIndex] = byte(value >> 24)
+		outputBytes[outputIndex+1] = byte(value >> 16)
+		outputBytes[outputIndex+2] = byte(value >> 8)
+		outputBytes[outputIndex+3] = byte(value)
+		//---------------------------------------------------
+		outputIndex += 4
+		//---------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return string(outputBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Base_Ascii85
+//------------------------------------------------------------
+
+// Base_Ascii85 converts Base_encode output into Ascii85
+func Base_Ascii85(dataString string, adobeBool bool) (string, error) {
+	//------------------------------------------------------------
+	dataString, err := Base_decode(dataString)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return Ascii85_encode(dataString, adobeBool), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Ascii85_Base
+//------------------------------------------------------------
+
+// Ascii85_Base converts Ascii85 data into Base_encode output
+func Ascii85_Base(dataString string) (string, error) {
+	//------------------------------------------------------------
+	dataString, err := Ascii85_decode(dataString)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return Base_encode(dataString), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base_Z85
+//------------------------------------------------------------
+
+// Base_Z85 converts Base_encode output into Z85
+//
+// the decoded data length must be a multiple of 4 bytes
+func Base_Z85(dataString string) (string, error) {
+	//------------------------------------------------------------
+	dataString, err := Base_decode(dataString)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return Z85_encode(dataString)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Z85_Base
+//------------------------------------------------------------
+
+// Z85_Base converts Z85 data into Base_encode output
+func Z85_Base(dataString string) (string, error) {
+	//------------------------------------------------------------
+	dataString, err := Z85_decode(dataString)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return Base_encode(dataString), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,194 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Ascii85_encode
+//------------------------------------------------------------
+
+func TestAscii85_encode(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		dataString     string
+		adobeBool      bool
+		expectedString string
+	}{
+		{"", false, ""},
+		{"", true, "<~~>"},
+		{"Man is distinguished", false, "9jqo^BlbD-BleB1DJ+*+F(f,q"},
+		{"Man is distinguished", true, "<~9jqo^BlbD-BleB1DJ+*+F(f,q~>"},
+		{"sure.", true, "<~F*2M7/c~>"},
+		{"\x00\x00\x00\x00ABCD", false, "z5sdq,"},
+		{"\x00\x00\x00", false, "!!!!"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		resultString := Ascii85_encode(testCase.dataString, testCase.adobeBool)
+		if resultString != testCase.expectedString {
+			t.Errorf("(%q) resultString = %q but should = %q", testCase.dataString, resultString, testCase.expectedString)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Ascii85_decode
+//------------------------------------------------------------
+
+func TestAscii85_decode(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		dataString     string
+		expectedString string
+		expectedError  string
+	}{
+		{"", "", ""},
+		{"<~~>", "", ""},
+		{"9jqo^BlbD-BleB1DJ+*+F(f,q", "Man is distinguished", ""},
+		{"<~9jqo^BlbD-BleB1DJ+*+F(f,q~>", "Man is distinguished", ""},
+		{"<~9jqo^BlbD-\n  BleB1DJ+*+F(f,q~>\n", "Man is distinguished", ""},
+		{"<~F*2M7/c~>", "sure.", ""},
+		{"z5sdq,", "\x00\x00\x00\x00ABCD", ""},
+		{"<~F*2M7/c", "", "ascii85 data is missing ~> terminator"},
+		{"F*2M\x7f", "", "illegal ascii85 data at input byte 4"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		resultString, err := Ascii85_decode(testCase.dataString)
+		//--------------------------------------------------
+		if resultString != testCase.expectedString {
+			t.Errorf("(%q) resultString = %q but should = %q", testCase.dataString, resultString, testCase.expectedString)
+		}
+		//--------------------------------------------------
+		if err != nil {
+			if err.Error() != testCase.expectedError {
+				t.Errorf("returned error = %q but should = %q", err.Error(), testCase.expectedError)
+			}
+		} else if testCase.expectedError != "" {
+			t.Errorf("returned error is nil but should = %q", testCase.expectedError)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Z85_encode / Z85_decode
+//------------------------------------------------------------
+
+func TestZ85(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		dataString string
+		z85String  string
+	}{
+		{"", ""},
+		{"\x86\x4F\xD2\x6F\xB5\x59\xF7\x5B", "HelloWorld"},
+		{"\x00\x00\x00\x00", "00000"},
+		{"\xFF\xFF\xFF\xFF", "%nSc0"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		resultString, err := Z85_encode(testCase.dataString)
+		if err != nil {
+			t.Error(err)
+		} else if resultString != testCase.z85String {
+			t.Errorf("(%q) resultString = %q but should = %q", testCase.dataString, resultString, testCase.z85String)
+		}
+		//--------------------------------------------------
+		resultString, err = Z85_decode(testCase.z85String)
+		if err != nil {
+			t.Error(err)
+		} else if resultString != testCase.dataString {
+			t.Errorf("(%q) resultString = %q but should = %q", testCase.z85String, resultString, testCase.dataString)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	invalidTestCases := []struct {
+		function      func(string) (string, error)
+		dataString    string
+		expectedError string
+	}{
+		{Z85_encode, "ABC", "z85 data length must be a multiple of 4"},
+		{Z85_decode, "Hello", ""},
+		{Z85_decode, "Hell", "z85 data length must be a multiple of 5"},
+		{Z85_decode, "Hell~", "illegal z85 data at input byte 4"},
+		{Z85_decode, "#####", "illegal z85 data at input byte 0"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range invalidTestCases {
+		//--------------------------------------------------
+		_, err := testCase.function(testCase.dataString)
+		//--------------------------------------------------
+		if err != nil {
+			if err.Error() != testCase.expectedError {
+				t.Errorf("(%q) returned error = %q but should = %q", testCase.dataString, err.Error(), testCase.expectedError)
+			}
+		} else if testCase.expectedError != "" {
+			t.Errorf("(%q) returned error is nil but should = %q", testCase.dataString, testCase.expectedError)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base_Ascii85 / Ascii85_Base / Base_Z85 / Z85_Base
+//------------------------------------------------------------
+
+func TestBaseConversions(t *testing.T) {
+	//--------------------------------------------------
+	dataString := "\x86\x4F\xD2\x6F\xB5\x59\xF7\x5B"
+	baseString := Base_encode(dataString)
+	//--------------------------------------------------
+	resultString, err := Base_Z85(baseString)
+	if err != nil {
+		t.Error(err)
+	} else if resultString != "HelloWorld" {
+		t.Errorf("resultString = %q but should = %q", resultString, "HelloWorld")
+	}
+	//--------------------------------------------------
+	resultString, err = Z85_Base("HelloWorld")
+	if err != nil {
+		t.Error(err)
+	} else if resultString != baseString {
+		t.Errorf("resultString = %q but should = %q", resultString, baseString)
+	}
+	//--------------------------------------------------
+	baseString = Base_encode("Man is distinguished")
+	//--------------------------------------------------
+	resultString, err = Base_Ascii85(baseString, true)
+	if err != nil {
+		t.Error(err)
+	} else if resultString != "<~9jqo^BlbD-BleB1DJ+*+F(f,q~>" {
+		t.Errorf("resultString = %q but should = %q", resultString, "<~9jqo^BlbD-BleB1DJ+*+F(f,q~>")
+	}
+	//--------------------------------------------------
+	resultString, err = Ascii85_Base("<~9jqo^BlbD-BleB1DJ+*+F(f,q~>")
+	if err != nil {
+		t.Error(err)
+	} else if resultString != baseString {
+		t.Errorf("resultString = %q but should = %q", resultString, baseString)
+	}
+	//--------------------------------------------------
+	_, err = Base_Z85(Base_encode("ABC"))
+	if err == nil {
+		t.Error("returned error is nil but Base_Z85 should reject data that is not a multiple of 4 bytes")
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,339 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+const CROCKFORD_CHARSET = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// CROCKFORD_CHECK_CHARSET extends CROCKFORD_CHARSET with the 5 extra check symbols for values 32 to 36
+const CROCKFORD_CHECK_CHARSET = CROCKFORD_CHARSET + "*~$=U"
+
+var crockfordEncoding = base32.NewEncoding(CROCKFORD_CHARSET).WithPadding(base32.NoPadding)
+
+//------------------------------------------------------------
+
+func init() {
+	//------------------------------------------------------------
+	mustRegisterCodec(NewCodec("base32",
+		func(dataBytes []byte) ([]byte, error) {
+			return []byte(Base32_encode(string(dataBytes), true)), nil
+		},
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := Base32_decode(string(dataBytes))
+			return []byte(dataString), err
+		},
+	))
+	//------------------------------------------------------------
+	mustRegisterCodec(NewCodec("base32hex",
+		func(dataBytes []byte) ([]byte, error) {
+			return []byte(Base32hex_encode(string(dataBytes), true)), nil
+		},
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := Base32hex_decode(string(dataBytes))
+			return []byte(dataString), err
+		},
+	))
+	//------------------------------------------------------------
+	mustRegisterCodec(NewCodec("crockford",
+		func(dataBytes []byte) ([]byte, error) {
+			return []byte(Crockford_encode(string(dataBytes), false)), nil
+		},
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := Crockford_decode(string(dataBytes), false)
+			return []byte(dataString), err
+		},
+	))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Base32_encode
+//------------------------------------------------------------
+
+// Base32_encode encodes dataString using the RFC 4648 base32 alphabet
+//
+// paddingBool adds trailing "=" padding characters
+func Base32_encode(dataString string, paddingBool bool) string {
+	//------------------------------------------------------------
+	if dataString == "" {
+		return dataString
+	}
+	//------------------------------------------------------------
+	if paddingBool {
+		return base32.StdEncoding.EncodeToString([]byte(dataString))
+	}
+	//------------------------------------------------------------
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(dataString))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base32_decode
+//------------------------------------------------------------
+
+// Base32_decode decodes padded or unpadded RFC 4648 base32 data in either case
+func Base32_decode(dataString string) (string, error) {
+	//------------------------------------------------------------
+	return base32Decode(base32.StdEncoding, dataString)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base32hex_encode
+//------------------------------------------------------------
+
+// Base32hex_encode encodes dataString using the RFC 4648 "extended hex" base32 alphabet
+//
+// paddingBool adds trailing "=" padding characters
+func Base32hex_encode(dataString string, paddingBool bool) string {
+	//------------------------------------------------------------
+	if dataString == "" {
+		return dataString
+	}
+	//------------------------------------------------------------
+	if paddingBool {
+		return base32.HexEncoding.EncodeToString([]byte(dataString))
+	}
+	//------------------------------------------------------------
+	return base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(dataString))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base32hex_decode
+//------------------------------------------------------------
+
+// Base32hex_decode decodes padded or unpadded RFC 4648 base32hex data in either case
+func Base32hex_decode(dataString string) (string, error) {
+	//------------------------------------------------------------
+	return base32Decode(base32.HexEncoding, dataString)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// base32Decode
+//------------------------------------------------------------
+
+func base32Decode(encoding *base32.Encoding, dataString string) (string, error) {
+	//------------------------------------------------------------
+	if dataString == "" {
+		return dataString, nil
+	}
+	//------------------------------------------------------------
+	dataString = strings.ToUpper(strings.TrimRight(dataString, "="))
+	//------------------------------------------------------------
+	dataBytes, err := encoding.WithPadding(base32.NoPadding).DecodeString(dataString)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return string(dataBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Crockford_encode
+//------------------------------------------------------------
+
+// Crockford_encode encodes dataString using Crockford's base32 alphabet without padding
+//
+// checkBool appends the check symbol for the encoded symbols read as a base 32 number modulo 37,
+// the same symbol Crockford_encodeNumber gives for that number
+func Crockford_encode(dataString string, checkBool bool) string {
+	//------------------------------------------------------------
+	if dataString == "" {
+		return dataString
+	}
+	//------------------------------------------------------------
+	outputString := crockfordEncoding.EncodeToString([]byte(dataString))
+	//------------------------------------------------------------
+	if checkBool {
+		outputString += string(CROCKFORD_CHECK_CHARSET[crockfordCheckValue(outputString)])
+	}
+	//------------------------------------------------------------
+	return outputString
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Crockford_decode
+//------------------------------------------------------------
+
+// Crockford_decode decodes Crockford base32 data
+//
+// decoding is case insensitive, ignores hyphens and reads I and L as 1 and O as 0
+//
+// checkBool verifies and removes the trailing check symbol
+func Crockford_decode(dataString string, checkBool bool) (string, error) {
+	//------------------------------------------------------------
+	if dataString == "" {
+		return dataString, nil
+	}
+	//------------------------------------------------------------
+	dataString = crockfordNormalise(dataString)
+	//------------------------------------------------------------
+	checkSymbol := byte(0)
+	//------------------------------------------------------------
+	if checkBool {
+		if dataString == "" {
+			return "", errors.New("crockford data is missing check symbol")
+		}
+		checkSymbol = dataString[len(dataString)-1]
+		dataString = dataString[:len(dataString)-1]
+	}
+	//------------------------------------------------------------
+	dataBytes, err := crockfordEncoding.DecodeString(dataString)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	if checkBool {
+		if expectedSymbol := CROCKFORD_CHECK_CHARSET[crockfordCheckValue(dataString)]; checkSymbol != expectedSymbol {
+			return "", fmt.Errorf("crockford check symbol %q does not match %q", checkSymbol, expectedSymbol)
+		}
+	}
+	//------------------------------------------------------------
+	return string(dataBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Crockford_encodeNumber
+//------------------------------------------------------------
+
+// Crockford_encodeNumber encodes value as a Crockford base32 number
+//
+// checkBool appends the value modulo 37 check symbol
+func Crockford_encodeNumber(value uint64, checkBool bool) string {
+	//------------------------------------------------------------
+	var outputBytes [14]byte
+	outputIndex := len(outputBytes)
+	//------------------------------------------------------------
+	if checkBool {
+		outputIndex--
+		outputBytes[outputIndex] = CROCKFORD_CHECK_CHARSET[value%37]
+	}
+	//------------------------------------------------------------
+	for {
+		outputIndex--
+		outputBytes[outputIndex] = CROCKFORD_CHARSET[value%32]
+		value /= 32
+		if value == 0 {
+			break
+		}
+	}
+	//------------------------------------------------------------
+	return string(outputBytes[outputIndex:])
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Crockford_decodeNumber
+//------------------------------------------------------------
+
+// Crockford_decodeNumber decodes a Crockford base32 number using the same rules as Crockford_decode
+func Crockford_decodeNumber(dataString string, checkBool bool) (uint64, error) {
+	//------------------------------------------------------------
+	dataString = crockfordNormalise(dataString)
+	//------------------------------------------------------------
+	checkSymbol := byte(0)
+	//------------------------------------------------------------
+	if checkBool && dataString != "" {
+		checkSymbol = dataString[len(dataString)-1]
+		dataString = dataString[:len(dataString)-1]
+	}
+	//------------------------------------------------------------
+	if dataString == "" {
+		return 0, errors.New("crockford data is empty")
+	}
+	//------------------------------------------------------------
+	var value uint64
+	//------------------------------------------------------------
+	for index := 0; index < len(dataString); index++ {
+		//------------------------------------------------------------
+		digit := strings.IndexByte(CROCKFORD_CHARSET, dataString[index])
+		if digit == -1 {
+			return 0, fmt.Errorf("illegal crockford data at input byte %d", index)
+		}
+		//------------------------------------------------------------
+		if value > (^uint64(0)-uint64(digit))/32 {
+			return 0, errors.New("crockford number overflows uint64")
+		}
+		//------------------------------------------------------------
+		value = value*32 + uint64(digit)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if checkBool {
+		if expectedSymbol := CROCKFORD_CHECK_CHARSET[value%37]; checkSymbol != expectedSymbol {
+			return 0, fmt.Errorf("crockford check symbol %q does not match %q", checkSymbol, expectedSymbol)
+		}
+	}
+	//------------------------------------------------------------
+	return value, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// crockfordNormalise
+//------------------------------------------------------------
+
+func crockfordNormalise(dataString string) string {
+	//------------------------------------------------------------
+	replacer := strings.NewReplacer(
+		"-", "",
+		"I", "1",
+		"L", "1",
+		"O", "0",
+	)
+	//------------------------------------------------------------
+	return replacer.Replace(strings.ToUpper(dataString))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// crockfordCheckValue
+//------------------------------------------------------------
+
+// crockfordCheckValue returns the value of the normalised symbols in symbolString modulo 37,
+// symbolString must already have been validated
+func crockfordCheckValue(symbolString string) int {
+	//------------------------------------------------------------
+	checkValue := 0
+	//------------------------------------------------------------
+	for index := 0; index < len(symbolString); index++ {
+		checkValue = (checkValue*32 + strings.IndexByte(CROCKFORD_CHARSET, symbolString[index])) % 37
+	}
+	//------------------------------------------------------------
+	return checkValue
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
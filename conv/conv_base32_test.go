@@ -0,0 +1,211 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Base32_encode / Base32hex_encode
+//------------------------------------------------------------
+
+func TestBase32_encode(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		function       func(string, bool) string
+		dataString     string
+		paddingBool    bool
+		expectedString string
+	}{
+		{Base32_encode, "", true, ""},
+		{Base32_encode, "f", true, "MY======"},
+		{Base32_encode, "foobar", true, "MZXW6YTBOI======"},
+		{Base32_encode, "foobar", false, "MZXW6YTBOI"},
+		{Base32hex_encode, "f", true, "CO======"},
+		{Base32hex_encode, "foobar", true, "CPNMUOJ1E8======"},
+		{Base32hex_encode, "foobar", false, "CPNMUOJ1E8"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		resultString := testCase.function(testCase.dataString, testCase.paddingBool)
+		if resultString != testCase.expectedString {
+			t.Errorf("(%q) resultString = %q but should = %q", testCase.dataString, resultString, testCase.expectedString)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base32_decode / Base32hex_decode
+//------------------------------------------------------------
+
+func TestBase32_decode(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		function       func(string) (string, error)
+		dataString     string
+		expectedString string
+		expectedError  string
+	}{
+		{Base32_decode, "", "", ""},
+		{Base32_decode, "MZXW6YTBOI======", "foobar", ""},
+		{Base32_decode, "MZXW6YTBOI", "foobar", ""},
+		{Base32_decode, "mzxw6ytboi", "foobar", ""},
+		{Base32_decode, "MZXW6YTBO1", "", "illegal base32 data at input byte 9"},
+		{Base32hex_decode, "CPNMUOJ1E8======", "foobar", ""},
+		{Base32hex_decode, "cpnmuoj1e8", "foobar", ""},
+		{Base32hex_decode, "CPNMUOJ1EZ", "", "illegal base32 data at input byte 9"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		resultString, err := testCase.function(testCase.dataString)
+		//--------------------------------------------------
+		if resultString != testCase.expectedString {
+			t.Errorf("(%q) resultString = %q but should = %q", testCase.dataString, resultString, testCase.expectedString)
+		}
+		//--------------------------------------------------
+		if err != nil {
+			if err.Error() != testCase.expectedError {
+				t.Errorf("(%q) returned error = %q but should = %q", testCase.dataString, err.Error(), testCase.expectedError)
+			}
+		} else if testCase.expectedError != "" {
+			t.Errorf("(%q) returned error is nil but should = %q", testCase.dataString, testCase.expectedError)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Crockford_encode / Crockford_decode
+//------------------------------------------------------------
+
+func TestCrockford(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		dataString     string
+		checkBool      bool
+		expectedString string
+	}{
+		{"", false, ""},
+		{"foobar", false, "CSQPYRK1E8"},
+		{"foobar", true, "CSQPYRK1E8R"},
+		{"Hello", true, "91JPRV3FG"},
+		// 40 bits give whole symbols, 1234 is "16J" with check symbol "D" in Crockford's specification
+		{"\x00\x00\x00\x04\xd2", true, "0000016JD"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		resultString := Crockford_encode(testCase.dataString, testCase.checkBool)
+		if resultString != testCase.expectedString {
+			t.Errorf("(%q) resultString = %q but should = %q", testCase.dataString, resultString, testCase.expectedString)
+		}
+		//--------------------------------------------------
+		resultString, err := Crockford_decode(testCase.expectedString, testCase.checkBool)
+		if err != nil {
+			t.Error(err)
+		} else if resultString != testCase.dataString {
+			t.Errorf("(%q) resultString = %q but should = %q", testCase.expectedString, resultString, testCase.dataString)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	decodeTestCases := []struct {
+		dataString     string
+		checkBool      bool
+		expectedString string
+		expectedError  string
+	}{
+		{"csqp-yrki-e8", false, "foobar", ""},
+		{"CSQPYRKLE8-R", true, "foobar", ""},
+		{"9IJPRV3FG", true, "Hello", ""},
+		{"CSQPYRK1E87", true, "", "crockford check symbol '7' does not match 'R'"},
+		{"CSQPYRKUE8", false, "", "illegal base32 data at input byte 7"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range decodeTestCases {
+		//--------------------------------------------------
+		resultString, err := Crockford_decode(testCase.dataString, testCase.checkBool)
+		//--------------------------------------------------
+		if resultString != testCase.expectedString {
+			t.Errorf("(%q) resultString = %q but should = %q", testCase.dataString, resultString, testCase.expectedString)
+		}
+		//--------------------------------------------------
+		if err != nil {
+			if err.Error() != testCase.expectedError {
+				t.Errorf("(%q) returned error = %q but should = %q", testCase.dataString, err.Error(), testCase.expectedError)
+			}
+		} else if testCase.expectedError != "" {
+			t.Errorf("(%q) returned error is nil but should = %q", testCase.dataString, testCase.expectedError)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Crockford_encodeNumber / Crockford_decodeNumber
+//------------------------------------------------------------
+
+func TestCrockfordNumber(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		value          uint64
+		checkBool      bool
+		expectedString string
+	}{
+		{0, false, "0"},
+		{0, true, "00"},
+		{1, false, "1"},
+		{32, true, "10*"},
+		{1234, true, "16JD"},
+		{1234567890, false, "14SC0PJ"},
+		{1234567890, true, "14SC0PJV"},
+		{18446744073709551615, true, "FZZZZZZZZZZZZB"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		resultString := Crockford_encodeNumber(testCase.value, testCase.checkBool)
+		if resultString != testCase.expectedString {
+			t.Errorf("(%d) resultString = %q but should = %q", testCase.value, resultString, testCase.expectedString)
+		}
+		//--------------------------------------------------
+		resultValue, err := Crockford_decodeNumber(testCase.expectedString, testCase.checkBool)
+		if err != nil {
+			t.Error(err)
+		} else if resultValue != testCase.value {
+			t.Errorf("(%q) resultValue = %d but should = %d", testCase.expectedString, resultValue, testCase.value)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	resultValue, err := Crockford_decodeNumber("14sc-0pj-v", true)
+	if err != nil {
+		t.Error(err)
+	} else if resultValue != 1234567890 {
+		t.Errorf("resultValue = %d but should = %d", resultValue, 1234567890)
+	}
+	//--------------------------------------------------
+	for _, dataString := range []string{"", "14SC0PJ*", "14SC0PU", "ZZZZZZZZZZZZZZ"} {
+		if _, err := Crockford_decodeNumber(dataString, dataString == "14SC0PJ*"); err == nil {
+			t.Errorf("(%q) returned error is nil but should return an error", dataString)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,211 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// BASE58_CHARSET is the Bitcoin base58 alphabet
+const BASE58_CHARSET = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58DecodeMap = newDecodeMap(BASE58_CHARSET)
+
+//------------------------------------------------------------
+
+func init() {
+	//------------------------------------------------------------
+	mustRegisterCodec(NewCodec("base58",
+		func(dataBytes []byte) ([]byte, error) {
+			return []byte(Base58_encode(string(dataBytes))), nil
+		},
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := Base58_decode(string(dataBytes))
+			return []byte(dataString), err
+		},
+	))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Base58_encode
+//------------------------------------------------------------
+
+// Base58_encode encodes dataString using the Bitcoin base58 alphabet where each leading zero byte is written as "1"
+func Base58_encode(dataString string) string {
+	//------------------------------------------------------------
+	if dataString == "" {
+		return dataString
+	}
+	//------------------------------------------------------------
+	zeroCount := 0
+	for zeroCount < len(dataString) && dataString[zeroCount] == 0 {
+		zeroCount++
+	}
+	//------------------------------------------------------------
+	// log(256) / log(58) is approximately 1.37
+	digits := make([]byte, 0, (len(dataString)-zeroCount)*138/100+1)
+	//------------------------------------------------------------
+	for index := zeroCount; index < len(dataString); index++ {
+		//------------------------------------------------------------
+		carry := int(dataString[index])
+		//------------------------------------------------------------
+		for digitIndex := range digits {
+			carry += int(digits[digitIndex]) << 8
+			digits[digitIndex] = byte(carry % 58)
+			carry /= 58
+		}
+		//------------------------------------------------------------
+		for carry > 0 {
+			digits = append(digits, byte(carry%58))
+			carry /= 58
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	outputBytes := make([]byte, zeroCount+len(digits))
+	//------------------------------------------------------------
+	for index := 0; index < zeroCount; index++ {
+		outputBytes[index] = BASE58_CHARSET[0]
+	}
+	// digits are stored least significant first
+	for index, digit := range digits {
+		outputBytes[len(outputBytes)-1-index] = BASE58_CHARSET[digit]
+	}
+	//------------------------------------------------------------
+	return string(outputBytes)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base58_decode
+//------------------------------------------------------------
+
+func Base58_decode(dataString string) (string, error) {
+	//------------------------------------------------------------
+	if dataString == "" {
+		return dataString, nil
+	}
+	//------------------------------------------------------------
+	zeroCount := 0
+	for zeroCount < len(dataString) && dataString[zeroCount] == BASE58_CHARSET[0] {
+		zeroCount++
+	}
+	//------------------------------------------------------------
+	// log(58) / log(256) is approximately 0.733
+	values := make([]byte, 0, (len(dataString)-zeroCount)*733/1000+1)
+	//------------------------------------------------------------
+	for index := zeroCount; index < len(dataString); index++ {
+		//------------------------------------------------------------
+		digit := base58DecodeMap[dataString[index]]
+		if digit == 0xFF {
+			return "", fmt.Errorf("illegal base58 data at input byte %d", index)
+		}
+		//------------------------------------------------------------
+		carry := int(digit)
+		//------------------------------------------------------------
+		for valueIndex := range values {
+			carry += int(values[valueIndex]) * 58
+			values[valueIndex] = byte(carry)
+			carry >>= 8
+		}
+		//------------------------------------------------------------
+		for carry > 0 {
+			values = append(values, byte(carry))
+			carry >>= 8
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	outputBytes := make([]byte, zeroCount+len(values))
+	//------------------------------------------------------------
+	// values are stored least significant first
+	for index, value := range values {
+		outputBytes[len(outputBytes)-1-index] = value
+	}
+	//------------------------------------------------------------
+	return string(outputBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Base58Check_encode
+//------------------------------------------------------------
+
+// Base58Check_encode prefixes dataString with the version byte and appends a 4 byte double SHA-256 checksum before base58 encoding
+func Base58Check_encode(dataString string, version byte) string {
+	//------------------------------------------------------------
+	payloadBytes := make([]byte, 0, 1+len(dataString)+4)
+	payloadBytes = append(payloadBytes, version)
+	payloadBytes = append(payloadBytes, dataString...)
+	payloadBytes = append(payloadBytes, base58Checksum(payloadBytes)...)
+	//------------------------------------------------------------
+	return Base58_encode(string(payloadBytes))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base58Check_decode
+//------------------------------------------------------------
+
+// Base58Check_decode verifies the checksum and returns the data and version byte
+func Base58Check_decode(dataString string) (string, byte, error) {
+	//------------------------------------------------------------
+	payloadString, err := Base58_decode(dataString)
+	if err != nil {
+		return "", 0, err
+	}
+	//------------------------------------------------------------
+	if len(payloadString) < 5 {
+		return "", 0, errors.New("invalid base58check data length")
+	}
+	//------------------------------------------------------------
+	payloadBytes := []byte(payloadString)
+	checksumIndex := len(payloadBytes) - 4
+	//------------------------------------------------------------
+	if !bytes.Equal(base58Checksum(payloadBytes[:checksumIndex]), payloadBytes[checksumIndex:]) {
+		return "", 0, errors.New("invalid base58check checksum")
+	}
+	//------------------------------------------------------------
+	return string(payloadBytes[1:checksumIndex]), payloadBytes[0], nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// base58Checksum
+//------------------------------------------------------------
+
+func base58Checksum(payloadBytes []byte) []byte {
+	//------------------------------------------------------------
+	firstHash := sha256.Sum256(payloadBytes)
+	secondHash := sha256.Sum256(firstHash[:])
+	//------------------------------------------------------------
+	return secondHash[:4]
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
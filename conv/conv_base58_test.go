@@ -0,0 +1,93 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Base58_encode / Base58_decode
+//------------------------------------------------------------
+
+func TestBase58(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		dataString     string
+		expectedString string
+	}{
+		{"", ""},
+		{"\x00", "1"},
+		{"\x00\x00\x28\x7f\xb4\xcd", "11233QC4"},
+		{"Hello World!", "2NEpo7TZRRrLZSi2U"},
+		{"\U0001f427", "79jdDG"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		resultString := Base58_encode(testCase.dataString)
+		if resultString != testCase.expectedString {
+			t.Errorf("(%q) resultString = %q but should = %q", testCase.dataString, resultString, testCase.expectedString)
+		}
+		//--------------------------------------------------
+		resultString, err := Base58_decode(testCase.expectedString)
+		if err != nil {
+			t.Error(err)
+		} else if resultString != testCase.dataString {
+			t.Errorf("(%q) resultString = %q but should = %q", testCase.expectedString, resultString, testCase.dataString)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	_, err := Base58_decode("2NEpo7TZRRrLZSi2O")
+	if err == nil || err.Error() != "illegal base58 data at input byte 16" {
+		t.Errorf("returned error = %v but should = %q", err, "illegal base58 data at input byte 16")
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Base58Check_encode / Base58Check_decode
+//------------------------------------------------------------
+
+func TestBase58Check(t *testing.T) {
+	//--------------------------------------------------
+	dataString, _ := Hex_decode("010966776006953D5567439E5E39F86A0D273BEE")
+	addressString := "16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvM"
+	//--------------------------------------------------
+	resultString := Base58Check_encode(dataString, 0)
+	if resultString != addressString {
+		t.Errorf("resultString = %q but should = %q", resultString, addressString)
+	}
+	//--------------------------------------------------
+	resultString, version, err := Base58Check_decode(addressString)
+	if err != nil {
+		t.Error(err)
+	} else {
+		if resultString != dataString {
+			t.Errorf("resultString = %q but should = %q", resultString, dataString)
+		}
+		if version != 0 {
+			t.Errorf("version = %d but should = %d", version, 0)
+		}
+	}
+	//--------------------------------------------------
+	_, _, err = Base58Check_decode("16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvN")
+	if err == nil || err.Error() != "invalid base58check checksum" {
+		t.Errorf("returned error = %v but should = %q", err, "invalid base58check checksum")
+	}
+	//--------------------------------------------------
+	_, _, err = Base58Check_decode("2NEp")
+	if err == nil || err.Error() != "invalid base58check data length" {
+		t.Errorf("returned error = %v but should = %q", err, "invalid base58check data length")
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
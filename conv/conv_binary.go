@@ -0,0 +1,706 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// binaryMaxDepth limits nesting so hostile input cannot exhaust the stack
+const binaryMaxDepth = 512
+
+var errBinaryTruncated = errors.New("binary data is truncated")
+
+var timeType = reflect.TypeOf(time.Time{})
+
+var jsonNumberType = reflect.TypeOf(json.Number(""))
+
+//------------------------------------------------------------
+
+// binaryFormat is implemented by the MessagePack and CBOR encoders so they can share the reflection code
+type binaryFormat interface {
+	tagName() string
+	appendNil(dst []byte) []byte
+	appendBool(dst []byte, value bool) []byte
+	appendInt(dst []byte, value int64) []byte
+	appendUint(dst []byte, value uint64) []byte
+	appendFloat(dst []byte, value float64, bitSize int) []byte
+	appendString(dst []byte, value string) []byte
+	appendBytes(dst []byte, value []byte) []byte
+	appendTime(dst []byte, value time.Time) []byte
+	appendArrayHeader(dst []byte, length int) []byte
+	appendMapHeader(dst []byte, length int) []byte
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// binaryAppendValue
+//------------------------------------------------------------
+
+// binaryAppendValue encodes value with map and struct keys sorted by their encoded bytes so the output is deterministic
+func binaryAppendValue(format binaryFormat, dst []byte, value reflect.Value, depth int) ([]byte, error) {
+	//------------------------------------------------------------
+	if depth > binaryMaxDepth {
+		return nil, errors.New("binary encoding exceeds maximum nesting depth")
+	}
+	//------------------------------------------------------------
+	if !value.IsValid() {
+		return format.appendNil(dst), nil
+	}
+	//------------------------------------------------------------
+	switch value.Type() {
+	case timeType:
+		return format.appendTime(dst, value.Interface().(time.Time)), nil
+	case jsonNumberType:
+		numberString := value.String()
+		if intValue, err := strconv.ParseInt(numberString, 10, 64); err == nil {
+			return format.appendInt(dst, intValue), nil
+		}
+		floatValue, err := strconv.ParseFloat(numberString, 64)
+		if err != nil {
+			return nil, err
+		}
+		return format.appendFloat(dst, floatValue, 64), nil
+	}
+	//------------------------------------------------------------
+	switch value.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if value.IsNil() {
+			return format.appendNil(dst), nil
+		}
+		return binaryAppendValue(format, dst, value.Elem(), depth+1)
+	case reflect.Bool:
+		return format.appendBool(dst, value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return format.appendInt(dst, value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return format.appendUint(dst, value.Uint()), nil
+	case reflect.Float32:
+		return format.appendFloat(dst, value.Float(), 32), nil
+	case reflect.Float64:
+		return format.appendFloat(dst, value.Float(), 64), nil
+	case reflect.String:
+		return format.appendString(dst, value.String()), nil
+	case reflect.Slice, reflect.Array:
+		//------------------------------------------------------------
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return format.appendNil(dst), nil
+		}
+		//------------------------------------------------------------
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			byteSlice := make([]byte, value.Len())
+			reflect.Copy(reflect.ValueOf(byteSlice), value)
+			return format.appendBytes(dst, byteSlice), nil
+		}
+		//------------------------------------------------------------
+		var err error
+		//------------------------------------------------------------
+		dst = format.appendArrayHeader(dst, value.Len())
+		//------------------------------------------------------------
+		for index := 0; index < value.Len(); index++ {
+			dst, err = binaryAppendValue(format, dst, value.Index(index), depth+1)
+			if err != nil {
+				return nil, err
+			}
+		}
+		//------------------------------------------------------------
+		return dst, nil
+		//------------------------------------------------------------
+	case reflect.Map:
+		//------------------------------------------------------------
+		if value.IsNil() {
+			return format.appendNil(dst), nil
+		}
+		//------------------------------------------------------------
+		pairs := make([][2][]byte, 0, value.Len())
+		//------------------------------------------------------------
+		iterator := value.MapRange()
+		//------------------------------------------------------------
+		for iterator.Next() {
+			//------------------------------------------------------------
+			keyBytes, err := binaryAppendKey(format, nil, iterator.Key())
+			if err != nil {
+				return nil, err
+			}
+			//------------------------------------------------------------
+			valueBytes, err := binaryAppendValue(format, nil, iterator.Value(), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			//------------------------------------------------------------
+			pairs = append(pairs, [2][]byte{keyBytes, valueBytes})
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+		return binaryAppendPairs(format, dst, pairs), nil
+		//------------------------------------------------------------
+	case reflect.Struct:
+		//------------------------------------------------------------
+		fields := binaryStructFields(value.Type(), format.tagName())
+		pairs := make([][2][]byte, 0, len(fields))
+		//------------------------------------------------------------
+		for _, field := range fields {
+			//------------------------------------------------------------
+			fieldValue := value.FieldByIndex(field.index)
+			//------------------------------------------------------------
+			if field.omitEmpty && fieldValue.IsZero() {
+				continue
+			}
+			//------------------------------------------------------------
+			valueBytes, err := binaryAppendValue(format, nil, fieldValue, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			//------------------------------------------------------------
+			pairs = append(pairs, [2][]byte{format.appendString(nil, field.name), valueBytes})
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+		return binaryAppendPairs(format, dst, pairs), nil
+		//------------------------------------------------------------
+	default:
+		return nil, fmt.Errorf("unsupported binary encoding type %s", value.Type())
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// binaryAppendKey
+//------------------------------------------------------------
+
+func binaryAppendKey(format binaryFormat, dst []byte, key reflect.Value) ([]byte, error) {
+	//------------------------------------------------------------
+	switch key.Kind() {
+	case reflect.String:
+		return format.appendString(dst, key.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return format.appendInt(dst, key.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return format.appendUint(dst, key.Uint()), nil
+	case reflect.Interface:
+		if !key.IsNil() {
+			return binaryAppendKey(format, dst, key.Elem())
+		}
+	}
+	//------------------------------------------------------------
+	return nil, fmt.Errorf("unsupported binary encoding map key type %s", key.Type())
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// binaryAppendPairs
+//------------------------------------------------------------
+
+func binaryAppendPairs(format binaryFormat, dst []byte, pairs [][2][]byte) []byte {
+	//------------------------------------------------------------
+	sort.Slice(pairs, func(index1 int, index2 int) bool {
+		return bytes.Compare(pairs[index1][0], pairs[index2][0]) < 0
+	})
+	//------------------------------------------------------------
+	dst = format.appendMapHeader(dst, len(pairs))
+	//------------------------------------------------------------
+	for _, pair := range pairs {
+		dst = append(dst, pair[0]...)
+		dst = append(dst, pair[1]...)
+	}
+	//------------------------------------------------------------
+	return dst
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type binaryField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+type binaryFieldsKey struct {
+	structType reflect.Type
+	tagName    string
+}
+
+var binaryFieldsCache sync.Map
+
+//------------------------------------------------------------
+// binaryStructFields
+//------------------------------------------------------------
+
+// binaryStructFields lists the encoded fields of structType
+//
+// the format tag (e.g. `msgpack:"name,omitempty"`) is used first, then the json tag and then the field name
+func binaryStructFields(structType reflect.Type, tagName string) []binaryField {
+	//------------------------------------------------------------
+	cacheKey := binaryFieldsKey{structType, tagName}
+	//------------------------------------------------------------
+	if fields, ok := binaryFieldsCache.Load(cacheKey); ok {
+		return fields.([]binaryField)
+	}
+	//------------------------------------------------------------
+	fields := binaryCollectFields(structType, tagName, nil)
+	//------------------------------------------------------------
+	binaryFieldsCache.Store(cacheKey, fields)
+	//------------------------------------------------------------
+	return fields
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// binaryCollectFields
+//------------------------------------------------------------
+
+func binaryCollectFields(structType reflect.Type, tagName string, parentIndex []int) []binaryField {
+	//------------------------------------------------------------
+	var fields []binaryField
+	var embeddedFields []binaryField
+	//------------------------------------------------------------
+	nameMap := map[string]bool{}
+	//------------------------------------------------------------
+	for index := 0; index < structType.NumField(); index++ {
+		//------------------------------------------------------------
+		structField := structType.Field(index)
+		//------------------------------------------------------------
+		tag, ok := structField.Tag.Lookup(tagName)
+		if !ok {
+			tag = structField.Tag.Get("json")
+		}
+		if tag == "-" {
+			continue
+		}
+		//------------------------------------------------------------
+		name, tagOptions, _ := strings.Cut(tag, ",")
+		//------------------------------------------------------------
+		fieldIndex := append(append([]int{}, parentIndex...), index)
+		//------------------------------------------------------------
+		// exported fields of unexported embedded structs stay settable so they are promoted like encoding/json
+		if !structField.IsExported() && !(structField.Anonymous && name == "" && structField.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		//------------------------------------------------------------
+		// promote the fields of untagged embedded structs after the outer fields so the outer names win
+		if structField.Anonymous && name == "" && structField.Type.Kind() == reflect.Struct {
+			embeddedFields = append(embeddedFields, binaryCollectFields(structField.Type, tagName, fieldIndex)...)
+			continue
+		}
+		//------------------------------------------------------------
+		if name == "" {
+			name = structField.Name
+		}
+		//------------------------------------------------------------
+		nameMap[name] = true
+		//------------------------------------------------------------
+		fields = append(fields, binaryField{
+			name:      name,
+			index:     fieldIndex,
+			omitEmpty: strings.Contains(","+tagOptions+",", ",omitempty,"),
+		})
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	for _, field := range embeddedFields {
+		if !nameMap[field.name] {
+			nameMap[field.name] = true
+			fields = append(fields, field)
+		}
+	}
+	//------------------------------------------------------------
+	return fields
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// binaryAssign
+//------------------------------------------------------------
+
+// binaryAssign stores a decoded MessagePack or CBOR value in target converting numbers, maps and arrays as needed
+func binaryAssign(target reflect.Value, value any, tagName string) error {
+	//------------------------------------------------------------
+	if value == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	//------------------------------------------------------------
+	if target.Kind() == reflect.Interface && target.NumMethod() == 0 {
+		target.Set(reflect.ValueOf(value))
+		return nil
+	}
+	//------------------------------------------------------------
+	if target.Kind() == reflect.Pointer {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return binaryAssign(target.Elem(), value, tagName)
+	}
+	//------------------------------------------------------------
+	typeError := fmt.Errorf("cannot decode %T into %s", value, target.Type())
+	//------------------------------------------------------------
+	if target.Type() == timeType {
+		switch typedValue := value.(type) {
+		case time.Time:
+			target.Set(reflect.ValueOf(typedValue))
+			return nil
+		case string:
+			timeValue, err := time.Parse(time.RFC3339Nano, typedValue)
+			if err != nil {
+				return err
+			}
+			target.Set(reflect.ValueOf(timeValue))
+			return nil
+		}
+		return typeError
+	}
+	//------------------------------------------------------------
+	switch target.Kind() {
+	case reflect.Bool:
+		//------------------------------------------------------------
+		boolValue, ok := value.(bool)
+		if !ok {
+			return typeError
+		}
+		target.SetBool(boolValue)
+		//------------------------------------------------------------
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		//------------------------------------------------------------
+		var intValue int64
+		//------------------------------------------------------------
+		switch typedValue := value.(type) {
+		case int64:
+			intValue = typedValue
+		case uint64:
+			if typedValue > math.MaxInt64 {
+				return fmt.Errorf("value %d overflows %s", typedValue, target.Type())
+			}
+			intValue = int64(typedValue)
+		case float64:
+			if typedValue != math.Trunc(typedValue) || typedValue < math.MinInt64 || typedValue >= math.MaxInt64 {
+				return typeError
+			}
+			intValue = int64(typedValue)
+		default:
+			return typeError
+		}
+		//------------------------------------------------------------
+		if target.OverflowInt(intValue) {
+			return fmt.Errorf("value %d overflows %s", intValue, target.Type())
+		}
+		target.SetInt(intValue)
+		//------------------------------------------------------------
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		//------------------------------------------------------------
+		var uintValue uint64
+		//------------------------------------------------------------
+		switch typedValue := value.(type) {
+		case int64:
+			if typedValue < 0 {
+				return fmt.Errorf("value %d overflows %s", typedValue, target.Type())
+			}
+			uintValue = uint64(typedValue)
+		case uint64:
+			uintValue = typedValue
+		case float64:
+			if typedValue != math.Trunc(typedValue) || typedValue < 0 || typedValue >= math.MaxUint64 {
+				return typeError
+			}
+			uintValue = uint64(typedValue)
+		default:
+			return typeError
+		}
+		//------------------------------------------------------------
+		if target.OverflowUint(uintValue) {
+			return fmt.Errorf("value %d overflows %s", uintValue, target.Type())
+		}
+		target.SetUint(uintValue)
+		//------------------------------------------------------------
+	case reflect.Float32, reflect.Float64:
+		//------------------------------------------------------------
+		switch typedValue := value.(type) {
+		case float64:
+			target.SetFloat(typedValue)
+		case int64:
+			target.SetFloat(float64(typedValue))
+		case uint64:
+			target.SetFloat(float64(typedValue))
+		default:
+			return typeError
+		}
+		//------------------------------------------------------------
+	case reflect.String:
+		//------------------------------------------------------------
+		switch typedValue := value.(type) {
+		case string:
+			target.SetString(typedValue)
+		case []byte:
+			target.SetString(string(typedValue))
+		default:
+			return typeError
+		}
+		//------------------------------------------------------------
+	case reflect.Slice, reflect.Array:
+		//------------------------------------------------------------
+		var elements []any
+		//------------------------------------------------------------
+		switch typedValue := value.(type) {
+		case []byte:
+			if target.Type().Elem().Kind() != reflect.Uint8 {
+				return typeError
+			}
+			for _, byteValue := range typedValue {
+				elements = append(elements, uint64(byteValue))
+			}
+		case string:
+			if target.Type().Elem().Kind() != reflect.Uint8 {
+				return typeError
+			}
+			for index := 0; index < len(typedValue); index++ {
+				elements = append(elements, uint64(typedValue[index]))
+			}
+		case []any:
+			elements = typedValue
+		default:
+			return typeError
+		}
+		//------------------------------------------------------------
+		if target.Kind() == reflect.Slice {
+			target.Set(reflect.MakeSlice(target.Type(), len(elements), len(elements)))
+		} else if len(elements) > target.Len() {
+			return fmt.Errorf("cannot decode %d elements into %s", len(elements), target.Type())
+		} else {
+			target.Set(reflect.Zero(target.Type()))
+		}
+		//------------------------------------------------------------
+		for index, element := range elements {
+			if err := binaryAssign(target.Index(index), element, tagName); err != nil {
+				return err
+			}
+		}
+		//------------------------------------------------------------
+	case reflect.Map:
+		//------------------------------------------------------------
+		mapValue, ok := value.(map[string]any)
+		if !ok {
+			return typeError
+		}
+		//------------------------------------------------------------
+		if target.IsNil() {
+			target.Set(reflect.MakeMapWithSize(target.Type(), len(mapValue)))
+		}
+		//------------------------------------------------------------
+		keyType := target.Type().Key()
+		//------------------------------------------------------------
+		for key, element := range mapValue {
+			//------------------------------------------------------------
+			keyValue := reflect.New(keyType).Elem()
+			//------------------------------------------------------------
+			switch keyType.Kind() {
+			case reflect.String:
+				keyValue.SetString(key)
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				intKey, err := strconv.ParseInt(key, 10, keyType.Bits())
+				if err != nil {
+					return err
+				}
+				keyValue.SetInt(intKey)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				uintKey, err := strconv.ParseUint(key, 10, keyType.Bits())
+				if err != nil {
+					return err
+				}
+				keyValue.SetUint(uintKey)
+			default:
+				return typeError
+			}
+			//------------------------------------------------------------
+			elementValue := reflect.New(target.Type().Elem()).Elem()
+			//------------------------------------------------------------
+			if err := binaryAssign(elementValue, element, tagName); err != nil {
+				return err
+			}
+			//------------------------------------------------------------
+			target.SetMapIndex(keyValue, elementValue)
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+	case reflect.Struct:
+		//------------------------------------------------------------
+		mapValue, ok := value.(map[string]any)
+		if !ok {
+			return typeError
+		}
+		//------------------------------------------------------------
+		for _, field := range binaryStructFields(target.Type(), tagName) {
+			if element, exists := mapValue[field.name]; exists {
+				if err := binaryAssign(target.FieldByIndex(field.index), element, tagName); err != nil {
+					return fmt.Errorf("%s: %w", field.name, err)
+				}
+			}
+		}
+		//------------------------------------------------------------
+	default:
+		return typeError
+	}
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// binaryReader reads from decoded data checking every length against the data left
+type binaryReader struct {
+	data   []byte
+	offset int
+}
+
+//------------------------------------------------------------
+// binaryReader.read
+//------------------------------------------------------------
+
+func (reader *binaryReader) read(length uint64) ([]byte, error) {
+	//------------------------------------------------------------
+	if length > uint64(len(reader.data)-reader.offset) {
+		return nil, errBinaryTruncated
+	}
+	//------------------------------------------------------------
+	dataBytes := reader.data[reader.offset : reader.offset+int(length)]
+	reader.offset += int(length)
+	//------------------------------------------------------------
+	return dataBytes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// binaryReader.readByte
+//------------------------------------------------------------
+
+func (reader *binaryReader) readByte() (byte, error) {
+	//------------------------------------------------------------
+	if reader.offset >= len(reader.data) {
+		return 0, errBinaryTruncated
+	}
+	//------------------------------------------------------------
+	reader.offset++
+	//------------------------------------------------------------
+	return reader.data[reader.offset-1], nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// binaryReader.readUint
+//------------------------------------------------------------
+
+// readUint reads a big-endian unsigned integer of size bytes
+func (reader *binaryReader) readUint(size int) (uint64, error) {
+	//------------------------------------------------------------
+	dataBytes, err := reader.read(uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	//------------------------------------------------------------
+	var value uint64
+	for _, dataByte := range dataBytes {
+		value = value<<8 | uint64(dataByte)
+	}
+	//------------------------------------------------------------
+	return value, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// binaryMapKey
+//------------------------------------------------------------
+
+// binaryMapKey converts decoded map keys to strings to match the JSON_decode map[string]any shape,
+// a key already in mapValue is an error so duplicates and keys such as 1 and "1" cannot overwrite each other
+func binaryMapKey(mapValue map[string]any, key any) (string, error) {
+	//------------------------------------------------------------
+	var keyString string
+	//------------------------------------------------------------
+	switch typedKey := key.(type) {
+	case string:
+		keyString = typedKey
+	case []byte:
+		keyString = string(typedKey)
+	case int64, uint64, bool:
+		keyString = fmt.Sprint(typedKey)
+	case float64:
+		keyString = strconv.FormatFloat(typedKey, 'f', -1, 64)
+	default:
+		return "", fmt.Errorf("unsupported map key type %T", key)
+	}
+	//------------------------------------------------------------
+	if _, exists := mapValue[keyString]; exists {
+		return "", fmt.Errorf("duplicate map key %q", keyString)
+	}
+	//------------------------------------------------------------
+	return keyString, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// binaryIntValue
+//------------------------------------------------------------
+
+// binaryIntValue returns int64 where the value fits, otherwise uint64
+func binaryIntValue(value uint64) any {
+	//------------------------------------------------------------
+	if value > math.MaxInt64 {
+		return value
+	}
+	//------------------------------------------------------------
+	return int64(value)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// binaryDecodeInto
+//------------------------------------------------------------
+
+func binaryDecodeInto[T any](value any, tagName string) (T, error) {
+	//------------------------------------------------------------
+	var result T
+	//------------------------------------------------------------
+	if err := binaryAssign(reflect.ValueOf(&result).Elem(), value, tagName); err != nil {
+		var zero T
+		return zero, err
+	}
+	//------------------------------------------------------------
+	return result, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,553 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+const (
+	cborMajorUint   = 0
+	cborMajorNegint = 1
+	cborMajorBytes  = 2
+	cborMajorText   = 3
+	cborMajorArray  = 4
+	cborMajorMap    = 5
+	cborMajorTag    = 6
+	cborMajorSimple = 7
+)
+
+// cborIndefinite is the additional information value for indefinite length items
+const cborIndefinite = 31
+
+const cborBreak = 0xff
+
+type cborFormat struct{}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// CBOR_Marshal
+//------------------------------------------------------------
+
+// CBOR_Marshal encodes value as CBOR (RFC 8949) using the core deterministic encoding rules
+//
+// integers and lengths use the shortest form, floats use the shortest of half, single and double
+// precision that keeps the value and map keys are sorted by their encoded bytes
+//
+// struct fields use the cbor tag, then the json tag and then the field name and
+// time.Time values are written as tag 0 RFC 3339 strings
+func CBOR_Marshal(value any) ([]byte, error) {
+	//------------------------------------------------------------
+	return binaryAppendValue(cborFormat{}, nil, reflect.ValueOf(value), 0)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CBOR_Unmarshal
+//------------------------------------------------------------
+
+// CBOR_Unmarshal decodes CBOR into the same shapes as JSON_decode
+//
+// maps become map[string]any, arrays []any, integers int64 (or uint64 when too large),
+// floats float64, byte strings []byte and tag 0 and 1 times time.Time
+//
+// other tags are ignored and their content is returned
+func CBOR_Unmarshal(data []byte) (any, error) {
+	//------------------------------------------------------------
+	reader := &binaryReader{data: data}
+	//------------------------------------------------------------
+	value, err := cborDecodeValue(reader, 0)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if reader.offset != len(data) {
+		return nil, fmt.Errorf("cbor data has %d bytes of trailing data", len(data)-reader.offset)
+	}
+	//------------------------------------------------------------
+	return value, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CBOR_DecodeInto
+//------------------------------------------------------------
+
+// CBOR_DecodeInto decodes CBOR into a value of type T such as a tagged struct
+func CBOR_DecodeInto[T any](data []byte) (T, error) {
+	//------------------------------------------------------------
+	value, err := CBOR_Unmarshal(data)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	//------------------------------------------------------------
+	return binaryDecodeInto[T](value, cborFormat{}.tagName())
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+func (cborFormat) tagName() string { return "cbor" }
+
+func (cborFormat) appendNil(dst []byte) []byte { return append(dst, 0xf6) }
+
+func (cborFormat) appendBool(dst []byte, value bool) []byte {
+	if value {
+		return append(dst, 0xf5)
+	}
+	return append(dst, 0xf4)
+}
+
+func (cborFormat) appendInt(dst []byte, value int64) []byte {
+	if value < 0 {
+		return cborAppendHead(dst, cborMajorNegint, uint64(-1-value))
+	}
+	return cborAppendHead(dst, cborMajorUint, uint64(value))
+}
+
+func (cborFormat) appendUint(dst []byte, value uint64) []byte {
+	return cborAppendHead(dst, cborMajorUint, value)
+}
+
+func (cborFormat) appendString(dst []byte, value string) []byte {
+	return append(cborAppendHead(dst, cborMajorText, uint64(len(value))), value...)
+}
+
+func (cborFormat) appendBytes(dst []byte, value []byte) []byte {
+	return append(cborAppendHead(dst, cborMajorBytes, uint64(len(value))), value...)
+}
+
+func (format cborFormat) appendTime(dst []byte, value time.Time) []byte {
+	return format.appendString(cborAppendHead(dst, cborMajorTag, 0), value.Format(time.RFC3339Nano))
+}
+
+func (cborFormat) appendArrayHeader(dst []byte, length int) []byte {
+	return cborAppendHead(dst, cborMajorArray, uint64(length))
+}
+
+func (cborFormat) appendMapHeader(dst []byte, length int) []byte {
+	return cborAppendHead(dst, cborMajorMap, uint64(length))
+}
+
+//------------------------------------------------------------
+
+// appendFloat writes the shortest float that decodes to the same value, NaN is always written as 0xf97e00
+func (cborFormat) appendFloat(dst []byte, value float64, bitSize int) []byte {
+	//------------------------------------------------------------
+	if math.IsNaN(value) {
+		return append(dst, 0xf9, 0x7e, 0x00)
+	}
+	//------------------------------------------------------------
+	float32Value := float32(value)
+	//------------------------------------------------------------
+	if float64(float32Value) != value {
+		return binary.BigEndian.AppendUint64(append(dst, 0xfb), math.Float64bits(value))
+	}
+	//------------------------------------------------------------
+	if halfBits, ok := cborFloat16Bits(float32Value); ok {
+		return binary.BigEndian.AppendUint16(append(dst, 0xf9), halfBits)
+	}
+	//------------------------------------------------------------
+	return binary.BigEndian.AppendUint32(append(dst, 0xfa), math.Float32bits(float32Value))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// cborAppendHead
+//------------------------------------------------------------
+
+func cborAppendHead(dst []byte, major byte, value uint64) []byte {
+	//------------------------------------------------------------
+	major <<= 5
+	//------------------------------------------------------------
+	switch {
+	case value < 24:
+		return append(dst, major|byte(value))
+	case value <= math.MaxUint8:
+		return append(dst, major|24, byte(value))
+	case value <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, major|25), uint16(value))
+	case value <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(dst, major|26), uint32(value))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, major|27), value)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// cborFloat16Bits
+//------------------------------------------------------------
+
+// cborFloat16Bits returns the IEEE 754 half precision bits for value if the conversion is exact
+func cborFloat16Bits(value float32) (uint16, bool) {
+	//------------------------------------------------------------
+	bits := math.Float32bits(value)
+	//------------------------------------------------------------
+	sign := uint16(bits>>16) & 0x8000
+	exponent := int(bits>>23&0xff) - 127
+	mantissa := bits & 0x7fffff
+	//------------------------------------------------------------
+	switch {
+	case bits&0x7fffffff == 0:
+		return sign, true
+	case exponent == 128:
+		// infinity, NaN is handled by the caller
+		return sign | 0x7c00, mantissa == 0
+	case exponent >= -14 && exponent <= 15:
+		if mantissa&0x1fff != 0 {
+			return 0, false
+		}
+		return sign | uint16(exponent+15)<<10 | uint16(mantissa>>13), true
+	case exponent >= -24 && exponent < -14:
+		// subnormal half precision values are multiples of 2^-24
+		shift := uint(-exponent - 14 + 13)
+		fullMantissa := mantissa | 0x800000
+		if fullMantissa&(1<<shift-1) != 0 {
+			return 0, false
+		}
+		return sign | uint16(fullMantissa>>shift), true
+	default:
+		return 0, false
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// cborFloat16Value
+//------------------------------------------------------------
+
+func cborFloat16Value(bits uint16) float64 {
+	//------------------------------------------------------------
+	exponent := int(bits>>10) & 0x1f
+	mantissa := float64(bits & 0x3ff)
+	//------------------------------------------------------------
+	var value float64
+	//------------------------------------------------------------
+	switch exponent {
+	case 0:
+		value = math.Ldexp(mantissa, -24)
+	case 31:
+		if mantissa == 0 {
+			value = math.Inf(1)
+		} else {
+			value = math.NaN()
+		}
+	default:
+		value = math.Ldexp(mantissa+1024, exponent-25)
+	}
+	//------------------------------------------------------------
+	if bits&0x8000 != 0 {
+		return -value
+	}
+	//------------------------------------------------------------
+	return value
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// cborDecodeValue
+//------------------------------------------------------------
+
+func cborDecodeValue(reader *binaryReader, depth int) (any, error) {
+	//------------------------------------------------------------
+	if depth > binaryMaxDepth {
+		return nil, errors.New("cbor data exceeds maximum nesting depth")
+	}
+	//------------------------------------------------------------
+	initialByte, err := reader.readByte()
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	major := initialByte >> 5
+	information := initialByte & 0x1f
+	//------------------------------------------------------------
+	if major == cborMajorSimple {
+		return cborDecodeSimple(reader, information)
+	}
+	//------------------------------------------------------------
+	if information == cborIndefinite {
+		return cborDecodeIndefinite(reader, major, depth)
+	}
+	//------------------------------------------------------------
+	argument, err := cborDecodeArgument(reader, information)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	switch major {
+	case cborMajorUint:
+		return binaryIntValue(argument), nil
+	case cborMajorNegint:
+		if argument > math.MaxInt64 {
+			return nil, fmt.Errorf("cbor negative integer overflows int64 at offset %d", reader.offset)
+		}
+		return -1 - int64(argument), nil
+	case cborMajorBytes, cborMajorText:
+		dataBytes, err := reader.read(argument)
+		if err != nil {
+			return nil, err
+		}
+		if major == cborMajorText {
+			return string(dataBytes), nil
+		}
+		return append([]byte{}, dataBytes...), nil
+	case cborMajorArray:
+		//------------------------------------------------------------
+		// every element takes at least one byte
+		if argument > uint64(len(reader.data)-reader.offset) {
+			return nil, errBinaryTruncated
+		}
+		//------------------------------------------------------------
+		array := make([]any, argument)
+		//------------------------------------------------------------
+		for index := range array {
+			if array[index], err = cborDecodeValue(reader, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		//------------------------------------------------------------
+		return array, nil
+		//------------------------------------------------------------
+	case cborMajorMap:
+		//------------------------------------------------------------
+		if argument > uint64(len(reader.data)-reader.offset)/2 {
+			return nil, errBinaryTruncated
+		}
+		//------------------------------------------------------------
+		mapValue := make(map[string]any, argument)
+		//------------------------------------------------------------
+		for index := uint64(0); index < argument; index++ {
+			if err = cborDecodePair(reader, mapValue, depth); err != nil {
+				return nil, err
+			}
+		}
+		//------------------------------------------------------------
+		return mapValue, nil
+		//------------------------------------------------------------
+	default:
+		return cborDecodeTag(reader, argument, depth)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// cborDecodeArgument
+//------------------------------------------------------------
+
+func cborDecodeArgument(reader *binaryReader, information byte) (uint64, error) {
+	//------------------------------------------------------------
+	switch {
+	case information < 24:
+		return uint64(information), nil
+	case information <= 27:
+		return reader.readUint(1 << (information - 24))
+	default:
+		return 0, fmt.Errorf("invalid cbor additional information %d at offset %d", information, reader.offset-1)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// cborDecodeSimple
+//------------------------------------------------------------
+
+func cborDecodeSimple(reader *binaryReader, information byte) (any, error) {
+	//------------------------------------------------------------
+	switch information {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		// null and undefined
+		return nil, nil
+	case 25:
+		bits, err := reader.readUint(2)
+		if err != nil {
+			return nil, err
+		}
+		return cborFloat16Value(uint16(bits)), nil
+	case 26:
+		bits, err := reader.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(uint32(bits))), nil
+	case 27:
+		bits, err := reader.readUint(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(bits), nil
+	default:
+		return nil, fmt.Errorf("unsupported cbor simple value %d at offset %d", information, reader.offset-1)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// cborDecodeIndefinite
+//------------------------------------------------------------
+
+func cborDecodeIndefinite(reader *binaryReader, major byte, depth int) (any, error) {
+	//------------------------------------------------------------
+	if major < cborMajorBytes || major > cborMajorMap {
+		return nil, fmt.Errorf("invalid cbor indefinite length major type %d at offset %d", major, reader.offset-1)
+	}
+	//------------------------------------------------------------
+	var chunks []byte
+	array := []any{}
+	mapValue := map[string]any{}
+	//------------------------------------------------------------
+	for {
+		//------------------------------------------------------------
+		if reader.offset >= len(reader.data) {
+			return nil, errBinaryTruncated
+		}
+		//------------------------------------------------------------
+		if reader.data[reader.offset] == cborBreak {
+			reader.offset++
+			break
+		}
+		//------------------------------------------------------------
+		switch major {
+		case cborMajorBytes, cborMajorText:
+			//------------------------------------------------------------
+			// chunks must be definite length strings of the same major type
+			if reader.data[reader.offset]>>5 != major || reader.data[reader.offset]&0x1f == cborIndefinite {
+				return nil, fmt.Errorf("invalid cbor string chunk at offset %d", reader.offset)
+			}
+			//------------------------------------------------------------
+			chunk, err := cborDecodeValue(reader, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			//------------------------------------------------------------
+			switch typedChunk := chunk.(type) {
+			case string:
+				chunks = append(chunks, typedChunk...)
+			case []byte:
+				chunks = append(chunks, typedChunk...)
+			}
+			//------------------------------------------------------------
+		case cborMajorArray:
+			element, err := cborDecodeValue(reader, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, element)
+		default:
+			if err := cborDecodePair(reader, mapValue, depth); err != nil {
+				return nil, err
+			}
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	switch major {
+	case cborMajorBytes:
+		return append([]byte{}, chunks...), nil
+	case cborMajorText:
+		return string(chunks), nil
+	case cborMajorArray:
+		return array, nil
+	default:
+		return mapValue, nil
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// cborDecodePair
+//------------------------------------------------------------
+
+func cborDecodePair(reader *binaryReader, mapValue map[string]any, depth int) error {
+	//------------------------------------------------------------
+	key, err := cborDecodeValue(reader, depth+1)
+	if err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	keyString, err := binaryMapKey(mapValue, key)
+	if err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	mapValue[keyString], err = cborDecodeValue(reader, depth+1)
+	//------------------------------------------------------------
+	return err
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// cborDecodeTag
+//------------------------------------------------------------
+
+func cborDecodeTag(reader *binaryReader, tag uint64, depth int) (any, error) {
+	//------------------------------------------------------------
+	content, err := cborDecodeValue(reader, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	switch tag {
+	case 0:
+		//------------------------------------------------------------
+		timeString, ok := content.(string)
+		if !ok {
+			return nil, errors.New("cbor tag 0 content must be a text string")
+		}
+		//------------------------------------------------------------
+		return time.Parse(time.RFC3339Nano, timeString)
+		//------------------------------------------------------------
+	case 1:
+		//------------------------------------------------------------
+		switch typedContent := content.(type) {
+		case int64:
+			return time.Unix(typedContent, 0).UTC(), nil
+		case uint64:
+			return nil, errors.New("cbor tag 1 time is out of range")
+		case float64:
+			seconds, fraction := math.Modf(typedContent)
+			return time.Unix(int64(seconds), int64(fraction*1e9)).UTC(), nil
+		default:
+			return nil, errors.New("cbor tag 1 content must be a number")
+		}
+		//------------------------------------------------------------
+	default:
+		return content, nil
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
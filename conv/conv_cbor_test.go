@@ -0,0 +1,205 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"encoding/hex"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// CBOR_Marshal
+//------------------------------------------------------------
+
+func TestCBOR_Marshal(t *testing.T) {
+	//--------------------------------------------------
+	// RFC 8949 appendix A examples
+	testCases := []struct {
+		value    any
+		expected string
+	}{
+		{0, "00"},
+		{1, "01"},
+		{10, "0a"},
+		{23, "17"},
+		{24, "1818"},
+		{100, "1864"},
+		{1000, "1903e8"},
+		{1000000, "1a000f4240"},
+		{int64(1000000000000), "1b000000e8d4a51000"},
+		{uint64(18446744073709551615), "1bffffffffffffffff"},
+		{-1, "20"},
+		{-10, "29"},
+		{-100, "3863"},
+		{-1000, "3903e7"},
+		{0.0, "f90000"},
+		{math.Copysign(0, -1), "f98000"},
+		{1.0, "f93c00"},
+		{1.1, "fb3ff199999999999a"},
+		{1.5, "f93e00"},
+		{65504.0, "f97bff"},
+		{100000.0, "fa47c35000"},
+		{3.4028234663852886e+38, "fa7f7fffff"},
+		{1.0e+300, "fb7e37e43c8800759c"},
+		{5.960464477539063e-8, "f90001"},
+		{0.00006103515625, "f90400"},
+		{-4.0, "f9c400"},
+		{-4.1, "fbc010666666666666"},
+		{math.Inf(1), "f97c00"},
+		{math.NaN(), "f97e00"},
+		{math.Inf(-1), "f9fc00"},
+		{false, "f4"},
+		{true, "f5"},
+		{nil, "f6"},
+		{"", "60"},
+		{"a", "6161"},
+		{"IETF", "6449455446"},
+		{"\"\\", "62225c"},
+		{"ü", "62c3bc"},
+		{"水", "63e6b0b4"},
+		{[]byte{}, "40"},
+		{[]byte{1, 2, 3, 4}, "4401020304"},
+		{[]any{}, "80"},
+		{[]int{1, 2, 3}, "83010203"},
+		{[]any{1, []any{2, 3}, []any{4, 5}}, "8301820203820405"},
+		{map[string]any{}, "a0"},
+		{map[string]any{"a": 1, "b": []any{2, 3}}, "a26161016162820203"},
+		{map[string]string{"e": "E", "d": "D", "c": "C", "b": "B", "a": "A"}, "a56161614161626142616361436164614461656145"},
+		{map[int]string{10: "a", -1: "b", 100: "c"}, "a30a6161186461632061 62"},
+		{time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC), "c074323031332d30332d32315432303a30343a30305a"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		result, err := CBOR_Marshal(testCase.value)
+		//--------------------------------------------------
+		if err != nil {
+			t.Errorf("(%v) returned error = %v", testCase.value, err)
+		} else if hex.EncodeToString(result) != removeSpaces(testCase.expected) {
+			t.Errorf("(%v) = %x but should = %s", testCase.value, result, removeSpaces(testCase.expected))
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CBOR_Unmarshal
+//------------------------------------------------------------
+
+func TestCBOR_Unmarshal(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		data     string
+		expected any
+	}{
+		{"1b000000e8d4a51000", int64(1000000000000)},
+		{"1bffffffffffffffff", uint64(18446744073709551615)},
+		{"3903e7", int64(-1000)},
+		{"f93e00", 1.5},
+		{"f90001", 5.960464477539063e-8},
+		{"f9c400", -4.0},
+		{"fa47c35000", 100000.0},
+		{"f7", nil},
+		{"4401020304", []byte{1, 2, 3, 4}},
+		{"a26161016162820203", map[string]any{"a": 1, "b": []any{2, 3}}},
+		{"a201020304", map[string]any{"1": 2, "3": 4}},
+		{"c074323031332d30332d32315432303a30343a30305a", time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+		{"c11a514b67b0", time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+		{"c1fb41d452d9ec200000", time.Date(2013, 3, 21, 20, 4, 0, 500000000, time.UTC)},
+		{"d74401020304", []byte{1, 2, 3, 4}},
+		{"5f42010243030405ff", []byte{1, 2, 3, 4, 5}},
+		{"7f657374726561646d696e67ff", "streaming"},
+		{"9fff", []any{}},
+		{"9f018202039f0405ffff", []any{1, []any{2, 3}, []any{4, 5}}},
+		{"bf61610161629f0203ffff", map[string]any{"a": 1, "b": []any{2, 3}}},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		data, _ := hex.DecodeString(testCase.data)
+		//--------------------------------------------------
+		result, err := CBOR_Unmarshal(data)
+		//--------------------------------------------------
+		if err != nil {
+			t.Errorf("(%s) returned error = %v", testCase.data, err)
+		} else if !binaryTestEqual(result, testCase.expected) {
+			t.Errorf("(%s) = %#v but should = %#v", testCase.data, result, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	for _, invalidData := range []string{"", "18", "62c3", "9f01", "a1", "1c", "ff", "f8", "1f", "5f01ff", "0101"} {
+		//--------------------------------------------------
+		data, _ := hex.DecodeString(invalidData)
+		//--------------------------------------------------
+		if _, err := CBOR_Unmarshal(data); err == nil {
+			t.Errorf("(%s) should return an error", invalidData)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	// duplicate keys and keys that collide once converted to strings (1 and "1")
+	for _, duplicateData := range []string{"a2616101616102", "a20101613102", "bf616101616102ff"} {
+		//--------------------------------------------------
+		data, _ := hex.DecodeString(duplicateData)
+		//--------------------------------------------------
+		if _, err := CBOR_Unmarshal(data); err == nil || !strings.Contains(err.Error(), "duplicate map key") {
+			t.Errorf("(%s) err = %v but should be a duplicate map key error", duplicateData, err)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	// a huge array length must not allocate before the data is checked
+	if _, err := CBOR_Unmarshal([]byte{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}); err == nil {
+		t.Errorf("huge array length should return an error")
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CBOR_DecodeInto
+//------------------------------------------------------------
+
+func TestCBOR_DecodeInto(t *testing.T) {
+	//--------------------------------------------------
+	value := binaryTestStruct{
+		ID:      9007199254740993,
+		Name:    "test",
+		Tags:    []string{"a", "b"},
+		Score:   1.25,
+		Data:    []byte{0, 1, 2},
+		Created: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		Extra:   map[string]int{"x": 1},
+		Nested:  &binaryTestNested{Flag: true},
+	}
+	//--------------------------------------------------
+	data, err := CBOR_Marshal(value)
+	if err != nil {
+		t.Fatalf("CBOR_Marshal returned error = %v", err)
+	}
+	//--------------------------------------------------
+	result, err := CBOR_DecodeInto[binaryTestStruct](data)
+	if err != nil {
+		t.Fatalf("CBOR_DecodeInto returned error = %v", err)
+	}
+	//--------------------------------------------------
+	binaryTestCompareStruct(t, result, value)
+	//--------------------------------------------------
+	mapValue, _ := CBOR_Unmarshal(data)
+	if _, exists := mapValue.(map[string]any)["cbor_id"]; !exists {
+		t.Errorf("map = %v but should use the cbor tag name", mapValue)
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,361 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// Codec converts data to and from an encoded form
+type Codec interface {
+	Name() string
+	Encode(dataBytes []byte) ([]byte, error)
+	Decode(dataBytes []byte) ([]byte, error)
+}
+
+//------------------------------------------------------------
+
+// PIPELINE_SEPARATOR joins codec names into a pipeline e.g. "hex|base64url"
+const PIPELINE_SEPARATOR = "|"
+
+//------------------------------------------------------------
+
+var codecRegistry = struct {
+	sync.RWMutex
+	codecs map[string]Codec
+}{codecs: map[string]Codec{}}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+func init() {
+	//------------------------------------------------------------
+	mustRegisterCodec(NewCodec("base",
+		func(dataBytes []byte) ([]byte, error) {
+			return []byte(Base_encode(string(dataBytes))), nil
+		},
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := Base_decode(string(dataBytes))
+			return []byte(dataString), err
+		},
+	))
+	//------------------------------------------------------------
+	mustRegisterCodec(NewCodec("base64",
+		func(dataBytes []byte) ([]byte, error) {
+			return []byte(Base64_encode(string(dataBytes))), nil
+		},
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := Base64_decode(string(dataBytes))
+			return []byte(dataString), err
+		},
+	))
+	//------------------------------------------------------------
+	mustRegisterCodec(NewCodec("base64url",
+		func(dataBytes []byte) ([]byte, error) {
+			return []byte(Base64url_encode(string(dataBytes))), nil
+		},
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := Base64url_decode(string(dataBytes))
+			return []byte(dataString), err
+		},
+	))
+	//------------------------------------------------------------
+	// "base91" escapes quote, dollar and grave accent characters as used throughout this module
+	mustRegisterCodec(NewCodec("base91",
+		func(dataBytes []byte) ([]byte, error) {
+			return []byte(Base91_encode(string(dataBytes), true)), nil
+		},
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := Base91_decode(string(dataBytes), true)
+			return []byte(dataString), err
+		},
+	))
+	//------------------------------------------------------------
+	mustRegisterCodec(NewCodec("base91raw",
+		func(dataBytes []byte) ([]byte, error) {
+			return []byte(Base91_encode(string(dataBytes), false)), nil
+		},
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := Base91_decode(string(dataBytes), false)
+			return []byte(dataString), err
+		},
+	))
+	//------------------------------------------------------------
+	mustRegisterCodec(NewCodec("hex",
+		func(dataBytes []byte) ([]byte, error) {
+			return []byte(Hex_encode(string(dataBytes))), nil
+		},
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := Hex_decode(string(dataBytes))
+			return []byte(dataString), err
+		},
+	))
+	//------------------------------------------------------------
+	// "obfuscate" is left to the rpc package whose XOR obfuscation has used that name on the wire
+	obfuscateFunc := func(dataBytes []byte) ([]byte, error) {
+		dataString, err := ObfuscateData(string(dataBytes), false, false)
+		return []byte(dataString), err
+	}
+	mustRegisterCodec(NewCodec("conv_obfuscate", obfuscateFunc, obfuscateFunc))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// mustRegisterCodec
+//------------------------------------------------------------
+
+func mustRegisterCodec(codec Codec) {
+	if err := RegisterCodec(codec); err != nil {
+		panic(err)
+	}
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// NewCodec
+//------------------------------------------------------------
+
+// NewCodec creates a codec from a pair of encode / decode functions
+func NewCodec(name string, encodeFunc func([]byte) ([]byte, error), decodeFunc func([]byte) ([]byte, error)) Codec {
+	return &funcCodec{name: name, encodeFunc: encodeFunc, decodeFunc: decodeFunc}
+}
+
+//------------------------------------------------------------
+
+type funcCodec struct {
+	name       string
+	encodeFunc func([]byte) ([]byte, error)
+	decodeFunc func([]byte) ([]byte, error)
+}
+
+func (codec *funcCodec) Name() string { return codec.name }
+
+func (codec *funcCodec) Encode(dataBytes []byte) ([]byte, error) {
+	return codec.encodeFunc(dataBytes)
+}
+
+func (codec *funcCodec) Decode(dataBytes []byte) ([]byte, error) {
+	return codec.decodeFunc(dataBytes)
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// RegisterCodec
+//------------------------------------------------------------
+
+func RegisterCodec(codec Codec) error {
+	//------------------------------------------------------------
+	if codec == nil {
+		return errors.New("codec is nil")
+	}
+	//------------------------------------------------------------
+	name := codec.Name()
+	//------------------------------------------------------------
+	if name == "" {
+		return errors.New("codec name is empty")
+	}
+	if strings.Contains(name, PIPELINE_SEPARATOR) {
+		return fmt.Errorf("codec name %q contains pipeline separator %q", name, PIPELINE_SEPARATOR)
+	}
+	//------------------------------------------------------------
+	codecRegistry.Lock()
+	defer codecRegistry.Unlock()
+	//------------------------------------------------------------
+	if _, exists := codecRegistry.codecs[name]; exists {
+		return fmt.Errorf("codec %q is already registered", name)
+	}
+	//------------------------------------------------------------
+	codecRegistry.codecs[name] = codec
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// UnregisterCodec
+//------------------------------------------------------------
+
+func UnregisterCodec(name string) {
+	//------------------------------------------------------------
+	codecRegistry.Lock()
+	defer codecRegistry.Unlock()
+	//------------------------------------------------------------
+	delete(codecRegistry.codecs, name)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// GetCodec
+//------------------------------------------------------------
+
+// GetCodec returns the codec registered under name
+//
+// names joined by "|" return a pipeline which encodes left to right and decodes right to left
+func GetCodec(name string) (Codec, error) {
+	//------------------------------------------------------------
+	if !strings.Contains(name, PIPELINE_SEPARATOR) {
+		return lookupCodec(name)
+	}
+	//------------------------------------------------------------
+	names := strings.Split(name, PIPELINE_SEPARATOR)
+	codecs := make([]Codec, 0, len(names))
+	//------------------------------------------------------------
+	for _, codecName := range names {
+		codec, err := lookupCodec(strings.TrimSpace(codecName))
+		if err != nil {
+			return nil, err
+		}
+		codecs = append(codecs, codec)
+	}
+	//------------------------------------------------------------
+	return &pipelineCodec{name: name, codecs: codecs}, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// lookupCodec
+//------------------------------------------------------------
+
+func lookupCodec(name string) (Codec, error) {
+	//------------------------------------------------------------
+	codecRegistry.RLock()
+	defer codecRegistry.RUnlock()
+	//------------------------------------------------------------
+	codec, exists := codecRegistry.codecs[name]
+	if !exists {
+		return nil, fmt.Errorf("codec %q is not registered", name)
+	}
+	//------------------------------------------------------------
+	return codec, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CodecNames
+//------------------------------------------------------------
+
+func CodecNames() []string {
+	//------------------------------------------------------------
+	codecRegistry.RLock()
+	defer codecRegistry.RUnlock()
+	//------------------------------------------------------------
+	names := make([]string, 0, len(codecRegistry.codecs))
+	for name := range codecRegistry.codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	//------------------------------------------------------------
+	return names
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type pipelineCodec struct {
+	name   string
+	codecs []Codec
+}
+
+func (pipeline *pipelineCodec) Name() string { return pipeline.name }
+
+func (pipeline *pipelineCodec) Encode(dataBytes []byte) ([]byte, error) {
+	//------------------------------------------------------------
+	var err error
+	//------------------------------------------------------------
+	for _, codec := range pipeline.codecs {
+		dataBytes, err = codec.Encode(dataBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	//------------------------------------------------------------
+	return dataBytes, nil
+	//------------------------------------------------------------
+}
+
+func (pipeline *pipelineCodec) Decode(dataBytes []byte) ([]byte, error) {
+	//------------------------------------------------------------
+	var err error
+	//------------------------------------------------------------
+	for index := len(pipeline.codecs) - 1; index >= 0; index-- {
+		dataBytes, err = pipeline.codecs[index].Decode(dataBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	//------------------------------------------------------------
+	return dataBytes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Codec_encode
+//------------------------------------------------------------
+
+func Codec_encode(dataString string, name string) (string, error) {
+	//------------------------------------------------------------
+	codec, err := GetCodec(name)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	dataBytes, err := codec.Encode([]byte(dataString))
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return string(dataBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Codec_decode
+//------------------------------------------------------------
+
+func Codec_decode(dataString string, name string) (string, error) {
+	//------------------------------------------------------------
+	codec, err := GetCodec(name)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	dataBytes, err := codec.Decode([]byte(dataString))
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return string(dataBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
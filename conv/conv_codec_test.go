@@ -0,0 +1,179 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// GetCodec
+//------------------------------------------------------------
+
+func TestGetCodec(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		name           string
+		dataString     string
+		expectedString string
+	}{
+		{"base", "AAAA", Base_encode("AAAA")},
+		{"base64", "ABCD", "QUJDRA=="},
+		{"base64url", "ABCD", "QUJDRA"},
+		{"base91", "ABCD\x22", Base91_encode("ABCD\x22", true)},
+		{"base91raw", "ABCD\x22", Base91_encode("ABCD\x22", false)},
+		{"hex", "ABCD", "41424344"},
+		{"conv_obfuscate", "ABCD", "]\\[Z"},
+		{"conv_obfuscate|base64", "ABCD", "XVxbWg=="},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		codec, err := GetCodec(testCase.name)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		//--------------------------------------------------
+		if codec.Name() != testCase.name {
+			t.Errorf("codec.Name() = %q but should = %q", codec.Name(), testCase.name)
+		}
+		//--------------------------------------------------
+		encodedBytes, err := codec.Encode([]byte(testCase.dataString))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		//--------------------------------------------------
+		if string(encodedBytes) != testCase.expectedString {
+			t.Errorf("(%s) encoded = %q but should = %q", testCase.name, encodedBytes, testCase.expectedString)
+		}
+		//--------------------------------------------------
+		decodedBytes, err := codec.Decode(encodedBytes)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		//--------------------------------------------------
+		if string(decodedBytes) != testCase.dataString {
+			t.Errorf("(%s) decoded = %q but should = %q", testCase.name, decodedBytes, testCase.dataString)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	_, err := GetCodec("INVALID_CODEC")
+	if err == nil {
+		t.Errorf("err = nil but should = %q", `codec "INVALID_CODEC" is not registered`)
+	}
+	//--------------------------------------------------
+	_, err = GetCodec("base64|INVALID_CODEC")
+	if err == nil {
+		t.Errorf("err = nil but should = %q", `codec "INVALID_CODEC" is not registered`)
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Pipeline
+//------------------------------------------------------------
+
+func TestPipeline(t *testing.T) {
+	//--------------------------------------------------
+	dataBytes := bytes.Repeat([]byte("ABCD"), 1000)
+	//--------------------------------------------------
+	codec, err := GetCodec("hex|base64url")
+	if err != nil {
+		t.Fatal(err)
+	}
+	//--------------------------------------------------
+	encodedBytes, err := codec.Encode(dataBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//--------------------------------------------------
+	// encoding runs left to right
+	if expected := Base64url_encode(Hex_encode(string(dataBytes))); string(encodedBytes) != expected {
+		t.Errorf("encodedBytes = %q but should = %q", encodedBytes, expected)
+	}
+	if strings.ContainsAny(string(encodedBytes), "+/=") {
+		t.Errorf("encodedBytes = %q but should be base64url encoded", encodedBytes)
+	}
+	//--------------------------------------------------
+	decodedBytes, err := codec.Decode(encodedBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//--------------------------------------------------
+	if !bytes.Equal(decodedBytes, dataBytes) {
+		t.Errorf("decodedBytes = %q but should = %q", decodedBytes, dataBytes)
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// RegisterCodec
+//------------------------------------------------------------
+
+func TestRegisterCodec(t *testing.T) {
+	//--------------------------------------------------
+	reverseFunc := func(dataBytes []byte) ([]byte, error) {
+		outputBytes := make([]byte, len(dataBytes))
+		for index := range dataBytes {
+			outputBytes[len(dataBytes)-1-index] = dataBytes[index]
+		}
+		return outputBytes, nil
+	}
+	//--------------------------------------------------
+	err := RegisterCodec(NewCodec("test_reverse", reverseFunc, reverseFunc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer UnregisterCodec("test_reverse")
+	//--------------------------------------------------
+	err = RegisterCodec(NewCodec("test_reverse", reverseFunc, reverseFunc))
+	if err == nil {
+		t.Errorf("err = nil but should = %q", `codec "test_reverse" is already registered`)
+	}
+	//--------------------------------------------------
+	err = RegisterCodec(NewCodec("test|reverse", reverseFunc, reverseFunc))
+	if err == nil {
+		t.Error("err = nil but pipeline separator should be rejected")
+	}
+	//--------------------------------------------------
+	resultString, err := Codec_encode("ABCD", "test_reverse|hex")
+	//--------------------------------------------------
+	if err != nil {
+		t.Error(err)
+	} else if resultString != "44434241" {
+		t.Errorf("resultString = %q but should = %q", resultString, "44434241")
+	}
+	//--------------------------------------------------
+	resultString, err = Codec_decode("44434241", "test_reverse|hex")
+	//--------------------------------------------------
+	if err != nil {
+		t.Error(err)
+	} else if resultString != "ABCD" {
+		t.Errorf("resultString = %q but should = %q", resultString, "ABCD")
+	}
+	//--------------------------------------------------
+	found := false
+	for _, name := range CodecNames() {
+		if name == "test_reverse" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("CodecNames() = %v but should contain %q", CodecNames(), "test_reverse")
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,387 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// compression formats accepted by Compress, Decompress and the streaming functions
+const (
+	COMPRESS_GZIP    = "gzip"
+	COMPRESS_ZLIB    = "zlib"
+	COMPRESS_DEFLATE = "deflate"
+)
+
+// armour names accepted by Compress_encode, Compress_decode and the armoured streams
+const (
+	ARMOUR_BASE64URL = "base64url"
+	ARMOUR_BASE91    = "base91"
+)
+
+var ErrDecompressLimit = errors.New("decompressed data exceeds size limit")
+
+//------------------------------------------------------------
+
+type CompressOption func(*CompressOptions)
+
+type CompressOptions struct {
+	// Level is a compress/flate level from flate.HuffmanOnly (-2) to flate.BestCompression (9)
+	Level int
+	// MaxSize limits the decompressed size to guard against decompression bombs, 0 disables the limit
+	MaxSize int64
+}
+
+var DefaultCompressOptions = CompressOptions{
+	Level:   flate.DefaultCompression,
+	MaxSize: 64 << 20,
+}
+
+//------------------------------------------------------------
+
+func WithCompressLevel(level int) CompressOption {
+	return func(options *CompressOptions) { options.Level = level }
+}
+
+func WithCompressMaxSize(maxSize int64) CompressOption {
+	return func(options *CompressOptions) { options.MaxSize = maxSize }
+}
+
+//------------------------------------------------------------
+
+func NewCompressOptions(options ...CompressOption) CompressOptions {
+	compressOptions := DefaultCompressOptions
+	for _, optionFunc := range options {
+		optionFunc(&compressOptions)
+	}
+	return compressOptions
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+func init() {
+	//------------------------------------------------------------
+	for _, format := range []string{COMPRESS_GZIP, COMPRESS_ZLIB, COMPRESS_DEFLATE} {
+		//------------------------------------------------------------
+		mustRegisterCodec(NewCodec(format,
+			func(dataBytes []byte) ([]byte, error) {
+				return Compress(dataBytes, format)
+			},
+			func(dataBytes []byte) ([]byte, error) {
+				return Decompress(dataBytes, format)
+			},
+		))
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Compress
+//------------------------------------------------------------
+
+// Compress compresses data as gzip, zlib or raw deflate
+func Compress(dataBytes []byte, format string, options ...CompressOption) ([]byte, error) {
+	//------------------------------------------------------------
+	var outputBuffer bytes.Buffer
+	//------------------------------------------------------------
+	compressWriter, err := NewCompressWriter(&outputBuffer, format, options...)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if _, err = compressWriter.Write(dataBytes); err != nil {
+		return nil, err
+	}
+	if err = compressWriter.Close(); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return outputBuffer.Bytes(), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Decompress
+//------------------------------------------------------------
+
+// Decompress reverses Compress and returns ErrDecompressLimit when the output would exceed MaxSize
+func Decompress(dataBytes []byte, format string, options ...CompressOption) ([]byte, error) {
+	//------------------------------------------------------------
+	decompressReader, err := NewDecompressReader(bytes.NewReader(dataBytes), format, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer decompressReader.Close()
+	//------------------------------------------------------------
+	return io.ReadAll(decompressReader)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Compress_encode
+//------------------------------------------------------------
+
+// Compress_encode compresses a string and armours it as base64url or base91 text
+func Compress_encode(dataString string, format string, armour string, options ...CompressOption) (string, error) {
+	//------------------------------------------------------------
+	if err := checkArmour(armour); err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	dataBytes, err := Compress([]byte(dataString), format, options...)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	if armour == ARMOUR_BASE91 {
+		return Base91_encode(string(dataBytes), true), nil
+	}
+	//------------------------------------------------------------
+	return Base64url_encode(string(dataBytes)), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Compress_decode
+//------------------------------------------------------------
+
+// Compress_decode reverses Compress_encode
+func Compress_decode(dataString string, format string, armour string, options ...CompressOption) (string, error) {
+	//------------------------------------------------------------
+	if err := checkArmour(armour); err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	var err error
+	//------------------------------------------------------------
+	if armour == ARMOUR_BASE91 {
+		dataString, err = Base91_decode(dataString, true)
+	} else {
+		dataString, err = Base64url_decode(dataString)
+	}
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	dataBytes, err := Decompress([]byte(dataString), format, options...)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return string(dataBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// checkArmour
+//------------------------------------------------------------
+
+func checkArmour(armour string) error {
+	//------------------------------------------------------------
+	if armour != ARMOUR_BASE64URL && armour != ARMOUR_BASE91 {
+		return fmt.Errorf("unsupported armour %q", armour)
+	}
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// NewCompressWriter
+//------------------------------------------------------------
+
+// NewCompressWriter returns a writer compressing into writer
+//
+// Close must be called to flush the compressed data, it does not close writer
+func NewCompressWriter(writer io.Writer, format string, options ...CompressOption) (io.WriteCloser, error) {
+	//------------------------------------------------------------
+	compressOptions := NewCompressOptions(options...)
+	//------------------------------------------------------------
+	switch format {
+	case COMPRESS_GZIP:
+		return gzip.NewWriterLevel(writer, compressOptions.Level)
+	case COMPRESS_ZLIB:
+		return zlib.NewWriterLevel(writer, compressOptions.Level)
+	case COMPRESS_DEFLATE:
+		return flate.NewWriter(writer, compressOptions.Level)
+	}
+	//------------------------------------------------------------
+	return nil, fmt.Errorf("unsupported compression format %q", format)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NewDecompressReader
+//------------------------------------------------------------
+
+// NewDecompressReader returns a reader decompressing from reader
+//
+// reading past MaxSize returns ErrDecompressLimit
+func NewDecompressReader(reader io.Reader, format string, options ...CompressOption) (io.ReadCloser, error) {
+	//------------------------------------------------------------
+	var err error
+	var decompressReader io.ReadCloser
+	//------------------------------------------------------------
+	compressOptions := NewCompressOptions(options...)
+	//------------------------------------------------------------
+	switch format {
+	case COMPRESS_GZIP:
+		decompressReader, err = gzip.NewReader(reader)
+	case COMPRESS_ZLIB:
+		decompressReader, err = zlib.NewReader(reader)
+	case COMPRESS_DEFLATE:
+		decompressReader = flate.NewReader(reader)
+	default:
+		err = fmt.Errorf("unsupported compression format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if compressOptions.MaxSize <= 0 {
+		return decompressReader, nil
+	}
+	//------------------------------------------------------------
+	return &limitReader{ReadCloser: decompressReader, remaining: compressOptions.MaxSize}, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NewCompressArmourWriter
+//------------------------------------------------------------
+
+// NewCompressArmourWriter returns a writer producing the same output as Compress_encode
+//
+// Close must be called to flush both the compressed data and the armour
+func NewCompressArmourWriter(writer io.Writer, format string, armour string, options ...CompressOption) (io.WriteCloser, error) {
+	//------------------------------------------------------------
+	if err := checkArmour(armour); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	var armourWriter io.WriteCloser
+	//------------------------------------------------------------
+	if armour == ARMOUR_BASE91 {
+		armourWriter = NewBase91Encoder(writer, true)
+	} else {
+		armourWriter = NewBase64urlEncoder(writer)
+	}
+	//------------------------------------------------------------
+	compressWriter, err := NewCompressWriter(armourWriter, format, options...)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return &chainWriter{WriteCloser: compressWriter, next: armourWriter}, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NewDecompressArmourReader
+//------------------------------------------------------------
+
+// NewDecompressArmourReader returns a reader reversing NewCompressArmourWriter
+func NewDecompressArmourReader(reader io.Reader, format string, armour string, options ...CompressOption) (io.ReadCloser, error) {
+	//------------------------------------------------------------
+	if err := checkArmour(armour); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if armour == ARMOUR_BASE91 {
+		return NewDecompressReader(NewBase91Decoder(reader, true), format, options...)
+	}
+	//------------------------------------------------------------
+	return NewDecompressReader(NewBase64urlDecoder(reader), format, options...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type limitReader struct {
+	io.ReadCloser
+	remaining int64
+}
+
+//------------------------------------------------------------
+
+func (reader *limitReader) Read(dataBytes []byte) (int, error) {
+	//------------------------------------------------------------
+	if reader.remaining < 0 {
+		return 0, ErrDecompressLimit
+	}
+	//------------------------------------------------------------
+	// read one byte more than allowed so reaching the limit exactly is not an error
+	if int64(len(dataBytes)) > reader.remaining+1 {
+		dataBytes = dataBytes[:reader.remaining+1]
+	}
+	//------------------------------------------------------------
+	readCount, err := reader.ReadCloser.Read(dataBytes)
+	//------------------------------------------------------------
+	reader.remaining -= int64(readCount)
+	//------------------------------------------------------------
+	if reader.remaining < 0 {
+		return readCount + int(reader.remaining), ErrDecompressLimit
+	}
+	//------------------------------------------------------------
+	return readCount, err
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type chainWriter struct {
+	io.WriteCloser
+	next io.Closer
+}
+
+//------------------------------------------------------------
+
+func (writer *chainWriter) Close() error {
+	//------------------------------------------------------------
+	if err := writer.WriteCloser.Close(); err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	return writer.next.Close()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
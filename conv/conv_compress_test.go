@@ -0,0 +1,195 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Compress
+//------------------------------------------------------------
+
+func TestCompress(t *testing.T) {
+	//--------------------------------------------------
+	dataBytes := bytes.Repeat([]byte("ABCD1234"), 1000)
+	//--------------------------------------------------
+	for _, format := range []string{COMPRESS_GZIP, COMPRESS_ZLIB, COMPRESS_DEFLATE} {
+		for _, level := range []int{flate.DefaultCompression, flate.HuffmanOnly, flate.NoCompression, flate.BestSpeed, flate.BestCompression} {
+			//--------------------------------------------------
+			compressedBytes, err := Compress(dataBytes, format, WithCompressLevel(level))
+			if err != nil {
+				t.Fatalf("%s level %d returned error = %v", format, level, err)
+			}
+			//--------------------------------------------------
+			if level != flate.NoCompression && level != flate.HuffmanOnly && len(compressedBytes) >= len(dataBytes)/10 {
+				t.Errorf("%s level %d len = %d but should be less than %d", format, level, len(compressedBytes), len(dataBytes)/10)
+			}
+			//--------------------------------------------------
+			decompressedBytes, err := Decompress(compressedBytes, format)
+			if err != nil {
+				t.Fatalf("%s level %d returned error = %v", format, level, err)
+			}
+			if !bytes.Equal(decompressedBytes, dataBytes) {
+				t.Errorf("%s level %d decompressed data does not match", format, level)
+			}
+			//--------------------------------------------------
+		}
+	}
+	//--------------------------------------------------
+	if _, err := Compress(dataBytes, "lzma"); err == nil {
+		t.Errorf("unsupported format should return an error")
+	}
+	if _, err := Compress(dataBytes, COMPRESS_GZIP, WithCompressLevel(10)); err == nil {
+		t.Errorf("invalid level should return an error")
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Decompress
+//------------------------------------------------------------
+
+func TestDecompress(t *testing.T) {
+	//--------------------------------------------------
+	dataBytes := bytes.Repeat([]byte{0}, 1<<20)
+	//--------------------------------------------------
+	for _, format := range []string{COMPRESS_GZIP, COMPRESS_ZLIB, COMPRESS_DEFLATE} {
+		//--------------------------------------------------
+		compressedBytes, _ := Compress(dataBytes, format, WithCompressLevel(flate.BestCompression))
+		//--------------------------------------------------
+		if _, err := Decompress(compressedBytes, format, WithCompressMaxSize(1<<20-1)); !errors.Is(err, ErrDecompressLimit) {
+			t.Errorf("%s error = %v but should = %v", format, err, ErrDecompressLimit)
+		}
+		//--------------------------------------------------
+		if result, err := Decompress(compressedBytes, format, WithCompressMaxSize(1<<20)); err != nil || len(result) != 1<<20 {
+			t.Errorf("%s len = %d, error = %v but should = %d, nil", format, len(result), err, 1<<20)
+		}
+		//--------------------------------------------------
+		if result, err := Decompress(compressedBytes, format, WithCompressMaxSize(0)); err != nil || len(result) != 1<<20 {
+			t.Errorf("%s unlimited len = %d, error = %v but should = %d, nil", format, len(result), err, 1<<20)
+		}
+		//--------------------------------------------------
+		if _, err := Decompress(compressedBytes[:len(compressedBytes)/2], format); err == nil {
+			t.Errorf("%s truncated data should return an error", format)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Compress_encode
+//------------------------------------------------------------
+
+func TestCompress_encode(t *testing.T) {
+	//--------------------------------------------------
+	dataString := strings.Repeat(`{"key":"value","list":[1,2,3]}`, 100)
+	//--------------------------------------------------
+	for _, armour := range []string{ARMOUR_BASE64URL, ARMOUR_BASE91} {
+		//--------------------------------------------------
+		encodedString, err := Compress_encode(dataString, COMPRESS_ZLIB, armour)
+		if err != nil {
+			t.Fatalf("%s returned error = %v", armour, err)
+		}
+		//--------------------------------------------------
+		if len(encodedString) >= len(dataString)/10 {
+			t.Errorf("%s len = %d but should be less than %d", armour, len(encodedString), len(dataString)/10)
+		}
+		if strings.ContainsAny(encodedString, "\"$`") {
+			t.Errorf("%s encodedString = %q but should not contain quote, dollar or grave accent characters", armour, encodedString)
+		}
+		//--------------------------------------------------
+		decodedString, err := Compress_decode(encodedString, COMPRESS_ZLIB, armour)
+		if err != nil {
+			t.Fatalf("%s returned error = %v", armour, err)
+		}
+		if decodedString != dataString {
+			t.Errorf("%s decodedString does not match", armour)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	if _, err := Compress_encode(dataString, COMPRESS_ZLIB, "hex"); err == nil {
+		t.Errorf("unsupported armour should return an error")
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NewCompressArmourWriter
+//------------------------------------------------------------
+
+func TestNewCompressArmourWriter(t *testing.T) {
+	//--------------------------------------------------
+	dataString := strings.Repeat("streamed data ", 5000)
+	//--------------------------------------------------
+	for _, armour := range []string{ARMOUR_BASE64URL, ARMOUR_BASE91} {
+		//--------------------------------------------------
+		var outputBuffer bytes.Buffer
+		//--------------------------------------------------
+		armourWriter, err := NewCompressArmourWriter(&outputBuffer, COMPRESS_GZIP, armour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for index := 0; index < len(dataString); index += 1000 {
+			if _, err = armourWriter.Write([]byte(dataString[index:min(index+1000, len(dataString))])); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err = armourWriter.Close(); err != nil {
+			t.Fatal(err)
+		}
+		//--------------------------------------------------
+		expectedString, _ := Compress_encode(dataString, COMPRESS_GZIP, armour)
+		if outputBuffer.String() != expectedString {
+			t.Errorf("%s streamed output does not match Compress_encode", armour)
+		}
+		//--------------------------------------------------
+		armourReader, err := NewDecompressArmourReader(&outputBuffer, COMPRESS_GZIP, armour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resultBytes, err := io.ReadAll(armourReader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(resultBytes) != dataString {
+			t.Errorf("%s streamed result does not match", armour)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func TestCompress_Codec(t *testing.T) {
+	//--------------------------------------------------
+	for _, name := range []string{"gzip|base64url", "zlib|base91", "deflate|hex"} {
+		//--------------------------------------------------
+		encodedString, err := Codec_encode("codec data", name)
+		if err != nil {
+			t.Fatalf("%s returned error = %v", name, err)
+		}
+		//--------------------------------------------------
+		if decodedString, err := Codec_decode(encodedString, name); err != nil || decodedString != "codec data" {
+			t.Errorf("%s = %q, %v but should = %q, nil", name, decodedString, err, "codec data")
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,543 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type CSVOption func(*CSVOptions)
+
+type CSVOptions struct {
+	// Delimiter separates fields, e.g. ',' for CSV or '\t' for TSV
+	Delimiter rune
+	// Columns sets the column order, by default the sorted keys of the records are used
+	Columns []string
+	// Header writes or reads a header row of column names
+	Header bool
+	// QuoteAll quotes every field instead of only the fields that need it
+	QuoteAll bool
+	// NullString is written for nil values and read back as nil when not empty
+	NullString string
+	// TimeFormat is used to write time.Time values and to infer them when reading
+	TimeFormat string
+	// InferTypes reads int, float64, bool and time.Time values instead of strings
+	InferTypes bool
+	// CRLF ends rows with "\r\n" instead of "\n"
+	CRLF bool
+}
+
+var DefaultCSVOptions = CSVOptions{
+	Delimiter:  ',',
+	Header:     true,
+	TimeFormat: time.RFC3339,
+}
+
+//------------------------------------------------------------
+
+func WithCSVDelimiter(delimiter rune) CSVOption {
+	return func(options *CSVOptions) { options.Delimiter = delimiter }
+}
+
+func WithCSVColumns(columns ...string) CSVOption {
+	return func(options *CSVOptions) { options.Columns = columns }
+}
+
+func WithCSVHeader(header bool) CSVOption {
+	return func(options *CSVOptions) { options.Header = header }
+}
+
+func WithCSVQuoteAll(quoteAll bool) CSVOption {
+	return func(options *CSVOptions) { options.QuoteAll = quoteAll }
+}
+
+func WithCSVNullString(nullString string) CSVOption {
+	return func(options *CSVOptions) { options.NullString = nullString }
+}
+
+func WithCSVTimeFormat(timeFormat string) CSVOption {
+	return func(options *CSVOptions) { options.TimeFormat = timeFormat }
+}
+
+func WithCSVInferTypes(inferTypes bool) CSVOption {
+	return func(options *CSVOptions) { options.InferTypes = inferTypes }
+}
+
+func WithCSVCRLF(crlf bool) CSVOption {
+	return func(options *CSVOptions) { options.CRLF = crlf }
+}
+
+//------------------------------------------------------------
+
+func NewCSVOptions(options ...CSVOption) CSVOptions {
+	csvOptions := DefaultCSVOptions
+	for _, optionFunc := range options {
+		optionFunc(&csvOptions)
+	}
+	return csvOptions
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// CSV_encode
+//------------------------------------------------------------
+
+// CSV_encode converts records such as QueryRecords output into CSV
+func CSV_encode(records []map[string]any, options ...CSVOption) (string, error) {
+	//------------------------------------------------------------
+	var outputBuilder strings.Builder
+	//------------------------------------------------------------
+	csvOptions := NewCSVOptions(options...)
+	//------------------------------------------------------------
+	if csvOptions.Columns == nil {
+		options = append(options, WithCSVColumns(CSV_Columns(records)...))
+	}
+	//------------------------------------------------------------
+	csvWriter := NewCSVWriter(&outputBuilder, options...)
+	//------------------------------------------------------------
+	for _, record := range records {
+		if err := csvWriter.Write(record); err != nil {
+			return "", err
+		}
+	}
+	//------------------------------------------------------------
+	if err := csvWriter.Flush(); err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return outputBuilder.String(), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CSV_decode
+//------------------------------------------------------------
+
+// CSV_decode converts CSV into records
+func CSV_decode(csvString string, options ...CSVOption) ([]map[string]any, error) {
+	//------------------------------------------------------------
+	records := []map[string]any{}
+	//------------------------------------------------------------
+	csvReader := NewCSVReader(strings.NewReader(csvString), options...)
+	//------------------------------------------------------------
+	for {
+		//------------------------------------------------------------
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		//------------------------------------------------------------
+		records = append(records, record)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return records, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// TSV_encode
+//------------------------------------------------------------
+
+// TSV_encode converts records into tab separated values
+func TSV_encode(records []map[string]any, options ...CSVOption) (string, error) {
+	//------------------------------------------------------------
+	return CSV_encode(records, append([]CSVOption{WithCSVDelimiter('\t')}, options...)...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// TSV_decode
+//------------------------------------------------------------
+
+// TSV_decode converts tab separated values into records
+func TSV_decode(tsvString string, options ...CSVOption) ([]map[string]any, error) {
+	//------------------------------------------------------------
+	return CSV_decode(tsvString, append([]CSVOption{WithCSVDelimiter('\t')}, options...)...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CSV_Columns
+//------------------------------------------------------------
+
+// CSV_Columns returns the sorted names of every key used in records
+func CSV_Columns(records []map[string]any) []string {
+	//------------------------------------------------------------
+	columnMap := map[string]bool{}
+	//------------------------------------------------------------
+	for _, record := range records {
+		for key := range record {
+			columnMap[key] = true
+		}
+	}
+	//------------------------------------------------------------
+	columns := make([]string, 0, len(columnMap))
+	for column := range columnMap {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	//------------------------------------------------------------
+	return columns
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// CSVWriter streams records to an io.Writer
+//
+// a columns option selects and orders the fields written, otherwise the columns are
+// taken from the sorted keys of the first record and later records containing other keys return an error
+type CSVWriter struct {
+	writer        *bufio.Writer
+	options       CSVOptions
+	columnMap     map[string]bool
+	headerWritten bool
+	lineBytes     []byte
+}
+
+//------------------------------------------------------------
+// NewCSVWriter
+//------------------------------------------------------------
+
+func NewCSVWriter(w io.Writer, options ...CSVOption) *CSVWriter {
+	//------------------------------------------------------------
+	return &CSVWriter{writer: bufio.NewWriter(w), options: NewCSVOptions(options...)}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CSVWriter.Write
+//------------------------------------------------------------
+
+func (csvWriter *CSVWriter) Write(record map[string]any) error {
+	//------------------------------------------------------------
+	if csvWriter.options.Columns == nil {
+		csvWriter.options.Columns = CSV_Columns([]map[string]any{record})
+		csvWriter.columnMap = make(map[string]bool, len(csvWriter.options.Columns))
+		for _, column := range csvWriter.options.Columns {
+			csvWriter.columnMap[column] = true
+		}
+	}
+	//------------------------------------------------------------
+	if err := csvWriter.writeHeader(); err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	// columns taken from the first record must cover every later record
+	for key := range record {
+		if csvWriter.columnMap != nil && !csvWriter.columnMap[key] {
+			return fmt.Errorf("record contains column %q which is not in the csv columns", key)
+		}
+	}
+	//------------------------------------------------------------
+	csvWriter.lineBytes = csvWriter.lineBytes[:0]
+	//------------------------------------------------------------
+	for index, column := range csvWriter.options.Columns {
+		//------------------------------------------------------------
+		if index > 0 {
+			csvWriter.lineBytes = utf8.AppendRune(csvWriter.lineBytes, csvWriter.options.Delimiter)
+		}
+		//------------------------------------------------------------
+		value, exists := record[column]
+		if !exists {
+			value = nil
+		}
+		//------------------------------------------------------------
+		csvWriter.lineBytes = csvAppendField(csvWriter.lineBytes, csvFormatValue(value, csvWriter.options), csvWriter.options)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return csvWriter.writeLine()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CSVWriter.Flush
+//------------------------------------------------------------
+
+// Flush writes any buffered data and the header if no records were written
+func (csvWriter *CSVWriter) Flush() error {
+	//------------------------------------------------------------
+	if err := csvWriter.writeHeader(); err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	return csvWriter.writer.Flush()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CSVWriter.writeHeader
+//------------------------------------------------------------
+
+func (csvWriter *CSVWriter) writeHeader() error {
+	//------------------------------------------------------------
+	if csvWriter.headerWritten || !csvWriter.options.Header || len(csvWriter.options.Columns) == 0 {
+		return nil
+	}
+	//------------------------------------------------------------
+	csvWriter.headerWritten = true
+	//------------------------------------------------------------
+	csvWriter.lineBytes = csvWriter.lineBytes[:0]
+	//------------------------------------------------------------
+	for index, column := range csvWriter.options.Columns {
+		if index > 0 {
+			csvWriter.lineBytes = utf8.AppendRune(csvWriter.lineBytes, csvWriter.options.Delimiter)
+		}
+		csvWriter.lineBytes = csvAppendField(csvWriter.lineBytes, column, csvWriter.options)
+	}
+	//------------------------------------------------------------
+	return csvWriter.writeLine()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CSVWriter.writeLine
+//------------------------------------------------------------
+
+func (csvWriter *CSVWriter) writeLine() error {
+	//------------------------------------------------------------
+	if csvWriter.options.CRLF {
+		csvWriter.lineBytes = append(csvWriter.lineBytes, '\r', '\n')
+	} else {
+		csvWriter.lineBytes = append(csvWriter.lineBytes, '\n')
+	}
+	//------------------------------------------------------------
+	_, err := csvWriter.writer.Write(csvWriter.lineBytes)
+	//------------------------------------------------------------
+	return err
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// csvAppendField
+//------------------------------------------------------------
+
+func csvAppendField(dst []byte, field string, options CSVOptions) []byte {
+	//------------------------------------------------------------
+	quoteBool := options.QuoteAll || (field != "" && (field[0] == ' ' || field[0] == '\t')) ||
+		strings.ContainsRune(field, options.Delimiter) || strings.ContainsAny(field, "\"\r\n")
+	//------------------------------------------------------------
+	if !quoteBool {
+		return append(dst, field...)
+	}
+	//------------------------------------------------------------
+	dst = append(dst, '"')
+	dst = append(dst, strings.ReplaceAll(field, `"`, `""`)...)
+	//------------------------------------------------------------
+	return append(dst, '"')
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// csvFormatValue
+//------------------------------------------------------------
+
+func csvFormatValue(value any, options CSVOptions) string {
+	//------------------------------------------------------------
+	switch typedValue := value.(type) {
+	case nil:
+		return options.NullString
+	case string:
+		return typedValue
+	case []byte:
+		return string(typedValue)
+	case time.Time:
+		return typedValue.Format(options.TimeFormat)
+	case bool:
+		return strconv.FormatBool(typedValue)
+	case int:
+		return strconv.Itoa(typedValue)
+	case int64:
+		return strconv.FormatInt(typedValue, 10)
+	case uint:
+		return strconv.FormatUint(uint64(typedValue), 10)
+	case uint64:
+		return strconv.FormatUint(typedValue, 10)
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(typedValue), 'f', -1, 32)
+	default:
+		return fmt.Sprint(typedValue)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// CSVReader streams records from an io.Reader
+//
+// a columns option replaces the names in the header row and without either
+// the columns are named "column1", "column2" and so on
+type CSVReader struct {
+	reader        *csv.Reader
+	options       CSVOptions
+	columns       []string
+	headerPending bool
+}
+
+//------------------------------------------------------------
+// NewCSVReader
+//------------------------------------------------------------
+
+func NewCSVReader(r io.Reader, options ...CSVOption) *CSVReader {
+	//------------------------------------------------------------
+	csvOptions := NewCSVOptions(options...)
+	//------------------------------------------------------------
+	reader := csv.NewReader(r)
+	reader.Comma = csvOptions.Delimiter
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	//------------------------------------------------------------
+	return &CSVReader{reader: reader, options: csvOptions, columns: csvOptions.Columns, headerPending: csvOptions.Header}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CSVReader.Columns
+//------------------------------------------------------------
+
+// Columns returns the column names, reading the header row first if needed
+func (csvReader *CSVReader) Columns() ([]string, error) {
+	//------------------------------------------------------------
+	if !csvReader.headerPending {
+		return csvReader.columns, nil
+	}
+	//------------------------------------------------------------
+	header, err := csvReader.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	csvReader.headerPending = false
+	//------------------------------------------------------------
+	if csvReader.columns == nil {
+		csvReader.columns = append([]string{}, header...)
+	}
+	//------------------------------------------------------------
+	return csvReader.columns, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CSVReader.Read
+//------------------------------------------------------------
+
+// Read returns the next record or io.EOF when there are no more records
+func (csvReader *CSVReader) Read() (map[string]any, error) {
+	//------------------------------------------------------------
+	columns, err := csvReader.Columns()
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	fields, err := csvReader.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if columns == nil {
+		for index := range fields {
+			columns = append(columns, "column"+strconv.Itoa(index+1))
+		}
+		csvReader.columns = columns
+	}
+	//------------------------------------------------------------
+	if len(fields) != len(columns) {
+		line, _ := csvReader.reader.FieldPos(0)
+		return nil, fmt.Errorf("csv line %d has %d fields but should have %d", line, len(fields), len(columns))
+	}
+	//------------------------------------------------------------
+	record := make(map[string]any, len(columns))
+	//------------------------------------------------------------
+	for index, column := range columns {
+		record[column] = csvParseValue(fields[index], csvReader.options)
+	}
+	//------------------------------------------------------------
+	return record, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// csvParseValue
+//------------------------------------------------------------
+
+func csvParseValue(field string, options CSVOptions) any {
+	//------------------------------------------------------------
+	if options.NullString != "" && field == options.NullString {
+		return nil
+	}
+	//------------------------------------------------------------
+	if !options.InferTypes || field == "" {
+		return field
+	}
+	//------------------------------------------------------------
+	switch strings.ToLower(field) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	//------------------------------------------------------------
+	// numbers with leading zeros such as codes are left as strings
+	digits := strings.TrimPrefix(field, "-")
+	leadingZero := len(digits) > 1 && digits[0] == '0' && digits[1] != '.'
+	//------------------------------------------------------------
+	if digits != "" && digits[0] >= '0' && digits[0] <= '9' && !leadingZero {
+		//------------------------------------------------------------
+		if intValue, err := strconv.Atoi(field); err == nil {
+			return intValue
+		}
+		//------------------------------------------------------------
+		if strings.Trim(digits, "0123456789.eE+-") == "" {
+			if floatValue, err := strconv.ParseFloat(field, 64); err == nil {
+				return floatValue
+			}
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if options.TimeFormat != "" {
+		if timeValue, err := time.Parse(options.TimeFormat, field); err == nil {
+			return timeValue
+		}
+	}
+	//------------------------------------------------------------
+	return field
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,197 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+func csvTestRecords() []map[string]any {
+	//--------------------------------------------------
+	return []map[string]any{
+		{"id": 1, "name": "Alice", "score": 9.5, "active": true, "created": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "note": nil},
+		{"id": 2, "name": "Bob, \"Jr\"", "score": float64(10), "active": false, "created": time.Date(2024, 6, 7, 8, 9, 10, 0, time.UTC), "note": []byte("multi\nline")},
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CSV_encode
+//------------------------------------------------------------
+
+func TestCSV_encode(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		options  []CSVOption
+		expected string
+	}{
+		{
+			nil,
+			"active,created,id,name,note,score\n" +
+				"true,2024-01-02T03:04:05Z,1,Alice,,9.5\n" +
+				"false,2024-06-07T08:09:10Z,2,\"Bob, \"\"Jr\"\"\",\"multi\nline\",10\n",
+		},
+		{
+			[]CSVOption{WithCSVColumns("id", "note"), WithCSVNullString("NULL"), WithCSVCRLF(true)},
+			"id,note\r\n1,NULL\r\n2,\"multi\nline\"\r\n",
+		},
+		{
+			[]CSVOption{WithCSVColumns("id", "created"), WithCSVHeader(false), WithCSVQuoteAll(true), WithCSVTimeFormat(time.DateOnly)},
+			"\"1\",\"2024-01-02\"\n\"2\",\"2024-06-07\"\n",
+		},
+		{
+			[]CSVOption{WithCSVColumns("name", "id"), WithCSVDelimiter(';')},
+			"name;id\nAlice;1\n\"Bob, \"\"Jr\"\"\";2\n",
+		},
+	}
+	//--------------------------------------------------
+	for index, testCase := range testCases {
+		//--------------------------------------------------
+		result, err := CSV_encode(csvTestRecords(), testCase.options...)
+		//--------------------------------------------------
+		if err != nil {
+			t.Errorf("test case %d returned error = %v", index, err)
+		} else if result != testCase.expected {
+			t.Errorf("test case %d = %q but should = %q", index, result, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CSV_decode
+//------------------------------------------------------------
+
+func TestCSV_decode(t *testing.T) {
+	//--------------------------------------------------
+	csvString := "id,name,score,active,zip,created,note\n" +
+		"1,\"Bob, \"\"Jr\"\"\",9.5,true,01234,2024-01-02T03:04:05Z,NULL\n" +
+		"-2,,1e3,FALSE,0,x,\n"
+	//--------------------------------------------------
+	records, err := CSV_decode(csvString)
+	//--------------------------------------------------
+	if err != nil {
+		t.Fatalf("returned error = %v", err)
+	}
+	//--------------------------------------------------
+	expected := `[map[active:true created:2024-01-02T03:04:05Z id:1 name:Bob, "Jr" note:NULL score:9.5 zip:01234] map[active:FALSE created:x id:-2 name: note: score:1e3 zip:0]]`
+	if result := fmt.Sprint(records); result != expected {
+		t.Errorf("records = %s but should = %s", result, expected)
+	}
+	//--------------------------------------------------
+	records, err = CSV_decode(csvString, WithCSVInferTypes(true), WithCSVNullString("NULL"))
+	//--------------------------------------------------
+	if err != nil {
+		t.Fatalf("returned error = %v", err)
+	}
+	//--------------------------------------------------
+	testCases := []struct {
+		value    any
+		expected any
+	}{
+		{records[0]["id"], 1},
+		{records[0]["name"], `Bob, "Jr"`},
+		{records[0]["score"], 9.5},
+		{records[0]["active"], true},
+		{records[0]["zip"], "01234"},
+		{records[0]["created"], time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{records[0]["note"], nil},
+		{records[1]["id"], -2},
+		{records[1]["name"], ""},
+		{records[1]["score"], float64(1000)},
+		{records[1]["active"], false},
+		{records[1]["zip"], 0},
+		{records[1]["created"], "x"},
+	}
+	//--------------------------------------------------
+	for index, testCase := range testCases {
+		if testCase.value != testCase.expected {
+			t.Errorf("test case %d = %#v but should = %#v", index, testCase.value, testCase.expected)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func TestCSV_decodeColumns(t *testing.T) {
+	//--------------------------------------------------
+	records, err := TSV_decode("a\tb\n1\t2\n", WithCSVHeader(false))
+	//--------------------------------------------------
+	if result := fmt.Sprint(records); err != nil || result != "[map[column1:a column2:b] map[column1:1 column2:2]]" {
+		t.Errorf("records = %s, error = %v", result, err)
+	}
+	//--------------------------------------------------
+	records, err = TSV_decode("a\tb\n1\t2\n", WithCSVColumns("x", "y"))
+	//--------------------------------------------------
+	if result := fmt.Sprint(records); err != nil || result != "[map[x:1 y:2]]" {
+		t.Errorf("records = %s, error = %v", result, err)
+	}
+	//--------------------------------------------------
+	if _, err = CSV_decode("a,b\n1,2,3\n"); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("error = %v but should report line 2", err)
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// CSVWriter / CSVReader
+//------------------------------------------------------------
+
+func TestCSVWriterReader(t *testing.T) {
+	//--------------------------------------------------
+	var builder strings.Builder
+	//--------------------------------------------------
+	csvWriter := NewCSVWriter(&builder, WithCSVDelimiter('\t'))
+	//--------------------------------------------------
+	for index := 0; index < 1000; index++ {
+		if err := csvWriter.Write(map[string]any{"id": index, "text": "line\t" + fmt.Sprint(index)}); err != nil {
+			t.Fatalf("Write returned error = %v", err)
+		}
+	}
+	//--------------------------------------------------
+	if err := csvWriter.Write(map[string]any{"other": 1}); err == nil {
+		t.Errorf("Write with an unknown column should return an error")
+	}
+	//--------------------------------------------------
+	if err := csvWriter.Flush(); err != nil {
+		t.Fatalf("Flush returned error = %v", err)
+	}
+	//--------------------------------------------------
+	csvReader := NewCSVReader(strings.NewReader(builder.String()), WithCSVDelimiter('\t'), WithCSVInferTypes(true))
+	//--------------------------------------------------
+	columns, err := csvReader.Columns()
+	if err != nil || fmt.Sprint(columns) != "[id text]" {
+		t.Errorf("columns = %v, error = %v", columns, err)
+	}
+	//--------------------------------------------------
+	for index := 0; index < 1000; index++ {
+		//--------------------------------------------------
+		record, err := csvReader.Read()
+		if err != nil {
+			t.Fatalf("Read returned error = %v", err)
+		}
+		//--------------------------------------------------
+		if record["id"] != index || record["text"] != "line\t"+fmt.Sprint(index) {
+			t.Fatalf("record = %v", record)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	if _, err = csvReader.Read(); err == nil {
+		t.Errorf("Read should return io.EOF after the last record")
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,559 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffDelete
+	DiffInsert
+)
+
+func (op DiffOp) String() string {
+	switch op {
+	case DiffDelete:
+		return "delete"
+	case DiffInsert:
+		return "insert"
+	default:
+		return "equal"
+	}
+}
+
+// DiffEdit is a line or run of words that is kept, deleted from the old text or inserted from the new text
+type DiffEdit struct {
+	Op   DiffOp
+	Text string
+}
+
+//------------------------------------------------------------
+
+type DiffChangeType string
+
+const (
+	DiffAdded   DiffChangeType = "added"
+	DiffRemoved DiffChangeType = "removed"
+	DiffChanged DiffChangeType = "changed"
+)
+
+// DiffChange is a difference between two decoded JSON / YAML trees at a JSON pointer path
+type DiffChange struct {
+	Type     DiffChangeType
+	Path     string
+	OldValue any
+	NewValue any
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Diff_Lines
+//------------------------------------------------------------
+
+// Diff_Lines returns one edit per line, each line keeps its "\n" apart from a final unterminated line
+func Diff_Lines(oldString string, newString string) []DiffEdit {
+	//------------------------------------------------------------
+	return diffMyers(diffSplitLines(oldString), diffSplitLines(newString))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Diff_Words
+//------------------------------------------------------------
+
+// Diff_Words compares words, whitespace and punctuation and merges neighbouring edits of the same kind
+func Diff_Words(oldString string, newString string) []DiffEdit {
+	//------------------------------------------------------------
+	edits := diffMyers(diffSplitWords(oldString), diffSplitWords(newString))
+	//------------------------------------------------------------
+	mergedEdits := make([]DiffEdit, 0, len(edits))
+	//------------------------------------------------------------
+	for _, edit := range edits {
+		if len(mergedEdits) > 0 && mergedEdits[len(mergedEdits)-1].Op == edit.Op {
+			mergedEdits[len(mergedEdits)-1].Text += edit.Text
+		} else {
+			mergedEdits = append(mergedEdits, edit)
+		}
+	}
+	//------------------------------------------------------------
+	return mergedEdits
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// diffSplitLines
+//------------------------------------------------------------
+
+func diffSplitLines(dataString string) []string {
+	//------------------------------------------------------------
+	if dataString == "" {
+		return nil
+	}
+	//------------------------------------------------------------
+	lines := strings.SplitAfter(dataString, "\n")
+	//------------------------------------------------------------
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	//------------------------------------------------------------
+	return lines
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// diffSplitWords
+//------------------------------------------------------------
+
+func diffSplitWords(dataString string) []string {
+	//------------------------------------------------------------
+	var tokens []string
+	//------------------------------------------------------------
+	tokenClass := func(char rune) int {
+		switch {
+		case unicode.IsSpace(char):
+			return 1
+		case unicode.IsLetter(char) || unicode.IsDigit(char) || char == '_':
+			return 2
+		default:
+			return 3
+		}
+	}
+	//------------------------------------------------------------
+	for len(dataString) > 0 {
+		//------------------------------------------------------------
+		char, size := utf8.DecodeRuneInString(dataString)
+		class := tokenClass(char)
+		//------------------------------------------------------------
+		// punctuation is compared one character at a time
+		if class != 3 {
+			for size < len(dataString) {
+				nextChar, nextSize := utf8.DecodeRuneInString(dataString[size:])
+				if tokenClass(nextChar) != class {
+					break
+				}
+				size += nextSize
+			}
+		}
+		//------------------------------------------------------------
+		tokens = append(tokens, dataString[:size])
+		dataString = dataString[size:]
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return tokens
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// diffMyers
+//------------------------------------------------------------
+
+// diffMyers is the O(ND) algorithm from Myers "An O(ND) Difference Algorithm and Its Variations"
+//
+// the common prefix and suffix are removed first so typical edits to large documents stay cheap
+func diffMyers(oldTokens []string, newTokens []string) []DiffEdit {
+	//------------------------------------------------------------
+	prefixLength := 0
+	for prefixLength < len(oldTokens) && prefixLength < len(newTokens) && oldTokens[prefixLength] == newTokens[prefixLength] {
+		prefixLength++
+	}
+	//------------------------------------------------------------
+	suffixLength := 0
+	for suffixLength < len(oldTokens)-prefixLength && suffixLength < len(newTokens)-prefixLength &&
+		oldTokens[len(oldTokens)-1-suffixLength] == newTokens[len(newTokens)-1-suffixLength] {
+		suffixLength++
+	}
+	//------------------------------------------------------------
+	edits := make([]DiffEdit, 0, len(oldTokens)+len(newTokens))
+	//------------------------------------------------------------
+	for _, token := range oldTokens[:prefixLength] {
+		edits = append(edits, DiffEdit{DiffEqual, token})
+	}
+	//------------------------------------------------------------
+	edits = append(edits, diffMyersMiddle(oldTokens[prefixLength:len(oldTokens)-suffixLength], newTokens[prefixLength:len(newTokens)-suffixLength])...)
+	//------------------------------------------------------------
+	for _, token := range oldTokens[len(oldTokens)-suffixLength:] {
+		edits = append(edits, DiffEdit{DiffEqual, token})
+	}
+	//------------------------------------------------------------
+	return edits
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// diffMyersMiddle
+//------------------------------------------------------------
+
+func diffMyersMiddle(oldTokens []string, newTokens []string) []DiffEdit {
+	//------------------------------------------------------------
+	oldLength, newLength := len(oldTokens), len(newTokens)
+	maxSteps := oldLength + newLength
+	//------------------------------------------------------------
+	if maxSteps == 0 {
+		return nil
+	}
+	//------------------------------------------------------------
+	// furthest x reached on each diagonal k = x - y, trace[d] keeps diagonals -d to d after d steps
+	offset := maxSteps + 1
+	furthest := make([]int, 2*maxSteps+3)
+	//------------------------------------------------------------
+	var trace [][]int
+	//------------------------------------------------------------
+	for steps := 0; steps <= maxSteps; steps++ {
+		//------------------------------------------------------------
+		done := false
+		//------------------------------------------------------------
+		for k := -steps; k <= steps; k += 2 {
+			//------------------------------------------------------------
+			var x int
+			if k == -steps || (k != steps && furthest[offset+k-1] < furthest[offset+k+1]) {
+				x = furthest[offset+k+1]
+			} else {
+				x = furthest[offset+k-1] + 1
+			}
+			y := x - k
+			//------------------------------------------------------------
+			for x < oldLength && y < newLength && oldTokens[x] == newTokens[y] {
+				x++
+				y++
+			}
+			//------------------------------------------------------------
+			furthest[offset+k] = x
+			//------------------------------------------------------------
+			if x >= oldLength && y >= newLength {
+				done = true
+			}
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+		trace = append(trace, append([]int(nil), furthest[offset-steps:offset+steps+1]...))
+		//------------------------------------------------------------
+		if done {
+			break
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	// walk back from the end collecting the edits in reverse
+	var reversedEdits []DiffEdit
+	//------------------------------------------------------------
+	x, y := oldLength, newLength
+	//------------------------------------------------------------
+	for steps := len(trace) - 1; steps > 0; steps-- {
+		//------------------------------------------------------------
+		previous := trace[steps-1]
+		k := x - y
+		//------------------------------------------------------------
+		var previousK int
+		if k == -steps || (k != steps && previous[k-1+steps-1] < previous[k+1+steps-1]) {
+			previousK = k + 1
+		} else {
+			previousK = k - 1
+		}
+		//------------------------------------------------------------
+		previousX := previous[previousK+steps-1]
+		previousY := previousX - previousK
+		//------------------------------------------------------------
+		for x > previousX && y > previousY {
+			x--
+			y--
+			reversedEdits = append(reversedEdits, DiffEdit{DiffEqual, oldTokens[x]})
+		}
+		//------------------------------------------------------------
+		if x == previousX {
+			y--
+			reversedEdits = append(reversedEdits, DiffEdit{DiffInsert, newTokens[y]})
+		} else {
+			x--
+			reversedEdits = append(reversedEdits, DiffEdit{DiffDelete, oldTokens[x]})
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	for x > 0 && y > 0 {
+		x--
+		y--
+		reversedEdits = append(reversedEdits, DiffEdit{DiffEqual, oldTokens[x]})
+	}
+	//------------------------------------------------------------
+	edits := make([]DiffEdit, len(reversedEdits))
+	for index, edit := range reversedEdits {
+		edits[len(reversedEdits)-1-index] = edit
+	}
+	//------------------------------------------------------------
+	return diffOrderChanges(edits)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// diffOrderChanges
+//------------------------------------------------------------
+
+// diffOrderChanges puts deletes before inserts within each run of changes as unified diffs do
+func diffOrderChanges(edits []DiffEdit) []DiffEdit {
+	//------------------------------------------------------------
+	for start := 0; start < len(edits); {
+		//------------------------------------------------------------
+		if edits[start].Op == DiffEqual {
+			start++
+			continue
+		}
+		//------------------------------------------------------------
+		end := start
+		for end < len(edits) && edits[end].Op != DiffEqual {
+			end++
+		}
+		//------------------------------------------------------------
+		orderedEdits := make([]DiffEdit, 0, end-start)
+		for _, op := range []DiffOp{DiffDelete, DiffInsert} {
+			for _, edit := range edits[start:end] {
+				if edit.Op == op {
+					orderedEdits = append(orderedEdits, edit)
+				}
+			}
+		}
+		copy(edits[start:end], orderedEdits)
+		//------------------------------------------------------------
+		start = end
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return edits
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Diff_Unified
+//------------------------------------------------------------
+
+// Diff_Unified returns a unified diff of two texts as produced by "diff -u", or "" when they are equal
+func Diff_Unified(oldString string, newString string, oldName string, newName string, contextLines int) string {
+	//------------------------------------------------------------
+	edits := Diff_Lines(oldString, newString)
+	//------------------------------------------------------------
+	var changeIndexes []int
+	for index, edit := range edits {
+		if edit.Op != DiffEqual {
+			changeIndexes = append(changeIndexes, index)
+		}
+	}
+	//------------------------------------------------------------
+	if len(changeIndexes) == 0 {
+		return ""
+	}
+	//------------------------------------------------------------
+	contextLines = max(contextLines, 0)
+	//------------------------------------------------------------
+	// line positions before each edit
+	oldPositions := make([]int, len(edits)+1)
+	newPositions := make([]int, len(edits)+1)
+	for index, edit := range edits {
+		oldPositions[index+1], newPositions[index+1] = oldPositions[index], newPositions[index]
+		if edit.Op != DiffInsert {
+			oldPositions[index+1]++
+		}
+		if edit.Op != DiffDelete {
+			newPositions[index+1]++
+		}
+	}
+	//------------------------------------------------------------
+	var outputBuilder strings.Builder
+	//------------------------------------------------------------
+	outputBuilder.WriteString("--- " + oldName + "\n")
+	outputBuilder.WriteString("+++ " + newName + "\n")
+	//------------------------------------------------------------
+	for hunkStart := 0; hunkStart < len(changeIndexes); {
+		//------------------------------------------------------------
+		// changes separated by no more than twice the context share a hunk
+		hunkEnd := hunkStart
+		for hunkEnd+1 < len(changeIndexes) && changeIndexes[hunkEnd+1]-changeIndexes[hunkEnd] <= 2*contextLines+1 {
+			hunkEnd++
+		}
+		//------------------------------------------------------------
+		start := max(changeIndexes[hunkStart]-contextLines, 0)
+		end := min(changeIndexes[hunkEnd]+contextLines+1, len(edits))
+		//------------------------------------------------------------
+		outputBuilder.WriteString(fmt.Sprintf("@@ -%s +%s @@\n",
+			diffHunkRange(oldPositions[start], oldPositions[end]-oldPositions[start]),
+			diffHunkRange(newPositions[start], newPositions[end]-newPositions[start]),
+		))
+		//------------------------------------------------------------
+		for _, edit := range edits[start:end] {
+			//------------------------------------------------------------
+			switch edit.Op {
+			case DiffDelete:
+				outputBuilder.WriteString("-")
+			case DiffInsert:
+				outputBuilder.WriteString("+")
+			default:
+				outputBuilder.WriteString(" ")
+			}
+			//------------------------------------------------------------
+			outputBuilder.WriteString(edit.Text)
+			//------------------------------------------------------------
+			if !strings.HasSuffix(edit.Text, "\n") {
+				outputBuilder.WriteString("\n\\ No newline at end of file\n")
+			}
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+		hunkStart = hunkEnd + 1
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return outputBuilder.String()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// diffHunkRange
+//------------------------------------------------------------
+
+func diffHunkRange(position int, count int) string {
+	//------------------------------------------------------------
+	// an empty range names the line before it
+	if count == 0 {
+		return strconv.Itoa(position) + ",0"
+	}
+	//------------------------------------------------------------
+	if count == 1 {
+		return strconv.Itoa(position + 1)
+	}
+	//------------------------------------------------------------
+	return strconv.Itoa(position+1) + "," + strconv.Itoa(count)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Diff_Tree
+//------------------------------------------------------------
+
+// Diff_Tree compares decoded JSON / YAML documents and returns the added, removed and changed paths
+//
+// map keys are visited in sorted order and arrays are compared index by index
+func Diff_Tree(oldValue any, newValue any) []DiffChange {
+	//------------------------------------------------------------
+	return diffTree(oldValue, newValue, "", []DiffChange{})
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// diffTree
+//------------------------------------------------------------
+
+func diffTree(oldValue any, newValue any, path string, changes []DiffChange) []DiffChange {
+	//------------------------------------------------------------
+	oldMap, oldIsMap := diffTreeMap(oldValue)
+	newMap, newIsMap := diffTreeMap(newValue)
+	//------------------------------------------------------------
+	if oldIsMap && newIsMap {
+		//------------------------------------------------------------
+		for _, key := range sortedKeys(oldMap) {
+			//------------------------------------------------------------
+			keyPath := path + JSONPointer_Join(key)
+			//------------------------------------------------------------
+			if newMapValue, exists := newMap[key]; exists {
+				changes = diffTree(oldMap[key], newMapValue, keyPath, changes)
+			} else {
+				changes = append(changes, DiffChange{Type: DiffRemoved, Path: keyPath, OldValue: oldMap[key]})
+			}
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+		for _, key := range sortedKeys(newMap) {
+			if _, exists := oldMap[key]; !exists {
+				changes = append(changes, DiffChange{Type: DiffAdded, Path: path + JSONPointer_Join(key), NewValue: newMap[key]})
+			}
+		}
+		//------------------------------------------------------------
+		return changes
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	oldSlice, oldIsSlice := oldValue.([]any)
+	newSlice, newIsSlice := newValue.([]any)
+	//------------------------------------------------------------
+	if oldIsSlice && newIsSlice {
+		//------------------------------------------------------------
+		for index := 0; index < max(len(oldSlice), len(newSlice)); index++ {
+			//------------------------------------------------------------
+			indexPath := path + "/" + strconv.Itoa(index)
+			//------------------------------------------------------------
+			switch {
+			case index >= len(newSlice):
+				changes = append(changes, DiffChange{Type: DiffRemoved, Path: indexPath, OldValue: oldSlice[index]})
+			case index >= len(oldSlice):
+				changes = append(changes, DiffChange{Type: DiffAdded, Path: indexPath, NewValue: newSlice[index]})
+			default:
+				changes = diffTree(oldSlice[index], newSlice[index], indexPath, changes)
+			}
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+		return changes
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if !JSON_Equal(oldValue, newValue) {
+		changes = append(changes, DiffChange{Type: DiffChanged, Path: path, OldValue: oldValue, NewValue: newValue})
+	}
+	//------------------------------------------------------------
+	return changes
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// diffTreeMap
+//------------------------------------------------------------
+
+// diffTreeMap also accepts the map[any]any nodes some YAML decoders produce
+func diffTreeMap(value any) (map[string]any, bool) {
+	//------------------------------------------------------------
+	switch typedValue := value.(type) {
+	case map[string]any:
+		return typedValue, true
+	case map[any]any:
+		stringMap := make(map[string]any, len(typedValue))
+		for key, mapValue := range typedValue {
+			stringMap[fmt.Sprint(key)] = mapValue
+		}
+		return stringMap, true
+	}
+	//------------------------------------------------------------
+	return nil, false
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
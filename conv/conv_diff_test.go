@@ -0,0 +1,251 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+func diffTestString(edits []DiffEdit) string {
+	//--------------------------------------------------
+	var outputBuilder strings.Builder
+	//--------------------------------------------------
+	for _, edit := range edits {
+		switch edit.Op {
+		case DiffDelete:
+			outputBuilder.WriteString("[-" + edit.Text + "]")
+		case DiffInsert:
+			outputBuilder.WriteString("[+" + edit.Text + "]")
+		default:
+			outputBuilder.WriteString(edit.Text)
+		}
+	}
+	//--------------------------------------------------
+	return outputBuilder.String()
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func diffTestApply(edits []DiffEdit) (string, string) {
+	//--------------------------------------------------
+	var oldBuilder, newBuilder strings.Builder
+	//--------------------------------------------------
+	for _, edit := range edits {
+		if edit.Op != DiffInsert {
+			oldBuilder.WriteString(edit.Text)
+		}
+		if edit.Op != DiffDelete {
+			newBuilder.WriteString(edit.Text)
+		}
+	}
+	//--------------------------------------------------
+	return oldBuilder.String(), newBuilder.String()
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Diff_Lines
+//------------------------------------------------------------
+
+func TestDiff_Lines(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		oldString string
+		newString string
+		expected  string
+	}{
+		{"", "", ""},
+		{"a\n", "a\n", "a\n"},
+		{"", "a\nb\n", "[+a\n][+b\n]"},
+		{"a\nb\n", "", "[-a\n][-b\n]"},
+		{"a\nb\nc\n", "a\nx\nc\n", "a\n[-b\n][+x\n]c\n"},
+		{"a\nb\nc\nd\n", "b\nc\nd\ne\n", "[-a\n]b\nc\nd\n[+e\n]"},
+		{"a\nb", "a\nb\n", "a\n[-b][+b\n]"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		result := diffTestString(Diff_Lines(testCase.oldString, testCase.newString))
+		//--------------------------------------------------
+		if result != testCase.expected {
+			t.Errorf("(%q, %q) = %q but should = %q", testCase.oldString, testCase.newString, result, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func TestDiff_LinesMinimal(t *testing.T) {
+	//--------------------------------------------------
+	// example from the Myers paper, the shortest edit script has 5 changes
+	edits := Diff_Lines("a\nb\nc\na\nb\nb\na\n", "c\nb\na\nb\na\nc\n")
+	//--------------------------------------------------
+	changeCount := 0
+	for _, edit := range edits {
+		if edit.Op != DiffEqual {
+			changeCount++
+		}
+	}
+	//--------------------------------------------------
+	if changeCount != 5 {
+		t.Errorf("changes = %d but should = 5 (%q)", changeCount, diffTestString(edits))
+	}
+	//--------------------------------------------------
+	random := rand.New(rand.NewSource(1))
+	//--------------------------------------------------
+	for count := 0; count < 200; count++ {
+		//--------------------------------------------------
+		var oldLines, newLines []string
+		for index := random.Intn(20); index > 0; index-- {
+			oldLines = append(oldLines, fmt.Sprintf("%d\n", random.Intn(4)))
+		}
+		for index := random.Intn(20); index > 0; index-- {
+			newLines = append(newLines, fmt.Sprintf("%d\n", random.Intn(4)))
+		}
+		//--------------------------------------------------
+		oldString, newString := diffTestApply(Diff_Lines(strings.Join(oldLines, ""), strings.Join(newLines, "")))
+		//--------------------------------------------------
+		if oldString != strings.Join(oldLines, "") || newString != strings.Join(newLines, "") {
+			t.Fatalf("edits do not rebuild (%q, %q)", oldLines, newLines)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Diff_Words
+//------------------------------------------------------------
+
+func TestDiff_Words(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		oldString string
+		newString string
+		expected  string
+	}{
+		{"the quick brown fox", "the quick red fox", "the quick [-brown][+red] fox"},
+		{"port: 8080", "port: 9090", "port: [-8080][+9090]"},
+		{"a b", "a  b c", "a[- ][+  ]b[+ c]"},
+		{"héllo wörld", "héllo world", "héllo [-wörld][+world]"},
+		{"x=1,y=2", "x=1;y=2", "x=1[-,][+;]y=2"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		result := diffTestString(Diff_Words(testCase.oldString, testCase.newString))
+		//--------------------------------------------------
+		if result != testCase.expected {
+			t.Errorf("(%q, %q) = %q but should = %q", testCase.oldString, testCase.newString, result, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Diff_Unified
+//------------------------------------------------------------
+
+func TestDiff_Unified(t *testing.T) {
+	//--------------------------------------------------
+	oldString := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	newString := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n"
+	//--------------------------------------------------
+	testCases := []struct {
+		oldString    string
+		newString    string
+		contextLines int
+		expected     string
+	}{
+		{oldString, oldString, 3, ""},
+		{
+			oldString, newString, 3,
+			"--- old\n+++ new\n@@ -1,6 +1,6 @@\n 1\n 2\n-3\n+three\n 4\n 5\n 6\n@@ -10,3 +10,4 @@\n 10\n 11\n 12\n+13\n",
+		},
+		{
+			oldString, newString, 5,
+			"--- old\n+++ new\n@@ -1,12 +1,13 @@\n 1\n 2\n-3\n+three\n 4\n 5\n 6\n 7\n 8\n 9\n 10\n 11\n 12\n+13\n",
+		},
+		{
+			oldString, newString, 0,
+			"--- old\n+++ new\n@@ -3 +3 @@\n-3\n+three\n@@ -12,0 +13 @@\n+13\n",
+		},
+		{"", "a\n", 3, "--- old\n+++ new\n@@ -0,0 +1 @@\n+a\n"},
+		{"a\nb", "a\nc", 3, "--- old\n+++ new\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		result := Diff_Unified(testCase.oldString, testCase.newString, "old", "new", testCase.contextLines)
+		//--------------------------------------------------
+		if result != testCase.expected {
+			t.Errorf("(%d) = %q but should = %q", testCase.contextLines, result, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Diff_Tree
+//------------------------------------------------------------
+
+func TestDiff_Tree(t *testing.T) {
+	//--------------------------------------------------
+	oldDocument, _ := JSON_decode(`{"name":"app","port":8080,"tags":["a","b","c"],"db":{"host":"x","user":"u"},"a/b":1}`)
+	newDocument, _ := JSON_decode(`{"name":"app","port":9090,"tags":["a","z"],"db":{"host":"x","pass":"p"},"a/b":1,"debug":true}`)
+	//--------------------------------------------------
+	expected := []DiffChange{
+		{DiffRemoved, "/db/user", "u", nil},
+		{DiffAdded, "/db/pass", nil, "p"},
+		{DiffChanged, "/port", 8080.0, 9090.0},
+		{DiffChanged, "/tags/1", "b", "z"},
+		{DiffRemoved, "/tags/2", "c", nil},
+		{DiffAdded, "/debug", nil, true},
+	}
+	//--------------------------------------------------
+	result := Diff_Tree(oldDocument, newDocument)
+	//--------------------------------------------------
+	if fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.Errorf("result = %v but should = %v", result, expected)
+	}
+	//--------------------------------------------------
+	// YAML decoders produce int values and may produce map[any]any nodes
+	yamlResult := Diff_Tree(map[string]any{"port": 8080, "db": map[any]any{"host": "x"}}, map[string]any{"port": 8080.0, "db": map[string]any{"host": "y"}})
+	//--------------------------------------------------
+	if fmt.Sprint(yamlResult) != fmt.Sprint([]DiffChange{{DiffChanged, "/db/host", "x", "y"}}) {
+		t.Errorf("yamlResult = %v", yamlResult)
+	}
+	//--------------------------------------------------
+	if bigResult := Diff_Tree(map[string]any{"id": int64(9007199254740993)}, map[string]any{"id": int64(9007199254740992)}); len(bigResult) != 1 {
+		t.Errorf("bigResult = %v should report the changed id", bigResult)
+	}
+	//--------------------------------------------------
+	if typeResult := Diff_Tree([]any{1.0}, map[string]any{}); len(typeResult) != 1 || typeResult[0].Type != DiffChanged || typeResult[0].Path != "" {
+		t.Errorf("typeResult = %v", typeResult)
+	}
+	//--------------------------------------------------
+	if equalResult := Diff_Tree(oldDocument, JSON_Copy(oldDocument)); len(equalResult) != 0 {
+		t.Errorf("equalResult = %v but should be empty", equalResult)
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,610 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+var ErrHumanParse = errors.New("cannot parse human value")
+
+var humanSIUnits = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+var humanIECUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+var humanDurationUnits = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"us", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+var humanDurationNames = map[string]time.Duration{
+	"ns": time.Nanosecond, "nanosecond": time.Nanosecond, "nanoseconds": time.Nanosecond,
+	"us": time.Microsecond, "µs": time.Microsecond, "μs": time.Microsecond, "microsecond": time.Microsecond, "microseconds": time.Microsecond,
+	"ms": time.Millisecond, "millisecond": time.Millisecond, "milliseconds": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+var humanRelativeUnits = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"week", 7 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Human_FormatBytes
+//------------------------------------------------------------
+
+// Human_FormatBytes formats a byte count as SI ("1.5 GB") or IEC ("512 MiB") units
+//
+// precision is the maximum number of decimals, -1 gives as many as Human_ParseBytes needs to return size exactly
+func Human_FormatBytes(size int64, iec bool, precision int) string {
+	//------------------------------------------------------------
+	base, units, unitDigits := int64(1000), humanSIUnits, 3
+	if iec {
+		base, units, unitDigits = 1024, humanIECUnits, 10
+	}
+	//------------------------------------------------------------
+	sign := ""
+	absSize := new(big.Int).SetInt64(size)
+	if size < 0 {
+		sign = "-"
+		absSize.Neg(absSize)
+	}
+	//------------------------------------------------------------
+	unitIndex := 0
+	unitValue := big.NewInt(1)
+	for unitIndex < len(units)-1 && absSize.Cmp(new(big.Int).Mul(unitValue, big.NewInt(base))) >= 0 {
+		unitIndex++
+		unitValue.Mul(unitValue, big.NewInt(base))
+	}
+	//------------------------------------------------------------
+	if unitIndex == 0 {
+		return sign + absSize.String() + " B"
+	}
+	//------------------------------------------------------------
+	// big.Rat keeps the division exact, a power of 1000 or 1024 needs at most 3 or 10 decimals per unit
+	value := new(big.Rat).SetFrac(absSize, unitValue)
+	//------------------------------------------------------------
+	if precision < 0 {
+		return sign + humanTrimZeros(value.FloatString(unitIndex*unitDigits)) + " " + units[unitIndex]
+	}
+	//------------------------------------------------------------
+	// rounding 999.96 kB to one decimal moves it up to the next unit
+	if roundedValue, _ := new(big.Rat).SetString(value.FloatString(precision)); unitIndex < len(units)-1 && roundedValue.Cmp(new(big.Rat).SetInt64(base)) >= 0 {
+		unitIndex++
+		value.Quo(value, new(big.Rat).SetInt64(base))
+	}
+	//------------------------------------------------------------
+	return sign + humanTrimZeros(value.FloatString(precision)) + " " + units[unitIndex]
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_ParseBytes
+//------------------------------------------------------------
+
+// Human_ParseBytes parses sizes such as "512MiB", "1.5 GB", "10kb" or "100", rounding to the nearest byte
+//
+// units with "i" are powers of 1024 and the others powers of 1000, a bare unit letter
+// must be upper case apart from "k" so "1m" is left to Human_ParseDuration
+func Human_ParseBytes(sizeString string) (int64, error) {
+	//------------------------------------------------------------
+	numberString, unit := humanSplitNumber(strings.TrimSpace(sizeString))
+	//------------------------------------------------------------
+	value, ok := new(big.Rat).SetString(numberString)
+	if !ok || numberString == "" {
+		return 0, fmt.Errorf("%w: byte size %q", ErrHumanParse, sizeString)
+	}
+	//------------------------------------------------------------
+	base, exponent := int64(1000), 0
+	//------------------------------------------------------------
+	if unit != "" && unit != "B" {
+		//------------------------------------------------------------
+		exponent = strings.IndexByte("kmgtpe", strings.ToLower(unit[:1])[0]) + 1
+		suffix := unit[1:]
+		//------------------------------------------------------------
+		switch {
+		case exponent == 0:
+			return 0, fmt.Errorf("%w: byte size %q", ErrHumanParse, sizeString)
+		case suffix == "":
+			if !strings.Contains("kKMGTPE", unit) {
+				return 0, fmt.Errorf("%w: byte size %q", ErrHumanParse, sizeString)
+			}
+		case suffix == "B" || suffix == "b":
+		case suffix == "i" || suffix == "iB" || suffix == "ib":
+			base = 1024
+		default:
+			return 0, fmt.Errorf("%w: byte size %q", ErrHumanParse, sizeString)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	value.Mul(value, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(base), big.NewInt(int64(exponent)), nil)))
+	//------------------------------------------------------------
+	// round half away from zero
+	size := new(big.Int).Abs(value.Num())
+	size.Mul(size, big.NewInt(2)).Add(size, value.Denom())
+	size.Quo(size, new(big.Int).Mul(value.Denom(), big.NewInt(2)))
+	if value.Sign() < 0 {
+		size.Neg(size)
+	}
+	//------------------------------------------------------------
+	if !size.IsInt64() {
+		return 0, fmt.Errorf("%w: byte size %q is out of range", ErrHumanParse, sizeString)
+	}
+	//------------------------------------------------------------
+	return size.Int64(), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Human_FormatInt
+//------------------------------------------------------------
+
+// Human_FormatInt formats an integer with thousands separators, e.g. "1,234,567"
+func Human_FormatInt(value int64) string {
+	//------------------------------------------------------------
+	return humanGroupThousands(strconv.FormatInt(value, 10))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_FormatFloat
+//------------------------------------------------------------
+
+// Human_FormatFloat formats a number with thousands separators and precision decimals, -1 for the shortest exact form
+func Human_FormatFloat(value float64, precision int) string {
+	//------------------------------------------------------------
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	//------------------------------------------------------------
+	numberString := strconv.FormatFloat(value, 'f', precision, 64)
+	//------------------------------------------------------------
+	integerPart, fractionPart, hasFraction := strings.Cut(numberString, ".")
+	//------------------------------------------------------------
+	if hasFraction {
+		return humanGroupThousands(integerPart) + "." + fractionPart
+	}
+	//------------------------------------------------------------
+	return humanGroupThousands(integerPart)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_ParseNumber
+//------------------------------------------------------------
+
+// Human_ParseNumber parses numbers with or without correctly placed thousands separators, e.g. "1,234.5"
+func Human_ParseNumber(numberString string) (float64, error) {
+	//------------------------------------------------------------
+	plainString, err := humanNumberString(numberString)
+	if err != nil {
+		return 0, err
+	}
+	//------------------------------------------------------------
+	return strconv.ParseFloat(plainString, 64)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_ParseNumberRat
+//------------------------------------------------------------
+
+// Human_ParseNumberRat is Human_ParseNumber returning the exact value, e.g. for integers above 2^53
+func Human_ParseNumberRat(numberString string) (*big.Rat, error) {
+	//------------------------------------------------------------
+	plainString, err := humanNumberString(numberString)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	ratValue, ok := new(big.Rat).SetString(plainString)
+	if !ok {
+		return nil, fmt.Errorf("%w: number %q", ErrHumanParse, numberString)
+	}
+	//------------------------------------------------------------
+	return ratValue, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// humanNumberString
+//------------------------------------------------------------
+
+// humanNumberString checks the sign, digits and thousands separators and returns the number without separators
+func humanNumberString(numberString string) (string, error) {
+	//------------------------------------------------------------
+	trimmedString := strings.TrimSpace(numberString)
+	//------------------------------------------------------------
+	integerPart, fractionPart, hasFraction := strings.Cut(strings.TrimLeft(trimmedString, "+-"), ".")
+	//------------------------------------------------------------
+	groups := strings.Split(integerPart, ",")
+	//------------------------------------------------------------
+	valid := integerPart != "" && len(trimmedString)-len(strings.TrimLeft(trimmedString, "+-")) <= 1
+	//------------------------------------------------------------
+	for index, group := range groups {
+		if strings.Trim(group, "0123456789") != "" || group == "" || (len(groups) > 1 && (len(group) > 3 || index > 0 && len(group) != 3)) {
+			valid = false
+		}
+	}
+	//------------------------------------------------------------
+	if hasFraction && (fractionPart == "" || strings.Trim(fractionPart, "0123456789") != "") {
+		valid = false
+	}
+	//------------------------------------------------------------
+	if !valid {
+		return "", fmt.Errorf("%w: number %q", ErrHumanParse, numberString)
+	}
+	//------------------------------------------------------------
+	return strings.ReplaceAll(trimmedString, ",", ""), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Human_FormatDuration
+//------------------------------------------------------------
+
+// Human_FormatDuration formats a duration as whole units from days to nanoseconds, e.g. "1h30m" or "2d4h"
+func Human_FormatDuration(duration time.Duration) string {
+	//------------------------------------------------------------
+	if duration == 0 {
+		return "0s"
+	}
+	//------------------------------------------------------------
+	var outputBuilder strings.Builder
+	//------------------------------------------------------------
+	// negate as uint64 so the minimum duration does not overflow
+	remaining := uint64(duration)
+	if duration < 0 {
+		outputBuilder.WriteString("-")
+		remaining = -remaining
+	}
+	//------------------------------------------------------------
+	for _, unit := range humanDurationUnits {
+		if count := remaining / uint64(unit.duration); count > 0 {
+			outputBuilder.WriteString(strconv.FormatUint(count, 10) + unit.name)
+			remaining -= count * uint64(unit.duration)
+		}
+	}
+	//------------------------------------------------------------
+	return outputBuilder.String()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_ParseDuration
+//------------------------------------------------------------
+
+// Human_ParseDuration parses durations such as "90s", "1h30m", "1.5h", "2 days 4 hours" or "1w"
+//
+// it accepts everything time.ParseDuration does plus days, weeks, spaces and long unit names
+func Human_ParseDuration(durationString string) (time.Duration, error) {
+	//------------------------------------------------------------
+	remaining := strings.TrimSpace(durationString)
+	//------------------------------------------------------------
+	negative := strings.HasPrefix(remaining, "-")
+	remaining = strings.TrimLeft(remaining, "+-")
+	//------------------------------------------------------------
+	if remaining == "0" {
+		return 0, nil
+	}
+	if remaining == "" {
+		return 0, fmt.Errorf("%w: duration %q", ErrHumanParse, durationString)
+	}
+	//------------------------------------------------------------
+	// the negative range is one larger than the positive range
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+	//------------------------------------------------------------
+	var total uint64
+	//------------------------------------------------------------
+	for remaining != "" {
+		//------------------------------------------------------------
+		numberString, rest := humanSplitNumber(remaining)
+		//------------------------------------------------------------
+		unitLength := strings.IndexAny(rest, " 0123456789.")
+		if unitLength < 0 {
+			unitLength = len(rest)
+		}
+		//------------------------------------------------------------
+		unit, exists := humanDurationNames[strings.ToLower(rest[:unitLength])]
+		if !exists || numberString == "" || strings.ContainsAny(numberString, "+-") {
+			return 0, fmt.Errorf("%w: duration %q", ErrHumanParse, durationString)
+		}
+		//------------------------------------------------------------
+		integerString, fractionString, _ := strings.Cut(numberString, ".")
+		if numberString == "." || strings.Contains(fractionString, ".") {
+			return 0, fmt.Errorf("%w: duration %q", ErrHumanParse, durationString)
+		}
+		//------------------------------------------------------------
+		integerValue, err := strconv.ParseUint("0"+integerString, 10, 64)
+		if err != nil || integerValue > limit/uint64(unit) {
+			return 0, fmt.Errorf("%w: duration %q is out of range", ErrHumanParse, durationString)
+		}
+		//------------------------------------------------------------
+		value := integerValue * uint64(unit)
+		//------------------------------------------------------------
+		if fractionString != "" {
+			fractionValue, _ := strconv.ParseFloat("0."+fractionString, 64)
+			value += uint64(math.Round(fractionValue * float64(unit)))
+		}
+		//------------------------------------------------------------
+		total += value
+		if total > limit || total < value {
+			return 0, fmt.Errorf("%w: duration %q is out of range", ErrHumanParse, durationString)
+		}
+		//------------------------------------------------------------
+		remaining = strings.TrimLeft(rest[unitLength:], " ")
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if negative {
+		return time.Duration(-total), nil
+	}
+	//------------------------------------------------------------
+	return time.Duration(total), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Human_FormatRelativeTime
+//------------------------------------------------------------
+
+// Human_FormatRelativeTime describes timeValue relative to now, e.g. "3 minutes ago", "in 2 days" or "now"
+//
+// the count is rounded down in the largest whole unit, months are 30 days and years 365 days
+func Human_FormatRelativeTime(timeValue time.Time, now time.Time) string {
+	//------------------------------------------------------------
+	difference := now.Sub(timeValue)
+	//------------------------------------------------------------
+	future := difference < 0
+	if future {
+		difference = -difference
+	}
+	//------------------------------------------------------------
+	for _, unit := range humanRelativeUnits {
+		//------------------------------------------------------------
+		count := int64(difference / unit.duration)
+		if count == 0 {
+			continue
+		}
+		//------------------------------------------------------------
+		text := strconv.FormatInt(count, 10) + " " + unit.name
+		if count != 1 {
+			text += "s"
+		}
+		//------------------------------------------------------------
+		if future {
+			return "in " + text
+		}
+		return text + " ago"
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return "now"
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_ParseRelativeTime
+//------------------------------------------------------------
+
+// Human_ParseRelativeTime reverses Human_FormatRelativeTime, also accepting "a" / "an" for one, e.g. "an hour ago"
+func Human_ParseRelativeTime(relativeString string, now time.Time) (time.Time, error) {
+	//------------------------------------------------------------
+	text := strings.Join(strings.Fields(strings.ToLower(relativeString)), " ")
+	//------------------------------------------------------------
+	if text == "now" || text == "just now" {
+		return now, nil
+	}
+	//------------------------------------------------------------
+	sign := time.Duration(0)
+	//------------------------------------------------------------
+	if trimmedText, found := strings.CutPrefix(text, "in "); found {
+		sign, text = 1, trimmedText
+	} else if trimmedText, found := strings.CutSuffix(text, " ago"); found {
+		sign, text = -1, trimmedText
+	}
+	//------------------------------------------------------------
+	countString, unitName, _ := strings.Cut(text, " ")
+	//------------------------------------------------------------
+	count, err := strconv.ParseInt(countString, 10, 64)
+	if countString == "a" || countString == "an" {
+		count, err = 1, nil
+	}
+	//------------------------------------------------------------
+	if sign != 0 && err == nil && count >= 0 {
+		for _, unit := range humanRelativeUnits {
+			if unitName == unit.name || unitName == unit.name+"s" {
+				if count > math.MaxInt64/int64(unit.duration) {
+					break
+				}
+				return now.Add(sign * time.Duration(count) * unit.duration), nil
+			}
+		}
+	}
+	//------------------------------------------------------------
+	return time.Time{}, fmt.Errorf("%w: relative time %q", ErrHumanParse, relativeString)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Human_FormatOrdinal
+//------------------------------------------------------------
+
+// Human_FormatOrdinal formats 1 as "1st", 2 as "2nd", 11 as "11th" and so on
+func Human_FormatOrdinal(value int) string {
+	//------------------------------------------------------------
+	return strconv.Itoa(value) + humanOrdinalSuffix(value)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_ParseOrdinal
+//------------------------------------------------------------
+
+// Human_ParseOrdinal reverses Human_FormatOrdinal and rejects mismatched suffixes such as "11st"
+func Human_ParseOrdinal(ordinalString string) (int, error) {
+	//------------------------------------------------------------
+	text := strings.ToLower(strings.TrimSpace(ordinalString))
+	//------------------------------------------------------------
+	if len(text) > 2 {
+		if value, err := strconv.Atoi(text[:len(text)-2]); err == nil && humanOrdinalSuffix(value) == text[len(text)-2:] {
+			return value, nil
+		}
+	}
+	//------------------------------------------------------------
+	return 0, fmt.Errorf("%w: ordinal %q", ErrHumanParse, ordinalString)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// humanOrdinalSuffix
+//------------------------------------------------------------
+
+func humanOrdinalSuffix(value int) string {
+	//------------------------------------------------------------
+	lastTwo := value % 100
+	if lastTwo < 0 {
+		lastTwo = -lastTwo
+	}
+	//------------------------------------------------------------
+	if lastTwo >= 11 && lastTwo <= 13 {
+		return "th"
+	}
+	//------------------------------------------------------------
+	switch lastTwo % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	default:
+		return "th"
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// humanSplitNumber
+//------------------------------------------------------------
+
+// humanSplitNumber splits a leading decimal number from the unit that follows it, skipping spaces between them
+func humanSplitNumber(text string) (string, string) {
+	//------------------------------------------------------------
+	index := 0
+	if index < len(text) && (text[index] == '-' || text[index] == '+') {
+		index++
+	}
+	for index < len(text) && (text[index] >= '0' && text[index] <= '9' || text[index] == '.') {
+		index++
+	}
+	//------------------------------------------------------------
+	return text[:index], strings.TrimLeft(text[index:], " ")
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// humanGroupThousands
+//------------------------------------------------------------
+
+func humanGroupThousands(integerString string) string {
+	//------------------------------------------------------------
+	sign := ""
+	if strings.HasPrefix(integerString, "-") {
+		sign, integerString = "-", integerString[1:]
+	}
+	//------------------------------------------------------------
+	var outputBuilder strings.Builder
+	//------------------------------------------------------------
+	for index, char := range integerString {
+		if index > 0 && (len(integerString)-index)%3 == 0 {
+			outputBuilder.WriteByte(',')
+		}
+		outputBuilder.WriteRune(char)
+	}
+	//------------------------------------------------------------
+	return sign + outputBuilder.String()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// humanTrimZeros
+//------------------------------------------------------------
+
+func humanTrimZeros(numberString string) string {
+	//------------------------------------------------------------
+	if strings.Contains(numberString, ".") {
+		numberString = strings.TrimRight(strings.TrimRight(numberString, "0"), ".")
+	}
+	//------------------------------------------------------------
+	return numberString
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
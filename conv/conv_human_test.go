@@ -0,0 +1,369 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Human_FormatBytes
+//------------------------------------------------------------
+
+func TestHuman_FormatBytes(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		size      int64
+		iec       bool
+		precision int
+		expected  string
+	}{
+		{0, false, 1, "0 B"},
+		{999, false, 1, "999 B"},
+		{1000, false, 1, "1 kB"},
+		{1500, false, 1, "1.5 kB"},
+		{1_500_000_000, false, 1, "1.5 GB"},
+		{999_960, false, 1, "1 MB"},
+		{1234567, false, -1, "1.234567 MB"},
+		{1024, true, 1, "1 KiB"},
+		{512 << 20, true, 1, "512 MiB"},
+		{1536, true, 2, "1.5 KiB"},
+		{1000, true, -1, "1000 B"},
+		{1025, true, -1, "1.0009765625 KiB"},
+		{-1536, true, 1, "-1.5 KiB"},
+		{math.MaxInt64, true, 1, "8 EiB"},
+		{math.MinInt64, true, 1, "-8 EiB"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		result := Human_FormatBytes(testCase.size, testCase.iec, testCase.precision)
+		//--------------------------------------------------
+		if result != testCase.expected {
+			t.Errorf("(%d, %v, %d) = %q but should = %q", testCase.size, testCase.iec, testCase.precision, result, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_ParseBytes
+//------------------------------------------------------------
+
+func TestHuman_ParseBytes(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		sizeString string
+		expected   int64
+	}{
+		{"100", 100},
+		{"100B", 100},
+		{"512MiB", 512 << 20},
+		{"512 MiB", 512 << 20},
+		{"512Mi", 512 << 20},
+		{"1.5GB", 1_500_000_000},
+		{"1.5 gb", 1_500_000_000},
+		{"10k", 10_000},
+		{"10K", 10_000},
+		{"10Ki", 10_240},
+		{"2T", 2_000_000_000_000},
+		{" -1.5 KiB ", -1536},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		result, err := Human_ParseBytes(testCase.sizeString)
+		//--------------------------------------------------
+		if err != nil {
+			t.Errorf("(%q) returned error = %v", testCase.sizeString, err)
+		} else if result != testCase.expected {
+			t.Errorf("(%q) = %d but should = %d", testCase.sizeString, result, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	for _, invalidString := range []string{"", "MB", "1m", "1g", "1 XB", "1MiBs", "1.2.3MB", "9EiB", "abc"} {
+		if _, err := Human_ParseBytes(invalidString); !errors.Is(err, ErrHumanParse) {
+			t.Errorf("(%q) error = %v but should = %v", invalidString, err, ErrHumanParse)
+		}
+	}
+	//--------------------------------------------------
+	random := rand.New(rand.NewSource(1))
+	//--------------------------------------------------
+	for count := 0; count < 1000; count++ {
+		//--------------------------------------------------
+		size := random.Int63() - random.Int63()
+		//--------------------------------------------------
+		for _, iec := range []bool{false, true} {
+			if result, err := Human_ParseBytes(Human_FormatBytes(size, iec, -1)); err != nil || result != size {
+				t.Fatalf("(%d, %v) round trip = %d, %v", size, iec, result, err)
+			}
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_FormatFloat
+//------------------------------------------------------------
+
+func TestHuman_FormatFloat(t *testing.T) {
+	//--------------------------------------------------
+	intCases := map[int64]string{0: "0", 999: "999", 1000: "1,000", -1234567: "-1,234,567", math.MinInt64: "-9,223,372,036,854,775,808"}
+	//--------------------------------------------------
+	for value, expected := range intCases {
+		if result := Human_FormatInt(value); result != expected {
+			t.Errorf("Human_FormatInt(%d) = %q but should = %q", value, result, expected)
+		}
+	}
+	//--------------------------------------------------
+	floatCases := []struct {
+		value     float64
+		precision int
+		expected  string
+	}{
+		{1234.5678, 2, "1,234.57"},
+		{1234567.5, -1, "1,234,567.5"},
+		{-999.5, 0, "-1,000"},
+		{0.25, -1, "0.25"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range floatCases {
+		if result := Human_FormatFloat(testCase.value, testCase.precision); result != testCase.expected {
+			t.Errorf("Human_FormatFloat(%v, %d) = %q but should = %q", testCase.value, testCase.precision, result, testCase.expected)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_ParseNumber
+//------------------------------------------------------------
+
+func TestHuman_ParseNumber(t *testing.T) {
+	//--------------------------------------------------
+	testCases := map[string]float64{"1,234.5": 1234.5, "1234.5": 1234.5, "-1,000": -1000, "+12": 12, "999": 999, "1,234,567": 1234567}
+	//--------------------------------------------------
+	for numberString, expected := range testCases {
+		if result, err := Human_ParseNumber(numberString); err != nil || result != expected {
+			t.Errorf("(%q) = %v, %v but should = %v", numberString, result, err, expected)
+		}
+	}
+	//--------------------------------------------------
+	for _, invalidString := range []string{"", "1,23", "12,345,67", "1234,567", ",123", "1.", "1.2.3", "--1", "1e3", "abc"} {
+		if _, err := Human_ParseNumber(invalidString); !errors.Is(err, ErrHumanParse) {
+			t.Errorf("(%q) error = %v but should = %v", invalidString, err, ErrHumanParse)
+		}
+	}
+	//--------------------------------------------------
+	for _, value := range []float64{0, 1, -1234567.125, 1e15} {
+		if result, err := Human_ParseNumber(Human_FormatFloat(value, -1)); err != nil || result != value {
+			t.Errorf("(%v) round trip = %v, %v", value, result, err)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_ParseNumberRat
+//------------------------------------------------------------
+
+func TestHuman_ParseNumberRat(t *testing.T) {
+	//--------------------------------------------------
+	testCases := map[string]string{"1,234.5": "2469/2", "-1,000": "-1000/1", "+12": "12/1", "9,007,199,254,740,993": "9007199254740993/1", "0.1": "1/10"}
+	//--------------------------------------------------
+	for numberString, expected := range testCases {
+		if result, err := Human_ParseNumberRat(numberString); err != nil || result.String() != expected {
+			t.Errorf("(%q) = %v, %v but should = %v", numberString, result, err, expected)
+		}
+	}
+	//--------------------------------------------------
+	for _, invalidString := range []string{"", "1,23", "1.", "--1", "1e3", "1/2", "abc"} {
+		if _, err := Human_ParseNumberRat(invalidString); !errors.Is(err, ErrHumanParse) {
+			t.Errorf("(%q) error = %v but should = %v", invalidString, err, ErrHumanParse)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_FormatDuration
+//------------------------------------------------------------
+
+func TestHuman_FormatDuration(t *testing.T) {
+	//--------------------------------------------------
+	testCases := map[time.Duration]string{
+		0:                              "0s",
+		90 * time.Second:               "1m30s",
+		90 * time.Minute:               "1h30m",
+		50 * time.Hour:                 "2d2h",
+		1500 * time.Millisecond:        "1s500ms",
+		-time.Second - time.Nanosecond: "-1s1ns",
+	}
+	//--------------------------------------------------
+	for duration, expected := range testCases {
+		if result := Human_FormatDuration(duration); result != expected {
+			t.Errorf("(%v) = %q but should = %q", duration, result, expected)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_ParseDuration
+//------------------------------------------------------------
+
+func TestHuman_ParseDuration(t *testing.T) {
+	//--------------------------------------------------
+	testCases := map[string]time.Duration{
+		"0":                         0,
+		"90s":                       90 * time.Second,
+		"1h30m":                     90 * time.Minute,
+		"1.5h":                      90 * time.Minute,
+		"2 days 4 hours":            52 * time.Hour,
+		"1w":                        7 * 24 * time.Hour,
+		"-1m30s":                    -90 * time.Second,
+		"300ms":                     300 * time.Millisecond,
+		"1µs":                       time.Microsecond,
+		".5s":                       500 * time.Millisecond,
+		"2562047h47m16.854775807s":  math.MaxInt64,
+		"-2562047h47m16.854775808s": math.MinInt64,
+	}
+	//--------------------------------------------------
+	for durationString, expected := range testCases {
+		if result, err := Human_ParseDuration(durationString); err != nil || result != expected {
+			t.Errorf("(%q) = %v, %v but should = %v", durationString, result, err, expected)
+		}
+	}
+	//--------------------------------------------------
+	for _, invalidString := range []string{"", "1", "h", "1x", "1h-30m", "1..5s", "2562047h47m16.854775808s", "106752d1h"} {
+		if _, err := Human_ParseDuration(invalidString); !errors.Is(err, ErrHumanParse) {
+			t.Errorf("(%q) error = %v but should = %v", invalidString, err, ErrHumanParse)
+		}
+	}
+	//--------------------------------------------------
+	random := rand.New(rand.NewSource(1))
+	//--------------------------------------------------
+	for count := 0; count < 1000; count++ {
+		//--------------------------------------------------
+		duration := time.Duration(random.Int63() - random.Int63())
+		//--------------------------------------------------
+		if result, err := Human_ParseDuration(Human_FormatDuration(duration)); err != nil || result != duration {
+			t.Fatalf("(%d) round trip = %d, %v", duration, result, err)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_FormatRelativeTime
+//------------------------------------------------------------
+
+func TestHuman_FormatRelativeTime(t *testing.T) {
+	//--------------------------------------------------
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	//--------------------------------------------------
+	testCases := map[time.Duration]string{
+		0:                       "now",
+		-500 * time.Millisecond: "now",
+		-time.Second:            "1 second ago",
+		-3 * time.Minute:        "3 minutes ago",
+		-119 * time.Minute:      "1 hour ago",
+		2 * 24 * time.Hour:      "in 2 days",
+		-8 * 24 * time.Hour:     "1 week ago",
+		-45 * 24 * time.Hour:    "1 month ago",
+		800 * 24 * time.Hour:    "in 2 years",
+	}
+	//--------------------------------------------------
+	for offset, expected := range testCases {
+		//--------------------------------------------------
+		result := Human_FormatRelativeTime(now.Add(offset), now)
+		//--------------------------------------------------
+		if result != expected {
+			t.Errorf("(%v) = %q but should = %q", offset, result, expected)
+		}
+		//--------------------------------------------------
+		parsedTime, err := Human_ParseRelativeTime(result, now)
+		if err != nil {
+			t.Errorf("(%q) returned error = %v", result, err)
+		} else if Human_FormatRelativeTime(parsedTime, now) != result {
+			t.Errorf("(%q) parsed time %v does not format back", result, parsedTime)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_ParseRelativeTime
+//------------------------------------------------------------
+
+func TestHuman_ParseRelativeTime(t *testing.T) {
+	//--------------------------------------------------
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	//--------------------------------------------------
+	testCases := map[string]time.Time{
+		"just now":       now,
+		"an hour ago":    now.Add(-time.Hour),
+		"In  3  Minutes": now.Add(3 * time.Minute),
+		"1 day ago":      now.Add(-24 * time.Hour),
+		"a week ago":     now.Add(-7 * 24 * time.Hour),
+		"in 10 seconds":  now.Add(10 * time.Second),
+	}
+	//--------------------------------------------------
+	for relativeString, expected := range testCases {
+		if result, err := Human_ParseRelativeTime(relativeString, now); err != nil || !result.Equal(expected) {
+			t.Errorf("(%q) = %v, %v but should = %v", relativeString, result, err, expected)
+		}
+	}
+	//--------------------------------------------------
+	for _, invalidString := range []string{"", "3 minutes", "in minutes", "in -3 minutes", "3 fortnights ago", "in 3 minutes ago", "in 999999999999 years"} {
+		if _, err := Human_ParseRelativeTime(invalidString, now); !errors.Is(err, ErrHumanParse) {
+			t.Errorf("(%q) error = %v but should = %v", invalidString, err, ErrHumanParse)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Human_FormatOrdinal
+//------------------------------------------------------------
+
+func TestHuman_FormatOrdinal(t *testing.T) {
+	//--------------------------------------------------
+	testCases := map[int]string{0: "0th", 1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 102: "102nd", 111: "111th", -1: "-1st"}
+	//--------------------------------------------------
+	for value, expected := range testCases {
+		//--------------------------------------------------
+		if result := Human_FormatOrdinal(value); result != expected {
+			t.Errorf("(%d) = %q but should = %q", value, result, expected)
+		}
+		//--------------------------------------------------
+		if result, err := Human_ParseOrdinal(expected); err != nil || result != value {
+			t.Errorf("(%q) = %d, %v but should = %d", expected, result, err, value)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	for _, invalidString := range []string{"", "st", "1", "11st", "2th", "1 st", "first"} {
+		if _, err := Human_ParseOrdinal(invalidString); !errors.Is(err, ErrHumanParse) {
+			t.Errorf("(%q) error = %v but should = %v", invalidString, err, ErrHumanParse)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
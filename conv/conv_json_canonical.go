@@ -0,0 +1,245 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+const hexLowerCharset = "0123456789abcdef"
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// JSON_Canonical
+//------------------------------------------------------------
+
+// JSON_Canonical json encodes input using the RFC 8785 JSON Canonicalization Scheme (JCS)
+//
+// object keys are sorted by UTF-16 code units, numbers use ES6 serialisation and
+// strings are only escaped where required so the output can be signed or hashed
+//
+// input is first marshalled as normal so struct tags and json.Marshaler are honoured
+func JSON_Canonical(input interface{}) ([]byte, error) {
+	//------------------------------------------------------------
+	jsonBytes, err := JSON_Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	//------------------------------------------------------------
+	var jsonInterface interface{}
+	//------------------------------------------------------------
+	err = decoder.Decode(&jsonInterface)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return jsonCanonicalAppend(nil, jsonInterface)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSON_encodeCanonical
+//------------------------------------------------------------
+
+// JSON_encodeCanonical returns the JSON_Canonical encoding of jsonInterface as a string
+func JSON_encodeCanonical(jsonInterface interface{}) (string, error) {
+	//------------------------------------------------------------
+	jsonBytes, err := JSON_Canonical(jsonInterface)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return string(jsonBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonCanonicalAppend
+//------------------------------------------------------------
+
+func jsonCanonicalAppend(dst []byte, value interface{}) ([]byte, error) {
+	//------------------------------------------------------------
+	var err error
+	//------------------------------------------------------------
+	switch typedValue := value.(type) {
+	case nil:
+		return append(dst, "null"...), nil
+	case bool:
+		return strconv.AppendBool(dst, typedValue), nil
+	case json.Number:
+		floatValue, err := strconv.ParseFloat(string(typedValue), 64)
+		if err != nil {
+			return nil, err
+		}
+		return JSON_AppendCanonicalNumber(dst, floatValue)
+	case float64:
+		return JSON_AppendCanonicalNumber(dst, typedValue)
+	case string:
+		return jsonCanonicalAppendString(dst, typedValue)
+	case []interface{}:
+		//------------------------------------------------------------
+		dst = append(dst, '[')
+		//------------------------------------------------------------
+		for index, element := range typedValue {
+			if index > 0 {
+				dst = append(dst, ',')
+			}
+			dst, err = jsonCanonicalAppend(dst, element)
+			if err != nil {
+				return nil, err
+			}
+		}
+		//------------------------------------------------------------
+		return append(dst, ']'), nil
+		//------------------------------------------------------------
+	case map[string]interface{}:
+		//------------------------------------------------------------
+		keys := make([]string, 0, len(typedValue))
+		for key := range typedValue {
+			keys = append(keys, key)
+		}
+		//------------------------------------------------------------
+		slices.SortFunc(keys, func(key1 string, key2 string) int {
+			return slices.Compare(utf16.Encode([]rune(key1)), utf16.Encode([]rune(key2)))
+		})
+		//------------------------------------------------------------
+		dst = append(dst, '{')
+		//------------------------------------------------------------
+		for index, key := range keys {
+			//------------------------------------------------------------
+			if index > 0 {
+				dst = append(dst, ',')
+			}
+			//------------------------------------------------------------
+			dst, err = jsonCanonicalAppendString(dst, key)
+			if err != nil {
+				return nil, err
+			}
+			//------------------------------------------------------------
+			dst = append(dst, ':')
+			//------------------------------------------------------------
+			dst, err = jsonCanonicalAppend(dst, typedValue[key])
+			if err != nil {
+				return nil, err
+			}
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+		return append(dst, '}'), nil
+		//------------------------------------------------------------
+	default:
+		return nil, fmt.Errorf("unsupported canonical json type %T", value)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSON_AppendCanonicalNumber
+//------------------------------------------------------------
+
+// JSON_AppendCanonicalNumber appends value using the ECMAScript Number.prototype.toString format required by RFC 8785
+//
+// NaN and Infinity cannot be represented and return an error
+func JSON_AppendCanonicalNumber(dst []byte, value float64) ([]byte, error) {
+	//------------------------------------------------------------
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, errors.New("canonical json does not support NaN or Infinity")
+	}
+	//------------------------------------------------------------
+	// also converts -0 to 0
+	if value == 0 {
+		return append(dst, '0'), nil
+	}
+	//------------------------------------------------------------
+	absValue := math.Abs(value)
+	//------------------------------------------------------------
+	if absValue >= 1e-6 && absValue < 1e21 {
+		return strconv.AppendFloat(dst, value, 'f', -1, 64), nil
+	}
+	//------------------------------------------------------------
+	startIndex := len(dst)
+	dst = strconv.AppendFloat(dst, value, 'e', -1, 64)
+	//------------------------------------------------------------
+	// ES6 exponents have no leading zero, e.g. "1e-07" becomes "1e-7"
+	length := len(dst)
+	if length-startIndex >= 4 && dst[length-4] == 'e' && dst[length-2] == '0' {
+		dst = append(dst[:length-2], dst[length-1])
+	}
+	//------------------------------------------------------------
+	return dst, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonCanonicalAppendString
+//------------------------------------------------------------
+
+func jsonCanonicalAppendString(dst []byte, value string) ([]byte, error) {
+	//------------------------------------------------------------
+	if !utf8.ValidString(value) {
+		return nil, errors.New("canonical json strings must be valid utf-8")
+	}
+	//------------------------------------------------------------
+	dst = append(dst, '"')
+	//------------------------------------------------------------
+	for index := 0; index < len(value); index++ {
+		//------------------------------------------------------------
+		char := value[index]
+		//------------------------------------------------------------
+		switch char {
+		case '"':
+			dst = append(dst, '\\', '"')
+		case '\\':
+			dst = append(dst, '\\', '\\')
+		case '\b':
+			dst = append(dst, '\\', 'b')
+		case '\t':
+			dst = append(dst, '\\', 't')
+		case '\n':
+			dst = append(dst, '\\', 'n')
+		case '\f':
+			dst = append(dst, '\\', 'f')
+		case '\r':
+			dst = append(dst, '\\', 'r')
+		default:
+			if char < 0x20 {
+				dst = append(dst, '\\', 'u', '0', '0', hexLowerCharset[char>>4], hexLowerCharset[char&0x0F])
+			} else {
+				dst = append(dst, char)
+			}
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return append(dst, '"'), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
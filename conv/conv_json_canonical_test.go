@@ -0,0 +1,139 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"math"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// JSON_Canonical
+//------------------------------------------------------------
+
+func TestJSON_Canonical(t *testing.T) {
+	//--------------------------------------------------
+	// RFC 8785 section 3.2.2 and 3.2.3 examples
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`{"numbers":[333333333.33333329,1E30,4.50,2e-3,0.000000000000000000000000001],"string":"\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/","literals":[null,true,false]}`,
+			`{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			`{"\u20ac":"Euro Sign","\r":"Carriage Return","\ufb33":"Hebrew Letter Dalet With Dagesh","1":"One","\ud83d\ude00":"Emoji: Grinning Face","\u0080":"Control","\u00f6":"Latin Small Letter O With Diaeresis"}`,
+			"{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\",\"\u20ac\":\"Euro Sign\",\"\U0001F600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		{`[]`, `[]`},
+		{`{}`, `{}`},
+		{`"<a & b>\u2028"`, "\"<a & b>\u2028\""},
+		{`{"b":[1,{"d":2,"c":3}],"a":-0}`, `{"a":0,"b":[1,{"c":3,"d":2}]}`},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		jsonInterface, err := JSON_decode(testCase.input)
+		if err != nil {
+			t.Fatalf("(%s) JSON_decode returned error = %v", testCase.input, err)
+		}
+		//--------------------------------------------------
+		result, err := JSON_encodeCanonical(jsonInterface)
+		//--------------------------------------------------
+		if err != nil {
+			t.Errorf("(%s) returned error = %v", testCase.input, err)
+		} else if result != testCase.expected {
+			t.Errorf("(%s) = %s but should = %s", testCase.input, result, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func TestJSON_CanonicalStruct(t *testing.T) {
+	//--------------------------------------------------
+	input := struct {
+		Zebra string  `json:"zebra"`
+		Alpha float64 `json:"alpha"`
+		Skip  string  `json:"-"`
+	}{"z", 1e21, "skip"}
+	//--------------------------------------------------
+	result, err := JSON_Canonical(input)
+	//--------------------------------------------------
+	expected := `{"alpha":1e+21,"zebra":"z"}`
+	//--------------------------------------------------
+	if err != nil || string(result) != expected {
+		t.Errorf("result = %s, error = %v but should = %s", result, err, expected)
+	}
+	//--------------------------------------------------
+	if _, err = JSON_Canonical(math.NaN()); err == nil {
+		t.Errorf("NaN should return an error")
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSON_AppendCanonicalNumber
+//------------------------------------------------------------
+
+func TestJSON_AppendCanonicalNumber(t *testing.T) {
+	//--------------------------------------------------
+	// RFC 8785 appendix B examples
+	testCases := []struct {
+		bits     uint64
+		expected string
+	}{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0xffefffffffffffff, "-1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x44b52d02c7e14af7, "1.0000000000000001e+23"},
+		{0x444b1ae4d6e2ef4e, "999999999999999700000"},
+		{0x444b1ae4d6e2ef4f, "999999999999999900000"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x41b3de4355555553, "333333333.3333332"},
+		{0x41b3de4355555554, "333333333.33333325"},
+		{0x41b3de4355555555, "333333333.3333333"},
+		{0x41b3de4355555556, "333333333.3333334"},
+		{0x41b3de4355555557, "333333333.33333343"},
+		{0xbecbf647612f3696, "-0.0000033333333333333333"},
+		{0x43143ff3c1cb0959, "1424953923781206.2"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		result, err := JSON_AppendCanonicalNumber(nil, math.Float64frombits(testCase.bits))
+		//--------------------------------------------------
+		if err != nil || string(result) != testCase.expected {
+			t.Errorf("(%016x) = %s, error = %v but should = %s", testCase.bits, result, err, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	for _, bits := range []uint64{0x7fffffffffffffff, 0x7ff0000000000000} {
+		if _, err := JSON_AppendCanonicalNumber(nil, math.Float64frombits(bits)); err == nil {
+			t.Errorf("(%016x) should return an error", bits)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,391 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+var ErrJSONUnknownField = errors.New("unknown field")
+
+var ErrJSONDuplicateKey = errors.New("duplicate key")
+
+var ErrJSONTrailingData = errors.New("trailing data after json value")
+
+//------------------------------------------------------------
+
+// JSONDecodeError annotates a decoding error with the position of the bad input
+//
+// Line and Column start at 1 and Column counts bytes
+type JSONDecodeError struct {
+	Line   int
+	Column int
+	Offset int64
+	Err    error
+}
+
+func (e *JSONDecodeError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *JSONDecodeError) Unwrap() error { return e.Err }
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type JSONNumberMode int
+
+const (
+	// JSONNumberFloat64 decodes numbers as float64 in the same way as JSON_decode
+	JSONNumberFloat64 JSONNumberMode = iota
+	// JSONNumberString keeps numbers as json.Number so no precision is lost
+	JSONNumberString
+	// JSONNumberInt64 decodes integers that fit as int64 and any other number as float64
+	JSONNumberInt64
+)
+
+//------------------------------------------------------------
+
+type JSONDecodeOption func(*JSONDecodeOptions)
+
+type JSONDecodeOptions struct {
+	NumberMode JSONNumberMode
+	// Strict rejects unknown struct fields and duplicate object keys, trailing data is always rejected
+	Strict bool
+}
+
+var DefaultJSONDecodeOptions = JSONDecodeOptions{
+	NumberMode: JSONNumberFloat64,
+	Strict:     false,
+}
+
+//------------------------------------------------------------
+
+func WithJSONNumberMode(numberMode JSONNumberMode) JSONDecodeOption {
+	return func(options *JSONDecodeOptions) { options.NumberMode = numberMode }
+}
+
+func WithJSONStrict(strict bool) JSONDecodeOption {
+	return func(options *JSONDecodeOptions) { options.Strict = strict }
+}
+
+//------------------------------------------------------------
+
+func NewJSONDecodeOptions(options ...JSONDecodeOption) JSONDecodeOptions {
+	decodeOptions := DefaultJSONDecodeOptions
+	for _, optionFunc := range options {
+		optionFunc(&decodeOptions)
+	}
+	return decodeOptions
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// JSON_DecodeInto
+//------------------------------------------------------------
+
+// JSON_DecodeInto json decodes jsonString into a value of type T
+//
+// errors for bad input are returned as *JSONDecodeError with the line and column
+//
+// e.g. JSON_DecodeInto[map[string]any](jsonString, WithJSONNumberMode(JSONNumberInt64), WithJSONStrict(true))
+func JSON_DecodeInto[T any](jsonString string, options ...JSONDecodeOption) (T, error) {
+	//------------------------------------------------------------
+	var result T
+	//------------------------------------------------------------
+	decodeOptions := NewJSONDecodeOptions(options...)
+	//------------------------------------------------------------
+	jsonBytes := []byte(jsonString)
+	//------------------------------------------------------------
+	if decodeOptions.Strict {
+		if err := jsonCheckDuplicateKeys(jsonBytes); err != nil {
+			return result, err
+		}
+	}
+	//------------------------------------------------------------
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	//------------------------------------------------------------
+	if decodeOptions.NumberMode != JSONNumberFloat64 {
+		decoder.UseNumber()
+	}
+	//------------------------------------------------------------
+	if decodeOptions.Strict {
+		decoder.DisallowUnknownFields()
+	}
+	//------------------------------------------------------------
+	err := decoder.Decode(&result)
+	if err != nil {
+		var zero T
+		return zero, jsonDecodeError(jsonBytes, decoder, err)
+	}
+	//------------------------------------------------------------
+	// only white space may follow the value, as with json.Unmarshal
+	offset := decoder.InputOffset()
+	//------------------------------------------------------------
+	if err = decoder.Decode(&json.RawMessage{}); err != io.EOF {
+		//------------------------------------------------------------
+		// skip white space so the error points at the trailing data
+		for offset < int64(len(jsonBytes)) && strings.IndexByte(" \t\r\n", jsonBytes[offset]) >= 0 {
+			offset++
+		}
+		//------------------------------------------------------------
+		var zero T
+		return zero, jsonPositionError(jsonBytes, offset, ErrJSONTrailingData)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if decodeOptions.NumberMode == JSONNumberInt64 {
+		jsonConvertNumbers(reflect.ValueOf(&result).Elem())
+	}
+	//------------------------------------------------------------
+	return result, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonDecodeError
+//------------------------------------------------------------
+
+func jsonDecodeError(jsonBytes []byte, decoder *json.Decoder, err error) error {
+	//------------------------------------------------------------
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	//------------------------------------------------------------
+	switch {
+	case errors.As(err, &syntaxError):
+		// Offset is the number of bytes read including the bad byte
+		return jsonPositionError(jsonBytes, syntaxError.Offset-1, err)
+	case errors.As(err, &typeError):
+		return jsonPositionError(jsonBytes, typeError.Offset-1, err)
+	case errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		return jsonPositionError(jsonBytes, int64(len(jsonBytes)), io.ErrUnexpectedEOF)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		fieldName := strings.TrimPrefix(err.Error(), "json: unknown field ")
+		// the decoder has consumed the whole value so point at the first object key with the field name
+		offset := decoder.InputOffset()
+		if key, unquoteErr := strconv.Unquote(fieldName); unquoteErr == nil {
+			_ = jsonWalkKeys(jsonBytes, func(keyName string, keyOffset int64, duplicate bool) error {
+				if keyName != key {
+					return nil
+				}
+				offset = keyOffset
+				return io.EOF
+			})
+		}
+		return jsonPositionError(jsonBytes, offset, fmt.Errorf("%w %s", ErrJSONUnknownField, fieldName))
+	default:
+		return jsonPositionError(jsonBytes, decoder.InputOffset(), err)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonPositionError
+//------------------------------------------------------------
+
+func jsonPositionError(jsonBytes []byte, offset int64, err error) error {
+	//------------------------------------------------------------
+	offset = max(0, min(offset, int64(len(jsonBytes))))
+	//------------------------------------------------------------
+	lineStart := bytes.LastIndexByte(jsonBytes[:offset], '\n') + 1
+	//------------------------------------------------------------
+	return &JSONDecodeError{
+		Line:   bytes.Count(jsonBytes[:offset], []byte{'\n'}) + 1,
+		Column: int(offset) - lineStart + 1,
+		Offset: offset,
+		Err:    err,
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonCheckDuplicateKeys
+//------------------------------------------------------------
+
+// jsonCheckDuplicateKeys walks the tokens in jsonBytes because encoding/json keeps the last duplicate key silently
+func jsonCheckDuplicateKeys(jsonBytes []byte) error {
+	//------------------------------------------------------------
+	return jsonWalkKeys(jsonBytes, func(key string, offset int64, duplicate bool) error {
+		if duplicate {
+			return jsonPositionError(jsonBytes, offset, fmt.Errorf("%w %q", ErrJSONDuplicateKey, key))
+		}
+		return nil
+	})
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonWalkKeys
+//------------------------------------------------------------
+
+// jsonWalkKeys calls keyFunc for each object key in document order with the offset of its opening quote
+// and whether the key has already been used in the same object, the walk stops at the first error from keyFunc
+//
+// syntax errors end the walk without an error as the main decode reports them with their position
+func jsonWalkKeys(jsonBytes []byte, keyFunc func(key string, offset int64, duplicate bool) error) error {
+	//------------------------------------------------------------
+	type jsonFrame struct {
+		keys      map[string]bool
+		expectKey bool
+	}
+	//------------------------------------------------------------
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	//------------------------------------------------------------
+	var frames []*jsonFrame
+	//------------------------------------------------------------
+	for {
+		//------------------------------------------------------------
+		offset := decoder.InputOffset()
+		//------------------------------------------------------------
+		token, err := decoder.Token()
+		if err != nil {
+			return nil
+		}
+		//------------------------------------------------------------
+		var frame *jsonFrame
+		if len(frames) > 0 {
+			frame = frames[len(frames)-1]
+		}
+		//------------------------------------------------------------
+		switch token {
+		case json.Delim('{'):
+			frames = append(frames, &jsonFrame{keys: map[string]bool{}, expectKey: true})
+			continue
+		case json.Delim('['):
+			frames = append(frames, &jsonFrame{})
+			continue
+		case json.Delim('}'), json.Delim(']'):
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 && frames[len(frames)-1].keys != nil {
+				frames[len(frames)-1].expectKey = true
+			}
+			continue
+		}
+		//------------------------------------------------------------
+		if frame == nil || frame.keys == nil {
+			continue
+		}
+		//------------------------------------------------------------
+		if !frame.expectKey {
+			frame.expectKey = true
+			continue
+		}
+		//------------------------------------------------------------
+		key := token.(string)
+		//------------------------------------------------------------
+		// offset is the end of the previous token so skip the separator and white space
+		for offset < int64(len(jsonBytes)) && jsonBytes[offset] != '"' {
+			offset++
+		}
+		//------------------------------------------------------------
+		if err := keyFunc(key, offset, frame.keys[key]); err != nil {
+			return err
+		}
+		//------------------------------------------------------------
+		frame.keys[key] = true
+		frame.expectKey = false
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonConvertNumbers
+//------------------------------------------------------------
+
+// jsonConvertNumbers replaces json.Number values held in interfaces with int64 where exact or float64 otherwise
+func jsonConvertNumbers(value reflect.Value) {
+	//------------------------------------------------------------
+	switch value.Kind() {
+	case reflect.Interface:
+		//------------------------------------------------------------
+		if value.IsNil() {
+			return
+		}
+		//------------------------------------------------------------
+		if number, ok := value.Interface().(json.Number); ok {
+			if value.CanSet() {
+				value.Set(reflect.ValueOf(jsonNumberValue(number)))
+			}
+			return
+		}
+		//------------------------------------------------------------
+		jsonConvertNumbers(value.Elem())
+		//------------------------------------------------------------
+	case reflect.Pointer:
+		if !value.IsNil() {
+			jsonConvertNumbers(value.Elem())
+		}
+	case reflect.Struct:
+		for index := 0; index < value.NumField(); index++ {
+			if value.Type().Field(index).IsExported() {
+				jsonConvertNumbers(value.Field(index))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for index := 0; index < value.Len(); index++ {
+			jsonConvertNumbers(value.Index(index))
+		}
+	case reflect.Map:
+		//------------------------------------------------------------
+		iterator := value.MapRange()
+		//------------------------------------------------------------
+		for iterator.Next() {
+			//------------------------------------------------------------
+			mapValue := reflect.New(value.Type().Elem()).Elem()
+			mapValue.Set(iterator.Value())
+			//------------------------------------------------------------
+			jsonConvertNumbers(mapValue)
+			//------------------------------------------------------------
+			value.SetMapIndex(iterator.Key(), mapValue)
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonNumberValue
+//------------------------------------------------------------
+
+func jsonNumberValue(number json.Number) any {
+	//------------------------------------------------------------
+	if intValue, err := number.Int64(); err == nil {
+		return intValue
+	}
+	//------------------------------------------------------------
+	if floatValue, err := number.Float64(); err == nil {
+		return floatValue
+	}
+	//------------------------------------------------------------
+	return number
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
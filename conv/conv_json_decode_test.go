@@ -0,0 +1,153 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// JSON_DecodeInto
+//------------------------------------------------------------
+
+func TestJSON_DecodeInto(t *testing.T) {
+	//--------------------------------------------------
+	type testStruct struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+		Data any    `json:"data"`
+	}
+	//--------------------------------------------------
+	result, err := JSON_DecodeInto[testStruct](`{"id":9007199254740993,"name":"test","data":[1,2.5]}`)
+	//--------------------------------------------------
+	if err != nil {
+		t.Fatalf("returned error = %v", err)
+	}
+	//--------------------------------------------------
+	if result.ID != 9007199254740993 || result.Name != "test" {
+		t.Errorf("result = %+v", result)
+	}
+	//--------------------------------------------------
+	if data, ok := result.Data.([]any); !ok || data[0] != float64(1) {
+		t.Errorf("data = %#v but should contain float64 values", result.Data)
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func TestJSON_DecodeIntoNumberMode(t *testing.T) {
+	//--------------------------------------------------
+	jsonString := `{"id":9007199254740993,"price":1.5,"big":123456789012345678901234567890,"list":[1,{"n":-2}]}`
+	//--------------------------------------------------
+	result, err := JSON_DecodeInto[map[string]any](jsonString, WithJSONNumberMode(JSONNumberInt64))
+	if err != nil {
+		t.Fatalf("returned error = %v", err)
+	}
+	//--------------------------------------------------
+	if result["id"] != int64(9007199254740993) {
+		t.Errorf("id = %#v but should = int64(9007199254740993)", result["id"])
+	}
+	if result["price"] != 1.5 {
+		t.Errorf("price = %#v but should = 1.5", result["price"])
+	}
+	if _, ok := result["big"].(float64); !ok {
+		t.Errorf("big = %#v but should be float64", result["big"])
+	}
+	if list := result["list"].([]any); list[0] != int64(1) || list[1].(map[string]any)["n"] != int64(-2) {
+		t.Errorf("list = %#v but should contain int64 values", list)
+	}
+	//--------------------------------------------------
+	numberResult, err := JSON_DecodeInto[any](jsonString, WithJSONNumberMode(JSONNumberString))
+	if err != nil {
+		t.Fatalf("returned error = %v", err)
+	}
+	//--------------------------------------------------
+	if id := numberResult.(map[string]any)["id"]; id != json.Number("9007199254740993") {
+		t.Errorf("id = %#v but should = json.Number(\"9007199254740993\")", id)
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func TestJSON_DecodeIntoStrict(t *testing.T) {
+	//--------------------------------------------------
+	type testStruct struct {
+		ID int `json:"id"`
+	}
+	//--------------------------------------------------
+	testCases := []struct {
+		jsonString     string
+		strict         bool
+		expectedError  error
+		expectedLine   int
+		expectedColumn int
+	}{
+		{`{"id":1,"name":"x"}`, false, nil, 0, 0},
+		{`{"id":1,"name":"x"}`, true, ErrJSONUnknownField, 1, 9},
+		{`{"id":1} {"id":2}`, false, ErrJSONTrailingData, 1, 10},
+		{`{"id":1} {"id":2}`, true, ErrJSONTrailingData, 1, 10},
+		{`{"id":1}{"id":2}`, false, ErrJSONTrailingData, 1, 9},
+		{`{"id":1} xyz`, false, ErrJSONTrailingData, 1, 10},
+		{"{\"id\":1} \n\t", false, nil, 0, 0},
+		{"{\n  \"id\": 1,\n  \"id\": 2\n}", false, nil, 0, 0},
+		{"{\n  \"id\": 1,\n  \"id\": 2\n}", true, ErrJSONDuplicateKey, 3, 3},
+		{"{\n  \"id\": 1 2\n}", true, nil, 2, 11},
+		{"{\n  \"id\": \"1\"\n}", false, nil, 2, 11},
+		{`{"id":`, false, io.ErrUnexpectedEOF, 1, 7},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		_, err := JSON_DecodeInto[testStruct](testCase.jsonString, WithJSONStrict(testCase.strict))
+		//--------------------------------------------------
+		if testCase.expectedLine == 0 {
+			if err != nil {
+				t.Errorf("(%q) returned error = %v", testCase.jsonString, err)
+			}
+			continue
+		}
+		//--------------------------------------------------
+		var decodeError *JSONDecodeError
+		if !errors.As(err, &decodeError) {
+			t.Errorf("(%q) returned error = %v but should be a *JSONDecodeError", testCase.jsonString, err)
+			continue
+		}
+		//--------------------------------------------------
+		if testCase.expectedError != nil && !errors.Is(err, testCase.expectedError) {
+			t.Errorf("(%q) returned error = %v but should wrap %q", testCase.jsonString, err, testCase.expectedError)
+		}
+		//--------------------------------------------------
+		if decodeError.Line != testCase.expectedLine || decodeError.Column != testCase.expectedColumn {
+			t.Errorf("(%q) error position = %d:%d but should = %d:%d", testCase.jsonString, decodeError.Line, decodeError.Column, testCase.expectedLine, testCase.expectedColumn)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	// the unknown field is reported at its key, not at an earlier string value with the same text
+	type keyStruct struct {
+		A string `json:"a"`
+	}
+	//--------------------------------------------------
+	_, err := JSON_DecodeInto[keyStruct](`{"a":"b","b":1}`, WithJSONStrict(true))
+	//--------------------------------------------------
+	var decodeError *JSONDecodeError
+	if !errors.As(err, &decodeError) || !errors.Is(err, ErrJSONUnknownField) {
+		t.Errorf("returned error = %v but should wrap %q", err, ErrJSONUnknownField)
+	} else if decodeError.Column != 10 {
+		t.Errorf("error column = %d but should = %d", decodeError.Column, 10)
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
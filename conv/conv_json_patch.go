@@ -0,0 +1,520 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+var ErrPatchInvalid = errors.New("invalid patch operation")
+
+var ErrPatchTestFailed = errors.New("patch test operation failed")
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// JSONPatch_Apply
+//------------------------------------------------------------
+
+// JSONPatch_Apply applies an RFC 6902 JSON Patch to a JSON_decode document
+//
+// patch is the decoded array of operations, e.g. from RPC_decode_json params
+//
+// the document is copied first so it is left unchanged if any operation fails
+func JSONPatch_Apply(document any, patch any) (any, error) {
+	//------------------------------------------------------------
+	operations, ok := patch.([]any)
+	if !ok {
+		if mapOperations, isMaps := patch.([]map[string]any); isMaps {
+			for _, operation := range mapOperations {
+				operations = append(operations, operation)
+			}
+		} else {
+			return nil, fmt.Errorf("%w: patch must be an array", ErrPatchInvalid)
+		}
+	}
+	//------------------------------------------------------------
+	var err error
+	//------------------------------------------------------------
+	document = JSON_Copy(document)
+	//------------------------------------------------------------
+	for index, operationValue := range operations {
+		//------------------------------------------------------------
+		operation, ok := operationValue.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("patch operation %d: %w: operation must be an object", index, ErrPatchInvalid)
+		}
+		//------------------------------------------------------------
+		op, _ := operation["op"].(string)
+		//------------------------------------------------------------
+		document, err = jsonPatchOperation(document, op, operation)
+		if err != nil {
+			return nil, fmt.Errorf("patch operation %d (%s): %w", index, op, err)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return document, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonPatchOperation
+//------------------------------------------------------------
+
+func jsonPatchOperation(document any, op string, operation map[string]any) (any, error) {
+	//------------------------------------------------------------
+	path, ok := operation["path"].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing path", ErrPatchInvalid)
+	}
+	//------------------------------------------------------------
+	value, hasValue := operation["value"]
+	value = JSON_Copy(value)
+	from, hasFrom := operation["from"].(string)
+	//------------------------------------------------------------
+	switch op {
+	case "add":
+		if !hasValue {
+			return nil, fmt.Errorf("%w: missing value", ErrPatchInvalid)
+		}
+		return jsonPatchAdd(document, path, value)
+	case "remove":
+		return JSONPointer_Delete(document, path)
+	case "replace":
+		if !hasValue {
+			return nil, fmt.Errorf("%w: missing value", ErrPatchInvalid)
+		}
+		if _, err := JSONPointer_Get(document, path); err != nil {
+			return nil, err
+		}
+		return JSONPointer_Set(document, path, value)
+	case "move":
+		if !hasFrom {
+			return nil, fmt.Errorf("%w: missing from", ErrPatchInvalid)
+		}
+		if path == from {
+			return document, nil
+		}
+		if strings.HasPrefix(path, from+"/") {
+			return nil, fmt.Errorf("%w: cannot move a value into one of its children", ErrPatchInvalid)
+		}
+		fromValue, err := JSONPointer_Get(document, from)
+		if err != nil {
+			return nil, err
+		}
+		document, err = JSONPointer_Delete(document, from)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPatchAdd(document, path, fromValue)
+	case "copy":
+		if !hasFrom {
+			return nil, fmt.Errorf("%w: missing from", ErrPatchInvalid)
+		}
+		fromValue, err := JSONPointer_Get(document, from)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPatchAdd(document, path, JSON_Copy(fromValue))
+	case "test":
+		if !hasValue {
+			return nil, fmt.Errorf("%w: missing value", ErrPatchInvalid)
+		}
+		currentValue, err := JSONPointer_Get(document, path)
+		if err != nil {
+			return nil, err
+		}
+		if !JSON_Equal(currentValue, value) {
+			return nil, fmt.Errorf("%w: %q", ErrPatchTestFailed, path)
+		}
+		return document, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown op %q", ErrPatchInvalid, op)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonPatchAdd
+//------------------------------------------------------------
+
+// jsonPatchAdd inserts into arrays rather than replacing as required by the RFC 6902 add operation
+func jsonPatchAdd(document any, pointer string, value any) (any, error) {
+	//------------------------------------------------------------
+	tokens, err := JSONPointer_Parse(pointer)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return jsonPointerUpdate(document, tokens, 0, func(parent any, token string, path string) (any, error) {
+		//------------------------------------------------------------
+		switch typedParent := parent.(type) {
+		case map[string]any:
+			typedParent[token] = value
+			return typedParent, nil
+		case []any:
+			arrayIndex, err := jsonPointerIndex(token, len(typedParent), true)
+			if err != nil {
+				return nil, &JSONPathError{Path: path, Err: err}
+			}
+			typedParent = append(typedParent, nil)
+			copy(typedParent[arrayIndex+1:], typedParent[arrayIndex:])
+			typedParent[arrayIndex] = value
+			return typedParent, nil
+		default:
+			return nil, &JSONPathError{Path: path, Err: ErrPathType}
+		}
+		//------------------------------------------------------------
+	}, value)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSONPatch_Diff
+//------------------------------------------------------------
+
+// JSONPatch_Diff returns the RFC 6902 JSON Patch operations that turn source into target
+//
+// the result is a []any of operation maps ready for JSON_encode
+func JSONPatch_Diff(source any, target any) []any {
+	//------------------------------------------------------------
+	return jsonPatchDiff(source, target, "", []any{})
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonPatchDiff
+//------------------------------------------------------------
+
+func jsonPatchDiff(source any, target any, path string, operations []any) []any {
+	//------------------------------------------------------------
+	switch typedSource := source.(type) {
+	case map[string]any:
+		//------------------------------------------------------------
+		typedTarget, ok := target.(map[string]any)
+		if !ok {
+			break
+		}
+		//------------------------------------------------------------
+		for _, key := range sortedKeys(typedSource) {
+			if _, exists := typedTarget[key]; !exists {
+				operations = append(operations, map[string]any{"op": "remove", "path": path + JSONPointer_Join(key)})
+			}
+		}
+		//------------------------------------------------------------
+		for _, key := range sortedKeys(typedTarget) {
+			if sourceValue, exists := typedSource[key]; exists {
+				operations = jsonPatchDiff(sourceValue, typedTarget[key], path+JSONPointer_Join(key), operations)
+			} else {
+				operations = append(operations, map[string]any{"op": "add", "path": path + JSONPointer_Join(key), "value": JSON_Copy(typedTarget[key])})
+			}
+		}
+		//------------------------------------------------------------
+		return operations
+		//------------------------------------------------------------
+	case []any:
+		//------------------------------------------------------------
+		typedTarget, ok := target.([]any)
+		if !ok {
+			break
+		}
+		//------------------------------------------------------------
+		commonLength := min(len(typedSource), len(typedTarget))
+		//------------------------------------------------------------
+		for index := 0; index < commonLength; index++ {
+			operations = jsonPatchDiff(typedSource[index], typedTarget[index], path+"/"+strconv.Itoa(index), operations)
+		}
+		//------------------------------------------------------------
+		// remove from the end so earlier indexes stay valid
+		for index := len(typedSource) - 1; index >= commonLength; index-- {
+			operations = append(operations, map[string]any{"op": "remove", "path": path + "/" + strconv.Itoa(index)})
+		}
+		//------------------------------------------------------------
+		for index := commonLength; index < len(typedTarget); index++ {
+			operations = append(operations, map[string]any{"op": "add", "path": path + "/-", "value": JSON_Copy(typedTarget[index])})
+		}
+		//------------------------------------------------------------
+		return operations
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if !JSON_Equal(source, target) {
+		operations = append(operations, map[string]any{"op": "replace", "path": path, "value": JSON_Copy(target)})
+	}
+	//------------------------------------------------------------
+	return operations
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// JSONMergePatch_Apply
+//------------------------------------------------------------
+
+// JSONMergePatch_Apply applies an RFC 7386 JSON Merge Patch where null values remove members
+//
+// the document is copied first and left unchanged
+func JSONMergePatch_Apply(document any, patch any) any {
+	//------------------------------------------------------------
+	return jsonMergePatch(JSON_Copy(document), patch)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonMergePatch
+//------------------------------------------------------------
+
+func jsonMergePatch(document any, patch any) any {
+	//------------------------------------------------------------
+	patchMap, ok := patch.(map[string]any)
+	if !ok {
+		return JSON_Copy(patch)
+	}
+	//------------------------------------------------------------
+	documentMap, ok := document.(map[string]any)
+	if !ok {
+		documentMap = map[string]any{}
+	}
+	//------------------------------------------------------------
+	for key, value := range patchMap {
+		if value == nil {
+			delete(documentMap, key)
+		} else {
+			documentMap[key] = jsonMergePatch(documentMap[key], value)
+		}
+	}
+	//------------------------------------------------------------
+	return documentMap
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSONMergePatch_Diff
+//------------------------------------------------------------
+
+// JSONMergePatch_Diff returns the RFC 7386 JSON Merge Patch that turns source into target
+//
+// merge patches cannot set a member to null so null values in target are treated as removals
+func JSONMergePatch_Diff(source any, target any) any {
+	//------------------------------------------------------------
+	sourceMap, sourceOk := source.(map[string]any)
+	targetMap, targetOk := target.(map[string]any)
+	//------------------------------------------------------------
+	if !sourceOk || !targetOk {
+		return JSON_Copy(target)
+	}
+	//------------------------------------------------------------
+	patchMap := map[string]any{}
+	//------------------------------------------------------------
+	for key := range sourceMap {
+		if value, exists := targetMap[key]; !exists || value == nil {
+			patchMap[key] = nil
+		}
+	}
+	//------------------------------------------------------------
+	for key, targetValue := range targetMap {
+		//------------------------------------------------------------
+		if targetValue == nil {
+			continue
+		}
+		//------------------------------------------------------------
+		sourceValue, exists := sourceMap[key]
+		//------------------------------------------------------------
+		switch {
+		case !exists:
+			patchMap[key] = JSON_Copy(targetValue)
+		case JSON_Equal(sourceValue, targetValue):
+			continue
+		default:
+			patchMap[key] = JSONMergePatch_Diff(sourceValue, targetValue)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return patchMap
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// JSON_Copy
+//------------------------------------------------------------
+
+// JSON_Copy returns a deep copy of the maps and arrays in a JSON_decode document
+func JSON_Copy(value any) any {
+	//------------------------------------------------------------
+	switch typedValue := value.(type) {
+	case map[string]any:
+		copyMap := make(map[string]any, len(typedValue))
+		for key, mapValue := range typedValue {
+			copyMap[key] = JSON_Copy(mapValue)
+		}
+		return copyMap
+	case []any:
+		copySlice := make([]any, len(typedValue))
+		for index, sliceValue := range typedValue {
+			copySlice[index] = JSON_Copy(sliceValue)
+		}
+		return copySlice
+	default:
+		return value
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSON_Equal
+//------------------------------------------------------------
+
+// JSON_Equal compares two JSON_decode values, numbers of any type (including json.Number) are compared by exact value
+func JSON_Equal(value1 any, value2 any) bool {
+	//------------------------------------------------------------
+	switch typedValue1 := value1.(type) {
+	case map[string]any:
+		typedValue2, ok := value2.(map[string]any)
+		if !ok || len(typedValue1) != len(typedValue2) {
+			return false
+		}
+		for key, mapValue := range typedValue1 {
+			mapValue2, exists := typedValue2[key]
+			if !exists || !JSON_Equal(mapValue, mapValue2) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		typedValue2, ok := value2.([]any)
+		if !ok || len(typedValue1) != len(typedValue2) {
+			return false
+		}
+		for index := range typedValue1 {
+			if !JSON_Equal(typedValue1[index], typedValue2[index]) {
+				return false
+			}
+		}
+		return true
+	}
+	//------------------------------------------------------------
+	number1, isNumber1 := jsonNumber(value1)
+	number2, isNumber2 := jsonNumber(value2)
+	//------------------------------------------------------------
+	if isNumber1 || isNumber2 {
+		//------------------------------------------------------------
+		// infinities and NaN have no exact value, a JSON value never holds them
+		if number1 == nil || number2 == nil {
+			return isNumber1 && isNumber2 && value1 == value2
+		}
+		//------------------------------------------------------------
+		return number1.Cmp(number2) == 0
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return reflect.DeepEqual(value1, value2)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonNumber
+//------------------------------------------------------------
+
+// jsonNumber returns the exact value of a number so integers above 2^53 are not rounded through float64,
+// the value is nil for infinities and NaN
+func jsonNumber(value any) (*big.Rat, bool) {
+	//------------------------------------------------------------
+	switch typedValue := value.(type) {
+	case float64:
+		return jsonFloat(typedValue), true
+	case float32:
+		return jsonFloat(float64(typedValue)), true
+	case int:
+		return new(big.Rat).SetInt64(int64(typedValue)), true
+	case int8:
+		return new(big.Rat).SetInt64(int64(typedValue)), true
+	case int16:
+		return new(big.Rat).SetInt64(int64(typedValue)), true
+	case int32:
+		return new(big.Rat).SetInt64(int64(typedValue)), true
+	case int64:
+		return new(big.Rat).SetInt64(typedValue), true
+	case uint:
+		return new(big.Rat).SetUint64(uint64(typedValue)), true
+	case uint8:
+		return new(big.Rat).SetUint64(uint64(typedValue)), true
+	case uint16:
+		return new(big.Rat).SetUint64(uint64(typedValue)), true
+	case uint32:
+		return new(big.Rat).SetUint64(uint64(typedValue)), true
+	case uint64:
+		return new(big.Rat).SetUint64(typedValue), true
+	case json.Number:
+		// a json.Number that is not a valid number is compared as a string
+		if number, ok := new(big.Rat).SetString(string(typedValue)); ok {
+			return number, true
+		}
+	}
+	//------------------------------------------------------------
+	return nil, false
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonFloat
+//------------------------------------------------------------
+
+func jsonFloat(value float64) *big.Rat {
+	//------------------------------------------------------------
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return nil
+	}
+	//------------------------------------------------------------
+	return new(big.Rat).SetFloat64(value)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// sortedKeys
+//------------------------------------------------------------
+
+func sortedKeys(valueMap map[string]any) []string {
+	//------------------------------------------------------------
+	keys := make([]string, 0, len(valueMap))
+	for key := range valueMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	//------------------------------------------------------------
+	return keys
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
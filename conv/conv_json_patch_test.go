@@ -0,0 +1,265 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// JSONPatch_Apply
+//------------------------------------------------------------
+
+func TestJSONPatch_Apply(t *testing.T) {
+	//--------------------------------------------------
+	// RFC 6902 appendix A examples
+	testCases := []struct {
+		document      string
+		patch         string
+		expected      string
+		expectedError error
+	}{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`, nil},
+		{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`, nil},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`, nil},
+		{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`, nil},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`, nil},
+		{`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`, nil},
+		{`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`, nil},
+		{`{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`, `{"baz":"qux","foo":["a",2,"c"]}`, nil},
+		{`{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`, ``, ErrPatchTestFailed},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"child":{"grandchild":{}},"foo":"bar"}`, nil},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`, ``, ErrPathNotFound},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`, nil},
+		{`{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10}]`, `{"/":9,"~1":10}`, nil},
+		{`{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":"10"}]`, ``, ErrPatchTestFailed},
+		{`{"foo":"bar"}`, `[{"op":"copy","from":"/foo","path":"/baz"}]`, `{"baz":"bar","foo":"bar"}`, nil},
+		{`{"foo":"bar"}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`, nil},
+		{`{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":1}]`, ``, ErrPathNotFound},
+		{`{"foo":{"bar":1}}`, `[{"op":"move","from":"/foo","path":"/foo/bar/baz"}]`, ``, ErrPatchInvalid},
+		{`{"foo":"bar"}`, `[{"op":"invalid","path":"/foo"}]`, ``, ErrPatchInvalid},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz"}]`, ``, ErrPatchInvalid},
+		{`{"foo":"bar"}`, `{"op":"add","path":"/baz","value":1}`, ``, ErrPatchInvalid},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		document, _ := JSON_decode(testCase.document)
+		patch, _ := JSON_decode(testCase.patch)
+		//--------------------------------------------------
+		result, err := JSONPatch_Apply(document, patch)
+		//--------------------------------------------------
+		if testCase.expectedError != nil {
+			if !errors.Is(err, testCase.expectedError) {
+				t.Errorf("(%s) returned error = %v but should wrap %q", testCase.patch, err, testCase.expectedError)
+			}
+			continue
+		}
+		//--------------------------------------------------
+		if err != nil {
+			t.Errorf("(%s) returned error = %v", testCase.patch, err)
+			continue
+		}
+		//--------------------------------------------------
+		resultString, _ := JSON_encode(result)
+		if resultString != testCase.expected {
+			t.Errorf("(%s) = %s but should = %s", testCase.patch, resultString, testCase.expected)
+		}
+		//--------------------------------------------------
+		originalString, _ := JSON_encode(document)
+		expectedOriginal, _ := JSON_decode(testCase.document)
+		if !JSON_Equal(document, expectedOriginal) {
+			t.Errorf("(%s) modified original document to %s", testCase.patch, originalString)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSONPatch_Diff
+//------------------------------------------------------------
+
+func TestJSONPatch_Diff(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		source   string
+		target   string
+		expected string
+	}{
+		{`{"a":1}`, `{"a":1}`, `[]`},
+		{`{"a":1,"b":2}`, `{"a":1,"c":3}`, `[{"op":"remove","path":"/b"},{"op":"add","path":"/c","value":3}]`},
+		{`{"a":{"b":[1,2,3]}}`, `{"a":{"b":[1,4]}}`, `[{"op":"replace","path":"/a/b/1","value":4},{"op":"remove","path":"/a/b/2"}]`},
+		{`[1]`, `[1,{"x":"y"},2]`, `[{"op":"add","path":"/-","value":{"x":"y"}},{"op":"add","path":"/-","value":2}]`},
+		{`{"a/b":1}`, `{"a/b":"1"}`, `[{"op":"replace","path":"/a~1b","value":"1"}]`},
+		{`{"a":1}`, `[1]`, `[{"op":"replace","path":"","value":[1]}]`},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		source, _ := JSON_decode(testCase.source)
+		target, _ := JSON_decode(testCase.target)
+		//--------------------------------------------------
+		patch := JSONPatch_Diff(source, target)
+		//--------------------------------------------------
+		patchString, _ := JSON_encode(patch)
+		if patchString != testCase.expected {
+			t.Errorf("(%s, %s) = %s but should = %s", testCase.source, testCase.target, patchString, testCase.expected)
+		}
+		//--------------------------------------------------
+		result, err := JSONPatch_Apply(source, patch)
+		if err != nil || !JSON_Equal(result, target) {
+			t.Errorf("(%s, %s) patch did not round trip: %v", testCase.source, testCase.target, err)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// JSONMergePatch_Apply
+//------------------------------------------------------------
+
+func TestJSONMergePatch_Apply(t *testing.T) {
+	//--------------------------------------------------
+	// RFC 7386 appendix A examples
+	testCases := []struct {
+		document string
+		patch    string
+		expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		document, _ := JSON_decode(testCase.document)
+		patch, _ := JSON_decode(testCase.patch)
+		//--------------------------------------------------
+		resultString, _ := JSON_encode(JSONMergePatch_Apply(document, patch))
+		if resultString != testCase.expected {
+			t.Errorf("(%s, %s) = %s but should = %s", testCase.document, testCase.patch, resultString, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSONMergePatch_Diff
+//------------------------------------------------------------
+
+func TestJSONMergePatch_Diff(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		source   string
+		target   string
+		expected string
+	}{
+		{`{"a":"b"}`, `{"a":"b"}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"b":"d","c":1}`, `{"a":null,"b":"d","c":1}`},
+		{`{"a":{"b":"c","d":"e"}}`, `{"a":{"b":"c"}}`, `{"a":{"d":null}}`},
+		{`{"a":[1,2]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`{"a":1}`, `[1]`, `[1]`},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		source, _ := JSON_decode(testCase.source)
+		target, _ := JSON_decode(testCase.target)
+		//--------------------------------------------------
+		patch := JSONMergePatch_Diff(source, target)
+		//--------------------------------------------------
+		patchString, _ := JSON_encode(patch)
+		if patchString != testCase.expected {
+			t.Errorf("(%s, %s) = %s but should = %s", testCase.source, testCase.target, patchString, testCase.expected)
+		}
+		//--------------------------------------------------
+		if result := JSONMergePatch_Apply(source, patch); !JSON_Equal(result, target) {
+			t.Errorf("(%s, %s) patch did not round trip", testCase.source, testCase.target)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSON_Equal
+//------------------------------------------------------------
+
+func TestJSON_Equal(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		value1   any
+		value2   any
+		expected bool
+	}{
+		{float64(1), 1, true},
+		{int64(2), uint8(2), true},
+		{1, "1", false},
+		{nil, nil, true},
+		{map[string]any{"a": []any{1, "b"}}, map[string]any{"a": []any{float64(1), "b"}}, true},
+		{map[string]any{"a": nil}, map[string]any{}, false},
+		{[]any{1, 2}, []any{2, 1}, false},
+		// integers above 2^53 must not be rounded through float64
+		{int64(9007199254740993), int64(9007199254740992), false},
+		{uint64(18446744073709551615), float64(18446744073709551615), false},
+		{int64(9007199254740992), float64(9007199254740992), true},
+		{json.Number("9007199254740993"), int64(9007199254740993), true},
+		{json.Number("9007199254740993"), json.Number("9007199254740992"), false},
+		{json.Number("1.50"), 1.5, true},
+		{json.Number("1e3"), 1000, true},
+		{json.Number("x"), "x", false},
+		{math.Inf(1), math.Inf(1), true},
+		{math.Inf(1), 1, false},
+		// values that are not comparable with == (e.g. from CBOR or MessagePack) must not panic
+		{[]byte("a"), []byte("a"), true},
+		{[]byte("a"), []byte("b"), false},
+		{map[string]string{"a": "b"}, map[string]string{"a": "b"}, true},
+		{[]string{"a"}, "a", false},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		if result := JSON_Equal(testCase.value1, testCase.value2); result != testCase.expected {
+			t.Errorf("(%v, %v) = %v but should = %v", testCase.value1, testCase.value2, result, testCase.expected)
+		}
+	}
+	//--------------------------------------------------
+	// as decoded with json.Decoder.UseNumber and as an int64
+	source := map[string]any{"id": json.Number("9007199254740993")}
+	target := map[string]any{"id": int64(9007199254740992)}
+	//--------------------------------------------------
+	if patch := JSONPatch_Diff(source, target); len(patch) != 1 {
+		t.Errorf("patch = %v should replace /id", patch)
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,655 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+var ErrPathNotFound = errors.New("path not found")
+
+var ErrPathType = errors.New("path does not match value type")
+
+var ErrPathSyntax = errors.New("invalid path syntax")
+
+//------------------------------------------------------------
+
+// JSONPathError reports the JSON Pointer or JSONPath location that failed
+//
+// Err is one of ErrPathNotFound, ErrPathType or ErrPathSyntax so callers can use errors.Is
+type JSONPathError struct {
+	Path string
+	Err  error
+}
+
+func (e *JSONPathError) Error() string { return fmt.Sprintf("%v: %q", e.Err, e.Path) }
+
+func (e *JSONPathError) Unwrap() error { return e.Err }
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// JSONPointer_Parse
+//------------------------------------------------------------
+
+// JSONPointer_Parse splits an RFC 6901 JSON Pointer into unescaped reference tokens
+func JSONPointer_Parse(pointer string) ([]string, error) {
+	//------------------------------------------------------------
+	if pointer == "" {
+		return []string{}, nil
+	}
+	//------------------------------------------------------------
+	if pointer[0] != '/' {
+		return nil, &JSONPathError{Path: pointer, Err: ErrPathSyntax}
+	}
+	//------------------------------------------------------------
+	tokens := strings.Split(pointer[1:], "/")
+	//------------------------------------------------------------
+	for index, token := range tokens {
+		//------------------------------------------------------------
+		for charIndex := 0; charIndex < len(token); charIndex++ {
+			if token[charIndex] == '~' && (charIndex+1 == len(token) || (token[charIndex+1] != '0' && token[charIndex+1] != '1')) {
+				return nil, &JSONPathError{Path: pointer, Err: ErrPathSyntax}
+			}
+		}
+		//------------------------------------------------------------
+		tokens[index] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return tokens, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSONPointer_Join
+//------------------------------------------------------------
+
+// JSONPointer_Join escapes and joins reference tokens into an RFC 6901 JSON Pointer
+func JSONPointer_Join(tokens ...string) string {
+	//------------------------------------------------------------
+	var builder strings.Builder
+	//------------------------------------------------------------
+	for _, token := range tokens {
+		builder.WriteByte('/')
+		builder.WriteString(JSONPointer_Escape(token))
+	}
+	//------------------------------------------------------------
+	return builder.String()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSONPointer_Escape
+//------------------------------------------------------------
+
+func JSONPointer_Escape(token string) string {
+	//------------------------------------------------------------
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// JSONPointer_Get
+//------------------------------------------------------------
+
+// JSONPointer_Get returns the value in a JSON_decode document referenced by pointer
+func JSONPointer_Get(document any, pointer string) (any, error) {
+	//------------------------------------------------------------
+	tokens, err := JSONPointer_Parse(pointer)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	node := document
+	//------------------------------------------------------------
+	for index, token := range tokens {
+		//------------------------------------------------------------
+		path := JSONPointer_Join(tokens[:index+1]...)
+		//------------------------------------------------------------
+		switch typedNode := node.(type) {
+		case map[string]any:
+			value, exists := typedNode[token]
+			if !exists {
+				return nil, &JSONPathError{Path: path, Err: ErrPathNotFound}
+			}
+			node = value
+		case []any:
+			arrayIndex, err := jsonPointerIndex(token, len(typedNode), false)
+			if err != nil {
+				return nil, &JSONPathError{Path: path, Err: err}
+			}
+			node = typedNode[arrayIndex]
+		default:
+			return nil, &JSONPathError{Path: path, Err: ErrPathType}
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return node, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSONPointer_Exists
+//------------------------------------------------------------
+
+func JSONPointer_Exists(document any, pointer string) bool {
+	//------------------------------------------------------------
+	_, err := JSONPointer_Get(document, pointer)
+	//------------------------------------------------------------
+	return err == nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSONPointer_Set
+//------------------------------------------------------------
+
+// JSONPointer_Set sets the value referenced by pointer and returns the updated document
+//
+// maps are updated in place, the parent of the target must already exist and
+// an array index of "-" or the array length appends a new element
+func JSONPointer_Set(document any, pointer string, value any) (any, error) {
+	//------------------------------------------------------------
+	tokens, err := JSONPointer_Parse(pointer)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return jsonPointerUpdate(document, tokens, 0, func(parent any, token string, path string) (any, error) {
+		//------------------------------------------------------------
+		switch typedParent := parent.(type) {
+		case map[string]any:
+			typedParent[token] = value
+			return typedParent, nil
+		case []any:
+			arrayIndex, err := jsonPointerIndex(token, len(typedParent), true)
+			if err != nil {
+				return nil, &JSONPathError{Path: path, Err: err}
+			}
+			if arrayIndex == len(typedParent) {
+				return append(typedParent, value), nil
+			}
+			typedParent[arrayIndex] = value
+			return typedParent, nil
+		default:
+			return nil, &JSONPathError{Path: path, Err: ErrPathType}
+		}
+		//------------------------------------------------------------
+	}, value)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSONPointer_Delete
+//------------------------------------------------------------
+
+// JSONPointer_Delete removes the value referenced by pointer and returns the updated document
+func JSONPointer_Delete(document any, pointer string) (any, error) {
+	//------------------------------------------------------------
+	tokens, err := JSONPointer_Parse(pointer)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	//------------------------------------------------------------
+	return jsonPointerUpdate(document, tokens, 0, func(parent any, token string, path string) (any, error) {
+		//------------------------------------------------------------
+		switch typedParent := parent.(type) {
+		case map[string]any:
+			if _, exists := typedParent[token]; !exists {
+				return nil, &JSONPathError{Path: path, Err: ErrPathNotFound}
+			}
+			delete(typedParent, token)
+			return typedParent, nil
+		case []any:
+			arrayIndex, err := jsonPointerIndex(token, len(typedParent), false)
+			if err != nil {
+				return nil, &JSONPathError{Path: path, Err: err}
+			}
+			return append(typedParent[:arrayIndex:arrayIndex], typedParent[arrayIndex+1:]...), nil
+		default:
+			return nil, &JSONPathError{Path: path, Err: ErrPathType}
+		}
+		//------------------------------------------------------------
+	}, nil)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonPointerUpdate
+//------------------------------------------------------------
+
+// jsonPointerUpdate walks to the parent of the final token, applies updateFunc and rebuilds any arrays on the way back up
+func jsonPointerUpdate(node any, tokens []string, depth int, updateFunc func(parent any, token string, path string) (any, error), rootValue any) (any, error) {
+	//------------------------------------------------------------
+	if len(tokens) == 0 {
+		return rootValue, nil
+	}
+	//------------------------------------------------------------
+	path := JSONPointer_Join(tokens[:depth+1]...)
+	token := tokens[depth]
+	//------------------------------------------------------------
+	if depth == len(tokens)-1 {
+		return updateFunc(node, token, path)
+	}
+	//------------------------------------------------------------
+	switch typedNode := node.(type) {
+	case map[string]any:
+		//------------------------------------------------------------
+		child, exists := typedNode[token]
+		if !exists {
+			return nil, &JSONPathError{Path: path, Err: ErrPathNotFound}
+		}
+		//------------------------------------------------------------
+		newChild, err := jsonPointerUpdate(child, tokens, depth+1, updateFunc, rootValue)
+		if err != nil {
+			return nil, err
+		}
+		//------------------------------------------------------------
+		typedNode[token] = newChild
+		return typedNode, nil
+		//------------------------------------------------------------
+	case []any:
+		//------------------------------------------------------------
+		arrayIndex, err := jsonPointerIndex(token, len(typedNode), false)
+		if err != nil {
+			return nil, &JSONPathError{Path: path, Err: err}
+		}
+		//------------------------------------------------------------
+		newChild, err := jsonPointerUpdate(typedNode[arrayIndex], tokens, depth+1, updateFunc, rootValue)
+		if err != nil {
+			return nil, err
+		}
+		//------------------------------------------------------------
+		typedNode[arrayIndex] = newChild
+		return typedNode, nil
+		//------------------------------------------------------------
+	default:
+		return nil, &JSONPathError{Path: path, Err: ErrPathType}
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonPointerIndex
+//------------------------------------------------------------
+
+// jsonPointerIndex converts an array reference token into an index
+//
+// appendBool allows "-" or the array length to refer to the position after the last element
+func jsonPointerIndex(token string, length int, appendBool bool) (int, error) {
+	//------------------------------------------------------------
+	if token == "-" {
+		if appendBool {
+			return length, nil
+		}
+		return 0, ErrPathNotFound
+	}
+	//------------------------------------------------------------
+	if token == "" || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, ErrPathNotFound
+	}
+	//------------------------------------------------------------
+	// leading zeros are not allowed by RFC 6901
+	if len(token) > 1 && token[0] == '0' {
+		return 0, ErrPathSyntax
+	}
+	//------------------------------------------------------------
+	arrayIndex, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, ErrPathNotFound
+	}
+	//------------------------------------------------------------
+	if arrayIndex > length || (arrayIndex == length && !appendBool) {
+		return 0, ErrPathNotFound
+	}
+	//------------------------------------------------------------
+	return arrayIndex, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type jsonPathSegment struct {
+	recursive bool
+	wildcard  bool
+	name      *string
+	index     *int
+	slice     *[3]*int
+}
+
+//------------------------------------------------------------
+// JSONPath_Query
+//------------------------------------------------------------
+
+// JSONPath_Query returns all values in a JSON_decode document matching path
+//
+// supported syntax: $ root, .name, ['name'], [index] (negative counts from the end),
+// * wildcard, [start:end:step] array slice and .. recursive descent
+//
+// an error wrapping ErrPathNotFound or ErrPathType is returned when nothing matches
+func JSONPath_Query(document any, path string) ([]any, error) {
+	//------------------------------------------------------------
+	segments, err := jsonPathParse(path)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	nodes := []any{document}
+	var failure error
+	//------------------------------------------------------------
+	for _, segment := range segments {
+		//------------------------------------------------------------
+		if segment.recursive {
+			descendants := []any{}
+			for _, node := range nodes {
+				descendants = jsonPathDescendants(node, descendants)
+			}
+			nodes = descendants
+		}
+		//------------------------------------------------------------
+		matches := []any{}
+		//------------------------------------------------------------
+		for _, node := range nodes {
+			var nodeMatches []any
+			nodeMatches, err = segment.match(node)
+			if err != nil && failure == nil {
+				failure = err
+			}
+			matches = append(matches, nodeMatches...)
+		}
+		//------------------------------------------------------------
+		nodes = matches
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if len(nodes) == 0 {
+		if failure == nil {
+			failure = ErrPathNotFound
+		}
+		return nil, &JSONPathError{Path: path, Err: failure}
+	}
+	//------------------------------------------------------------
+	return nodes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// match
+//------------------------------------------------------------
+
+func (segment jsonPathSegment) match(node any) ([]any, error) {
+	//------------------------------------------------------------
+	switch typedNode := node.(type) {
+	case map[string]any:
+		//------------------------------------------------------------
+		if segment.wildcard {
+			keys := make([]string, 0, len(typedNode))
+			for key := range typedNode {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			values := make([]any, 0, len(keys))
+			for _, key := range keys {
+				values = append(values, typedNode[key])
+			}
+			return values, nil
+		}
+		//------------------------------------------------------------
+		if segment.name == nil {
+			return nil, ErrPathType
+		}
+		//------------------------------------------------------------
+		value, exists := typedNode[*segment.name]
+		if !exists {
+			return nil, ErrPathNotFound
+		}
+		return []any{value}, nil
+		//------------------------------------------------------------
+	case []any:
+		//------------------------------------------------------------
+		switch {
+		case segment.wildcard:
+			return append([]any{}, typedNode...), nil
+		case segment.index != nil:
+			arrayIndex := *segment.index
+			if arrayIndex < 0 {
+				arrayIndex += len(typedNode)
+			}
+			if arrayIndex < 0 || arrayIndex >= len(typedNode) {
+				return nil, ErrPathNotFound
+			}
+			return []any{typedNode[arrayIndex]}, nil
+		case segment.slice != nil:
+			return jsonPathSlice(typedNode, *segment.slice), nil
+		default:
+			return nil, ErrPathType
+		}
+		//------------------------------------------------------------
+	default:
+		return nil, ErrPathType
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonPathSlice
+//------------------------------------------------------------
+
+// jsonPathSlice selects elements using python style start:end:step semantics
+func jsonPathSlice(array []any, slice [3]*int) []any {
+	//------------------------------------------------------------
+	length := len(array)
+	step := 1
+	if slice[2] != nil {
+		step = *slice[2]
+	}
+	//------------------------------------------------------------
+	normalise := func(value *int, defaultValue int) int {
+		if value == nil {
+			return defaultValue
+		}
+		if *value < 0 {
+			return *value + length
+		}
+		return *value
+	}
+	//------------------------------------------------------------
+	values := []any{}
+	//------------------------------------------------------------
+	if step > 0 {
+		lower := max(min(normalise(slice[0], 0), length), 0)
+		upper := max(min(normalise(slice[1], length), length), 0)
+		for index := lower; index < upper; index += step {
+			values = append(values, array[index])
+		}
+	} else {
+		upper := max(min(normalise(slice[0], length-1), length-1), -1)
+		lower := max(min(normalise(slice[1], -length-1), length-1), -1)
+		for index := upper; lower < index; index += step {
+			values = append(values, array[index])
+		}
+	}
+	//------------------------------------------------------------
+	return values
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonPathDescendants
+//------------------------------------------------------------
+
+// jsonPathDescendants appends node and all nested values in document order with map keys sorted
+func jsonPathDescendants(node any, descendants []any) []any {
+	//------------------------------------------------------------
+	descendants = append(descendants, node)
+	//------------------------------------------------------------
+	switch typedNode := node.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(typedNode))
+		for key := range typedNode {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			descendants = jsonPathDescendants(typedNode[key], descendants)
+		}
+	case []any:
+		for _, value := range typedNode {
+			descendants = jsonPathDescendants(value, descendants)
+		}
+	}
+	//------------------------------------------------------------
+	return descendants
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jsonPathParse
+//------------------------------------------------------------
+
+func jsonPathParse(path string) ([]jsonPathSegment, error) {
+	//------------------------------------------------------------
+	syntaxError := &JSONPathError{Path: path, Err: ErrPathSyntax}
+	//------------------------------------------------------------
+	if !strings.HasPrefix(path, "$") {
+		return nil, syntaxError
+	}
+	//------------------------------------------------------------
+	segments := []jsonPathSegment{}
+	position := 1
+	//------------------------------------------------------------
+	for position < len(path) {
+		//------------------------------------------------------------
+		segment := jsonPathSegment{}
+		//------------------------------------------------------------
+		switch {
+		case strings.HasPrefix(path[position:], ".."):
+			segment.recursive = true
+			position += 2
+			if position < len(path) && path[position] == '[' {
+				break
+			}
+			fallthrough
+		case path[position] == '.':
+			if !segment.recursive {
+				position++
+			}
+			end := position
+			for end < len(path) && path[end] != '.' && path[end] != '[' {
+				end++
+			}
+			name := path[position:end]
+			if name == "" {
+				return nil, syntaxError
+			}
+			if name == "*" {
+				segment.wildcard = true
+			} else {
+				segment.name = &name
+			}
+			position = end
+			segments = append(segments, segment)
+			continue
+		case path[position] != '[':
+			return nil, syntaxError
+		}
+		//------------------------------------------------------------
+		// bracket notation with a quoted name which may contain any character including "]"
+		if position+1 < len(path) && (path[position+1] == '\'' || path[position+1] == '"') {
+			//------------------------------------------------------------
+			closeIndex := strings.IndexByte(path[position+2:], path[position+1])
+			if closeIndex == -1 {
+				return nil, syntaxError
+			}
+			//------------------------------------------------------------
+			name := path[position+2 : position+2+closeIndex]
+			position += 2 + closeIndex + 1
+			//------------------------------------------------------------
+			if position >= len(path) || path[position] != ']' {
+				return nil, syntaxError
+			}
+			//------------------------------------------------------------
+			segment.name = &name
+			position++
+			segments = append(segments, segment)
+			continue
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+		end := strings.IndexByte(path[position:], ']')
+		if end == -1 {
+			return nil, syntaxError
+		}
+		//------------------------------------------------------------
+		content := strings.TrimSpace(path[position+1 : position+end])
+		//------------------------------------------------------------
+		switch {
+		case content == "*":
+			segment.wildcard = true
+		case strings.Contains(content, ":"):
+			parts := strings.Split(content, ":")
+			if len(parts) > 3 {
+				return nil, syntaxError
+			}
+			var slice [3]*int
+			for partIndex, part := range parts {
+				part = strings.TrimSpace(part)
+				if part == "" {
+					continue
+				}
+				value, err := strconv.Atoi(part)
+				if err != nil || (partIndex == 2 && value == 0) {
+					return nil, syntaxError
+				}
+				slice[partIndex] = &value
+			}
+			segment.slice = &slice
+		default:
+			value, err := strconv.Atoi(content)
+			if err != nil {
+				return nil, syntaxError
+			}
+			segment.index = &value
+		}
+		//------------------------------------------------------------
+		position += end + 1
+		segments = append(segments, segment)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return segments, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,233 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// RFC 6901 section 5 example document
+const jsonPointerTestDocument = `{"foo":["bar","baz"],"":0,"a/b":1,"c%d":2,"e^f":3,"g|h":4,"i\\j":5,"k\"l":6," ":7,"m~n":8}`
+
+//------------------------------------------------------------
+// JSONPointer_Get
+//------------------------------------------------------------
+
+func TestJSONPointer_Get(t *testing.T) {
+	//--------------------------------------------------
+	document, _ := JSON_decode(jsonPointerTestDocument)
+	//--------------------------------------------------
+	testCases := []struct {
+		pointer       string
+		expectedValue string
+		expectedError error
+	}{
+		{"", fmt.Sprint(document), nil},
+		{"/foo", "[bar baz]", nil},
+		{"/foo/0", "bar", nil},
+		{"/", "0", nil},
+		{"/a~1b", "1", nil},
+		{"/c%d", "2", nil},
+		{"/e^f", "3", nil},
+		{"/g|h", "4", nil},
+		{"/i\\j", "5", nil},
+		{"/k\"l", "6", nil},
+		{"/ ", "7", nil},
+		{"/m~0n", "8", nil},
+		{"/missing", "", ErrPathNotFound},
+		{"/foo/2", "", ErrPathNotFound},
+		{"/foo/-", "", ErrPathNotFound},
+		{"/foo/bar", "", ErrPathNotFound},
+		{"/foo/01", "", ErrPathSyntax},
+		{"/foo/0/bar", "", ErrPathType},
+		{"foo", "", ErrPathSyntax},
+		{"/m~2n", "", ErrPathSyntax},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		value, err := JSONPointer_Get(document, testCase.pointer)
+		//--------------------------------------------------
+		if testCase.expectedError != nil {
+			var pathError *JSONPathError
+			if !errors.Is(err, testCase.expectedError) || !errors.As(err, &pathError) {
+				t.Errorf("(%q) returned error = %v but should wrap %q", testCase.pointer, err, testCase.expectedError)
+			}
+			continue
+		}
+		//--------------------------------------------------
+		if err != nil {
+			t.Errorf("(%q) %v", testCase.pointer, err)
+		} else if fmt.Sprint(value) != testCase.expectedValue {
+			t.Errorf("(%q) value = %v but should = %v", testCase.pointer, value, testCase.expectedValue)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	if !JSONPointer_Exists(document, "/foo/1") || JSONPointer_Exists(document, "/foo/3") {
+		t.Error("JSONPointer_Exists returned an incorrect result")
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSONPointer_Set / JSONPointer_Delete
+//------------------------------------------------------------
+
+func TestJSONPointer_SetDelete(t *testing.T) {
+	//--------------------------------------------------
+	document, _ := JSON_decode(`{"a":{"b":[1,2,3]},"c":"d"}`)
+	//--------------------------------------------------
+	testCases := []struct {
+		function       string
+		pointer        string
+		value          any
+		expectedString string
+		expectedError  error
+	}{
+		{"set", "/a/b/0", "x", `{"a":{"b":["x",2,3]},"c":"d"}`, nil},
+		{"set", "/a/b/-", "y", `{"a":{"b":["x",2,3,"y"]},"c":"d"}`, nil},
+		{"set", "/a/b/4", "z", `{"a":{"b":["x",2,3,"y","z"]},"c":"d"}`, nil},
+		{"set", "/a/e", true, `{"a":{"b":["x",2,3,"y","z"],"e":true},"c":"d"}`, nil},
+		{"delete", "/a/b/1", nil, `{"a":{"b":["x",3,"y","z"],"e":true},"c":"d"}`, nil},
+		{"delete", "/c", nil, `{"a":{"b":["x",3,"y","z"],"e":true}}`, nil},
+		{"set", "/a/b/9", "z", "", ErrPathNotFound},
+		{"set", "/x/y", "z", "", ErrPathNotFound},
+		{"set", "/a/e/f", "z", "", ErrPathType},
+		{"delete", "/c", nil, "", ErrPathNotFound},
+		{"delete", "/a/b/-", nil, "", ErrPathNotFound},
+		{"set", "", map[string]any{"root": 1}, `{"root":1}`, nil},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		var result any
+		var err error
+		//--------------------------------------------------
+		if testCase.function == "set" {
+			result, err = JSONPointer_Set(document, testCase.pointer, testCase.value)
+		} else {
+			result, err = JSONPointer_Delete(document, testCase.pointer)
+		}
+		//--------------------------------------------------
+		if testCase.expectedError != nil {
+			if !errors.Is(err, testCase.expectedError) {
+				t.Errorf("(%s %q) returned error = %v but should wrap %q", testCase.function, testCase.pointer, err, testCase.expectedError)
+			}
+			continue
+		}
+		//--------------------------------------------------
+		if err != nil {
+			t.Errorf("(%s %q) %v", testCase.function, testCase.pointer, err)
+			continue
+		}
+		//--------------------------------------------------
+		document = result
+		//--------------------------------------------------
+		resultString, _ := JSON_encode(document)
+		if resultString != testCase.expectedString {
+			t.Errorf("(%s %q) resultString = %s but should = %s", testCase.function, testCase.pointer, resultString, testCase.expectedString)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// JSONPointer_Join
+//------------------------------------------------------------
+
+func TestJSONPointer_Join(t *testing.T) {
+	//--------------------------------------------------
+	pointer := JSONPointer_Join("a/b", "m~n", "0")
+	//--------------------------------------------------
+	if pointer != "/a~1b/m~0n/0" {
+		t.Errorf("pointer = %q but should = %q", pointer, "/a~1b/m~0n/0")
+	}
+	//--------------------------------------------------
+	tokens, err := JSONPointer_Parse(pointer)
+	if err != nil {
+		t.Error(err)
+	} else if fmt.Sprint(tokens) != "[a/b m~n 0]" {
+		t.Errorf("tokens = %q but should = %q", tokens, []string{"a/b", "m~n", "0"})
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// JSONPath_Query
+//------------------------------------------------------------
+
+func TestJSONPath_Query(t *testing.T) {
+	//--------------------------------------------------
+	document, _ := JSON_decode(`{"store":{"book":[
+		{"author":"Rees","title":"Sayings","price":8.95},
+		{"author":"Waugh","title":"Sword","price":12.99},
+		{"author":"Melville","title":"Moby Dick","price":8.99},
+		{"author":"Tolkien","title":"The Lord","price":22.99}
+	],"bicycle":{"colour":"red","price":19.95},"a.b]":"quoted"}}`)
+	//--------------------------------------------------
+	testCases := []struct {
+		path           string
+		expectedString string
+		expectedError  error
+	}{
+		{"$", fmt.Sprint([]any{document}), nil},
+		{"$.store.bicycle.colour", "[red]", nil},
+		{"$['store']['bicycle'][\"price\"]", "[19.95]", nil},
+		{"$.store['a.b]']", "[quoted]", nil},
+		{"$.store.book[0].author", "[Rees]", nil},
+		{"$.store.book[-1].author", "[Tolkien]", nil},
+		{"$.store.book[*].author", "[Rees Waugh Melville Tolkien]", nil},
+		{"$.store.book.*.price", "[8.95 12.99 8.99 22.99]", nil},
+		{"$.store.book[1:3].title", "[Sword Moby Dick]", nil},
+		{"$.store.book[:2].title", "[Sayings Sword]", nil},
+		{"$.store.book[-2:].title", "[Moby Dick The Lord]", nil},
+		{"$.store.book[::2].title", "[Sayings Moby Dick]", nil},
+		{"$.store.book[::-1].author", "[Tolkien Melville Waugh Rees]", nil},
+		{"$..price", "[19.95 8.95 12.99 8.99 22.99]", nil},
+		{"$..book[0].title", "[Sayings]", nil},
+		{"$.store.missing", "", ErrPathNotFound},
+		{"$.store.book[10]", "", ErrPathNotFound},
+		{"$.store.bicycle[0]", "", ErrPathType},
+		{"$.store.book.title", "", ErrPathType},
+		{"store", "", ErrPathSyntax},
+		{"$.store[", "", ErrPathSyntax},
+		{"$.store.book[::0]", "", ErrPathSyntax},
+		{"$.", "", ErrPathSyntax},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		values, err := JSONPath_Query(document, testCase.path)
+		//--------------------------------------------------
+		if testCase.expectedError != nil {
+			if !errors.Is(err, testCase.expectedError) {
+				t.Errorf("(%q) returned error = %v but should wrap %q", testCase.path, err, testCase.expectedError)
+			}
+			continue
+		}
+		//--------------------------------------------------
+		if err != nil {
+			t.Errorf("(%q) %v", testCase.path, err)
+		} else if fmt.Sprint(values) != testCase.expectedString {
+			t.Errorf("(%q) values = %v but should = %v", testCase.path, values, testCase.expectedString)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
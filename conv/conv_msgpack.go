@@ -0,0 +1,475 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// msgpackTimestampType is the MessagePack extension type reserved for timestamps
+const msgpackTimestampType = -1
+
+type msgpackFormat struct{}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// MsgPack_Marshal
+//------------------------------------------------------------
+
+// MsgPack_Marshal encodes value as MessagePack
+//
+// map and struct keys are sorted by their encoded bytes so the same value always gives the same output,
+// struct fields use the msgpack tag, then the json tag and then the field name and
+// time.Time values use the timestamp extension type
+func MsgPack_Marshal(value any) ([]byte, error) {
+	//------------------------------------------------------------
+	return binaryAppendValue(msgpackFormat{}, nil, reflect.ValueOf(value), 0)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// MsgPack_Unmarshal
+//------------------------------------------------------------
+
+// MsgPack_Unmarshal decodes MessagePack into the same shapes as JSON_decode
+//
+// maps become map[string]any, arrays []any, integers int64 (or uint64 when too large),
+// floats float64, binary []byte and timestamps time.Time
+func MsgPack_Unmarshal(data []byte) (any, error) {
+	//------------------------------------------------------------
+	reader := &binaryReader{data: data}
+	//------------------------------------------------------------
+	value, err := msgpackDecodeValue(reader, 0)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if reader.offset != len(data) {
+		return nil, fmt.Errorf("msgpack data has %d bytes of trailing data", len(data)-reader.offset)
+	}
+	//------------------------------------------------------------
+	return value, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// MsgPack_DecodeInto
+//------------------------------------------------------------
+
+// MsgPack_DecodeInto decodes MessagePack into a value of type T such as a tagged struct
+func MsgPack_DecodeInto[T any](data []byte) (T, error) {
+	//------------------------------------------------------------
+	value, err := MsgPack_Unmarshal(data)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	//------------------------------------------------------------
+	return binaryDecodeInto[T](value, msgpackFormat{}.tagName())
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+func (msgpackFormat) tagName() string { return "msgpack" }
+
+func (msgpackFormat) appendNil(dst []byte) []byte { return append(dst, 0xc0) }
+
+func (msgpackFormat) appendBool(dst []byte, value bool) []byte {
+	if value {
+		return append(dst, 0xc3)
+	}
+	return append(dst, 0xc2)
+}
+
+//------------------------------------------------------------
+
+func (format msgpackFormat) appendInt(dst []byte, value int64) []byte {
+	//------------------------------------------------------------
+	switch {
+	case value >= 0:
+		return format.appendUint(dst, uint64(value))
+	case value >= -32:
+		return append(dst, byte(value))
+	case value >= math.MinInt8:
+		return append(dst, 0xd0, byte(value))
+	case value >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xd1), uint16(value))
+	case value >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(dst, 0xd2), uint32(value))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, 0xd3), uint64(value))
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func (msgpackFormat) appendUint(dst []byte, value uint64) []byte {
+	//------------------------------------------------------------
+	switch {
+	case value <= 0x7f:
+		return append(dst, byte(value))
+	case value <= math.MaxUint8:
+		return append(dst, 0xcc, byte(value))
+	case value <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xcd), uint16(value))
+	case value <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(dst, 0xce), uint32(value))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, 0xcf), value)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func (msgpackFormat) appendFloat(dst []byte, value float64, bitSize int) []byte {
+	//------------------------------------------------------------
+	if bitSize == 32 {
+		return binary.BigEndian.AppendUint32(append(dst, 0xca), math.Float32bits(float32(value)))
+	}
+	//------------------------------------------------------------
+	return binary.BigEndian.AppendUint64(append(dst, 0xcb), math.Float64bits(value))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func (msgpackFormat) appendString(dst []byte, value string) []byte {
+	//------------------------------------------------------------
+	length := len(value)
+	//------------------------------------------------------------
+	switch {
+	case length < 32:
+		dst = append(dst, 0xa0|byte(length))
+	case length <= math.MaxUint8:
+		dst = append(dst, 0xd9, byte(length))
+	case length <= math.MaxUint16:
+		dst = binary.BigEndian.AppendUint16(append(dst, 0xda), uint16(length))
+	default:
+		dst = binary.BigEndian.AppendUint32(append(dst, 0xdb), uint32(length))
+	}
+	//------------------------------------------------------------
+	return append(dst, value...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func (msgpackFormat) appendBytes(dst []byte, value []byte) []byte {
+	//------------------------------------------------------------
+	length := len(value)
+	//------------------------------------------------------------
+	switch {
+	case length <= math.MaxUint8:
+		dst = append(dst, 0xc4, byte(length))
+	case length <= math.MaxUint16:
+		dst = binary.BigEndian.AppendUint16(append(dst, 0xc5), uint16(length))
+	default:
+		dst = binary.BigEndian.AppendUint32(append(dst, 0xc6), uint32(length))
+	}
+	//------------------------------------------------------------
+	return append(dst, value...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+// appendTime uses the smallest of the 32, 64 and 96 bit timestamp formats that can hold value
+func (msgpackFormat) appendTime(dst []byte, value time.Time) []byte {
+	//------------------------------------------------------------
+	seconds := value.Unix()
+	nanoseconds := uint64(value.Nanosecond())
+	//------------------------------------------------------------
+	if uint64(seconds)>>34 == 0 {
+		//------------------------------------------------------------
+		if nanoseconds == 0 && seconds <= math.MaxUint32 {
+			return binary.BigEndian.AppendUint32(append(dst, 0xd6, 0xff), uint32(seconds))
+		}
+		//------------------------------------------------------------
+		return binary.BigEndian.AppendUint64(append(dst, 0xd7, 0xff), nanoseconds<<34|uint64(seconds))
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	dst = binary.BigEndian.AppendUint32(append(dst, 0xc7, 12, 0xff), uint32(nanoseconds))
+	//------------------------------------------------------------
+	return binary.BigEndian.AppendUint64(dst, uint64(seconds))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func (msgpackFormat) appendArrayHeader(dst []byte, length int) []byte {
+	//------------------------------------------------------------
+	switch {
+	case length < 16:
+		return append(dst, 0x90|byte(length))
+	case length <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xdc), uint16(length))
+	default:
+		return binary.BigEndian.AppendUint32(append(dst, 0xdd), uint32(length))
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func (msgpackFormat) appendMapHeader(dst []byte, length int) []byte {
+	//------------------------------------------------------------
+	switch {
+	case length < 16:
+		return append(dst, 0x80|byte(length))
+	case length <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xde), uint16(length))
+	default:
+		return binary.BigEndian.AppendUint32(append(dst, 0xdf), uint32(length))
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// msgpackDecodeValue
+//------------------------------------------------------------
+
+func msgpackDecodeValue(reader *binaryReader, depth int) (any, error) {
+	//------------------------------------------------------------
+	if depth > binaryMaxDepth {
+		return nil, errors.New("msgpack data exceeds maximum nesting depth")
+	}
+	//------------------------------------------------------------
+	typeByte, err := reader.readByte()
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	switch {
+	case typeByte <= 0x7f:
+		return int64(typeByte), nil
+	case typeByte >= 0xe0:
+		return int64(int8(typeByte)), nil
+	case typeByte&0xe0 == 0xa0:
+		return msgpackDecodeString(reader, uint64(typeByte&0x1f))
+	case typeByte&0xf0 == 0x90:
+		return msgpackDecodeArray(reader, uint64(typeByte&0x0f), depth)
+	case typeByte&0xf0 == 0x80:
+		return msgpackDecodeMap(reader, uint64(typeByte&0x0f), depth)
+	}
+	//------------------------------------------------------------
+	switch typeByte {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		value, err := reader.readUint(1 << (typeByte - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		return binaryIntValue(value), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (typeByte - 0xd0)
+		value, err := reader.readUint(size)
+		if err != nil {
+			return nil, err
+		}
+		// sign extend from the encoded size
+		shift := 64 - 8*size
+		return int64(value<<shift) >> shift, nil
+	case 0xca:
+		value, err := reader.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(uint32(value))), nil
+	case 0xcb:
+		value, err := reader.readUint(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(value), nil
+	case 0xd9, 0xda, 0xdb:
+		length, err := reader.readUint(1 << (typeByte - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return msgpackDecodeString(reader, length)
+	case 0xc4, 0xc5, 0xc6:
+		length, err := reader.readUint(1 << (typeByte - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		dataBytes, err := reader.read(length)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, dataBytes...), nil
+	case 0xdc, 0xdd:
+		length, err := reader.readUint(2 << (typeByte - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return msgpackDecodeArray(reader, length, depth)
+	case 0xde, 0xdf:
+		length, err := reader.readUint(2 << (typeByte - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return msgpackDecodeMap(reader, length, depth)
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return msgpackDecodeExtension(reader, uint64(1)<<(typeByte-0xd4))
+	case 0xc7, 0xc8, 0xc9:
+		length, err := reader.readUint(1 << (typeByte - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return msgpackDecodeExtension(reader, length)
+	}
+	//------------------------------------------------------------
+	return nil, fmt.Errorf("invalid msgpack type byte 0x%02x at offset %d", typeByte, reader.offset-1)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// msgpackDecodeString
+//------------------------------------------------------------
+
+func msgpackDecodeString(reader *binaryReader, length uint64) (any, error) {
+	//------------------------------------------------------------
+	dataBytes, err := reader.read(length)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return string(dataBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// msgpackDecodeArray
+//------------------------------------------------------------
+
+func msgpackDecodeArray(reader *binaryReader, length uint64, depth int) (any, error) {
+	//------------------------------------------------------------
+	// every element takes at least one byte
+	if length > uint64(len(reader.data)-reader.offset) {
+		return nil, errBinaryTruncated
+	}
+	//------------------------------------------------------------
+	array := make([]any, length)
+	//------------------------------------------------------------
+	for index := range array {
+		//------------------------------------------------------------
+		element, err := msgpackDecodeValue(reader, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		//------------------------------------------------------------
+		array[index] = element
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return array, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// msgpackDecodeMap
+//------------------------------------------------------------
+
+func msgpackDecodeMap(reader *binaryReader, length uint64, depth int) (any, error) {
+	//------------------------------------------------------------
+	// every key and value takes at least one byte
+	if length > uint64(len(reader.data)-reader.offset)/2 {
+		return nil, errBinaryTruncated
+	}
+	//------------------------------------------------------------
+	mapValue := make(map[string]any, length)
+	//------------------------------------------------------------
+	for index := uint64(0); index < length; index++ {
+		//------------------------------------------------------------
+		key, err := msgpackDecodeValue(reader, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		//------------------------------------------------------------
+		keyString, err := binaryMapKey(mapValue, key)
+		if err != nil {
+			return nil, err
+		}
+		//------------------------------------------------------------
+		mapValue[keyString], err = msgpackDecodeValue(reader, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return mapValue, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// msgpackDecodeExtension
+//------------------------------------------------------------
+
+func msgpackDecodeExtension(reader *binaryReader, length uint64) (any, error) {
+	//------------------------------------------------------------
+	extensionType, err := reader.readByte()
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	dataBytes, err := reader.read(length)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if int8(extensionType) != msgpackTimestampType {
+		return nil, fmt.Errorf("unsupported msgpack extension type %d", int8(extensionType))
+	}
+	//------------------------------------------------------------
+	switch length {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(dataBytes)), 0).UTC(), nil
+	case 8:
+		value := binary.BigEndian.Uint64(dataBytes)
+		return time.Unix(int64(value&0x3ffffffff), int64(value>>34)).UTC(), nil
+	case 12:
+		return time.Unix(int64(binary.BigEndian.Uint64(dataBytes[4:])), int64(binary.BigEndian.Uint32(dataBytes))).UTC(), nil
+	default:
+		return nil, fmt.Errorf("invalid msgpack timestamp length %d", length)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
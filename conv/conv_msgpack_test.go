@@ -0,0 +1,291 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type binaryTestNested struct {
+	Flag bool `json:"flag"`
+}
+
+type binaryTestEmbedded struct {
+	Embedded string `json:"embedded"`
+}
+
+type binaryTestStruct struct {
+	binaryTestEmbedded
+	ID      int64             `json:"id" msgpack:"msgpack_id" cbor:"cbor_id"`
+	Name    string            `json:"name"`
+	Tags    []string          `json:"tags"`
+	Score   float32           `json:"score"`
+	Data    []byte            `json:"data"`
+	Created time.Time         `json:"created"`
+	Extra   map[string]int    `json:"extra,omitempty"`
+	Nested  *binaryTestNested `json:"nested"`
+	Skip    string            `json:"-"`
+	private string
+}
+
+//------------------------------------------------------------
+
+func binaryTestEqual(value1 any, value2 any) bool {
+	//--------------------------------------------------
+	return JSON_Equal(value1, value2)
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func binaryTestCompareStruct(t *testing.T, result binaryTestStruct, expected binaryTestStruct) {
+	//--------------------------------------------------
+	t.Helper()
+	//--------------------------------------------------
+	if result.ID != expected.ID || result.Name != expected.Name || result.Score != expected.Score ||
+		strings.Join(result.Tags, ",") != strings.Join(expected.Tags, ",") || !bytes.Equal(result.Data, expected.Data) ||
+		!result.Created.Equal(expected.Created) || result.Extra["x"] != expected.Extra["x"] ||
+		result.Nested == nil || result.Nested.Flag != expected.Nested.Flag || result.Embedded != expected.Embedded {
+		t.Errorf("result = %+v but should = %+v", result, expected)
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func removeSpaces(dataString string) string {
+	//--------------------------------------------------
+	return strings.ReplaceAll(dataString, " ", "")
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// MsgPack_Marshal
+//------------------------------------------------------------
+
+func TestMsgPack_Marshal(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		value    any
+		expected string
+	}{
+		{nil, "c0"},
+		{false, "c2"},
+		{true, "c3"},
+		{0, "00"},
+		{127, "7f"},
+		{128, "cc80"},
+		{256, "cd0100"},
+		{65536, "ce00010000"},
+		{int64(4294967296), "cf0000000100000000"},
+		{-1, "ff"},
+		{-32, "e0"},
+		{-33, "d0df"},
+		{-129, "d1ff7f"},
+		{-32769, "d2ffff7fff"},
+		{int64(-2147483649), "d3ffffffff7fffffff"},
+		{1.5, "cb3ff8000000000000"},
+		{float32(1.5), "ca3fc00000"},
+		{"", "a0"},
+		{"a", "a161"},
+		{strings.Repeat("x", 32), "d920" + strings.Repeat("78", 32)},
+		{[]byte{1, 2}, "c4020102"},
+		{[]any{1, "a"}, "9201a161"},
+		{map[string]any{"b": 2, "a": 1}, "82a16101a16202"},
+		{time.Unix(0, 0), "d6ff00000000"},
+		{time.Unix(1, 5), "d7ff0000001400000001"},
+		{time.Unix(1<<34, 0), "c70cff000000000000000400000000"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		result, err := MsgPack_Marshal(testCase.value)
+		//--------------------------------------------------
+		if err != nil {
+			t.Errorf("(%v) returned error = %v", testCase.value, err)
+		} else if hex.EncodeToString(result) != testCase.expected {
+			t.Errorf("(%v) = %x but should = %s", testCase.value, result, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	if _, err := MsgPack_Marshal(make(chan int)); err == nil {
+		t.Errorf("unsupported type should return an error")
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// MsgPack_Unmarshal
+//------------------------------------------------------------
+
+func TestMsgPack_Unmarshal(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		data     string
+		expected any
+	}{
+		{"cf0000000100000000", int64(4294967296)},
+		{"cfffffffffffffffff", uint64(18446744073709551615)},
+		{"d3ffffffff7fffffff", int64(-2147483649)},
+		{"d0df", int64(-33)},
+		{"ca3fc00000", 1.5},
+		{"c4020102", []byte{1, 2}},
+		{"82a16101a16202", map[string]any{"a": 1, "b": 2}},
+		{"8101a161", map[string]any{"1": "a"}},
+		{"dc00020102", []any{1, 2}},
+		{"d7ff0000001400000001", time.Unix(1, 5).UTC()},
+		{"c70cff000000000000000400000000", time.Unix(1<<34, 0).UTC()},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		data, _ := hex.DecodeString(testCase.data)
+		//--------------------------------------------------
+		result, err := MsgPack_Unmarshal(data)
+		//--------------------------------------------------
+		if err != nil {
+			t.Errorf("(%s) returned error = %v", testCase.data, err)
+		} else if !binaryTestEqual(result, testCase.expected) {
+			t.Errorf("(%s) = %#v but should = %#v", testCase.data, result, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	for _, invalidData := range []string{"", "c1", "cc", "d9", "d901", "92", "81a1", "d40101", "c0c0", "ddffffffff"} {
+		//--------------------------------------------------
+		data, _ := hex.DecodeString(invalidData)
+		//--------------------------------------------------
+		if _, err := MsgPack_Unmarshal(data); err == nil {
+			t.Errorf("(%s) should return an error", invalidData)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	// duplicate keys and keys that collide once converted to strings (1 and "1")
+	for _, duplicateData := range []string{"82a16101a16102", "820101a13102"} {
+		//--------------------------------------------------
+		data, _ := hex.DecodeString(duplicateData)
+		//--------------------------------------------------
+		if _, err := MsgPack_Unmarshal(data); err == nil || !strings.Contains(err.Error(), "duplicate map key") {
+			t.Errorf("(%s) err = %v but should be a duplicate map key error", duplicateData, err)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func TestMsgPack_RoundTrip(t *testing.T) {
+	//--------------------------------------------------
+	jsonString := `{"id":9007199254740993,"name":"test","values":[1,2.5,-3,null,true,"x",{"nested":[]}],"empty":{},"unicode":"€ü"}`
+	//--------------------------------------------------
+	document, err := JSON_DecodeInto[any](jsonString, WithJSONNumberMode(JSONNumberInt64))
+	if err != nil {
+		t.Fatalf("JSON_DecodeInto returned error = %v", err)
+	}
+	//--------------------------------------------------
+	for _, marshalFunc := range []struct {
+		name      string
+		marshal   func(any) ([]byte, error)
+		unmarshal func([]byte) (any, error)
+	}{
+		{"msgpack", MsgPack_Marshal, MsgPack_Unmarshal},
+		{"cbor", CBOR_Marshal, CBOR_Unmarshal},
+	} {
+		//--------------------------------------------------
+		data, err := marshalFunc.marshal(document)
+		if err != nil {
+			t.Fatalf("%s marshal returned error = %v", marshalFunc.name, err)
+		}
+		//--------------------------------------------------
+		// encoding must be deterministic so the output can be hashed
+		for count := 0; count < 10; count++ {
+			if repeatData, _ := marshalFunc.marshal(document); !bytes.Equal(data, repeatData) {
+				t.Fatalf("%s marshal output is not deterministic", marshalFunc.name)
+			}
+		}
+		//--------------------------------------------------
+		result, err := marshalFunc.unmarshal(data)
+		if err != nil {
+			t.Fatalf("%s unmarshal returned error = %v", marshalFunc.name, err)
+		}
+		//--------------------------------------------------
+		if !JSON_Equal(result, document) {
+			t.Errorf("%s result = %v but should = %v", marshalFunc.name, result, document)
+		}
+		//--------------------------------------------------
+		if id := result.(map[string]any)["id"]; id != int64(9007199254740993) {
+			t.Errorf("%s id = %#v but should = int64(9007199254740993)", marshalFunc.name, id)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// MsgPack_DecodeInto
+//------------------------------------------------------------
+
+func TestMsgPack_DecodeInto(t *testing.T) {
+	//--------------------------------------------------
+	value := binaryTestStruct{
+		binaryTestEmbedded: binaryTestEmbedded{Embedded: "embedded"},
+		ID:                 -5,
+		Name:               "test",
+		Tags:               []string{"a"},
+		Score:              2.5,
+		Data:               []byte("data"),
+		Created:            time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		Nested:             &binaryTestNested{Flag: true},
+		Skip:               "skip",
+		private:            "private",
+	}
+	//--------------------------------------------------
+	data, err := MsgPack_Marshal(value)
+	if err != nil {
+		t.Fatalf("MsgPack_Marshal returned error = %v", err)
+	}
+	//--------------------------------------------------
+	mapValue, _ := MsgPack_Unmarshal(data)
+	//--------------------------------------------------
+	for _, key := range []string{"msgpack_id", "name", "embedded", "created"} {
+		if _, exists := mapValue.(map[string]any)[key]; !exists {
+			t.Errorf("map = %v but should contain %q", mapValue, key)
+		}
+	}
+	for _, key := range []string{"extra", "Skip", "private"} {
+		if _, exists := mapValue.(map[string]any)[key]; exists {
+			t.Errorf("map = %v but should not contain %q", mapValue, key)
+		}
+	}
+	//--------------------------------------------------
+	result, err := MsgPack_DecodeInto[binaryTestStruct](data)
+	if err != nil {
+		t.Fatalf("MsgPack_DecodeInto returned error = %v", err)
+	}
+	//--------------------------------------------------
+	binaryTestCompareStruct(t, result, value)
+	//--------------------------------------------------
+	if _, err = MsgPack_DecodeInto[struct{ Value int8 }]([]byte{0x81, 0xa5, 'V', 'a', 'l', 'u', 'e', 0xcd, 0x01, 0x00}); err == nil {
+		t.Errorf("overflowing int8 should return an error")
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
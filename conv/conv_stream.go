@@ -0,0 +1,513 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+const streamBufferSize = 4096
+
+const hexUpperCharset = "0123456789ABCDEF"
+
+const base91Charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789!#$%&()*+,./:;<=>?@[]^_`{|}~\""
+
+var baseDecodeMap = newDecodeMap(BASE_CHARSET)
+
+var base91DecodeMap = newDecodeMap(base91Charset)
+
+//------------------------------------------------------------
+// newDecodeMap
+//------------------------------------------------------------
+
+func newDecodeMap(charset string) [256]byte {
+	//------------------------------------------------------------
+	var decodeMap [256]byte
+	//------------------------------------------------------------
+	for index := range decodeMap {
+		decodeMap[index] = 0xFF
+	}
+	for index := 0; index < len(charset); index++ {
+		decodeMap[charset[index]] = byte(index)
+	}
+	//------------------------------------------------------------
+	return decodeMap
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type streamEncoder struct {
+	writer     io.Writer
+	outputBuf  []byte
+	err        error
+	closed     bool
+	encodeFunc func(encoder *streamEncoder, dataBytes []byte)
+	finishFunc func(encoder *streamEncoder)
+}
+
+//------------------------------------------------------------
+
+func (encoder *streamEncoder) Write(dataBytes []byte) (int, error) {
+	//------------------------------------------------------------
+	if encoder.err != nil {
+		return 0, encoder.err
+	}
+	if encoder.closed {
+		return 0, errors.New("write to closed encoder")
+	}
+	//------------------------------------------------------------
+	written := 0
+	//------------------------------------------------------------
+	for len(dataBytes) > 0 {
+		//------------------------------------------------------------
+		chunk := dataBytes
+		if len(chunk) > streamBufferSize {
+			chunk = chunk[:streamBufferSize]
+		}
+		//------------------------------------------------------------
+		encoder.encodeFunc(encoder, chunk)
+		//------------------------------------------------------------
+		if err := encoder.flush(); err != nil {
+			return written, err
+		}
+		//------------------------------------------------------------
+		written += len(chunk)
+		dataBytes = dataBytes[len(chunk):]
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return written, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+// Close flushes any partially encoded data but does not close the underlying writer
+func (encoder *streamEncoder) Close() error {
+	//------------------------------------------------------------
+	if encoder.err != nil || encoder.closed {
+		return encoder.err
+	}
+	//------------------------------------------------------------
+	encoder.closed = true
+	//------------------------------------------------------------
+	if encoder.finishFunc != nil {
+		encoder.finishFunc(encoder)
+	}
+	//------------------------------------------------------------
+	return encoder.flush()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func (encoder *streamEncoder) flush() error {
+	//------------------------------------------------------------
+	if len(encoder.outputBuf) == 0 {
+		return nil
+	}
+	//------------------------------------------------------------
+	_, encoder.err = encoder.writer.Write(encoder.outputBuf)
+	encoder.outputBuf = encoder.outputBuf[:0]
+	//------------------------------------------------------------
+	return encoder.err
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type streamDecoder struct {
+	reader     io.Reader
+	readBuf    []byte
+	outputBuf  []byte
+	outputPos  int
+	eof        bool
+	err        error
+	decodeFunc func(decoder *streamDecoder, dataBytes []byte) error
+	finishFunc func(decoder *streamDecoder) error
+}
+
+//------------------------------------------------------------
+
+func (decoder *streamDecoder) Read(dataBytes []byte) (int, error) {
+	//------------------------------------------------------------
+	for decoder.outputPos >= len(decoder.outputBuf) {
+		//------------------------------------------------------------
+		if decoder.err != nil {
+			return 0, decoder.err
+		}
+		//------------------------------------------------------------
+		decoder.outputBuf = decoder.outputBuf[:0]
+		decoder.outputPos = 0
+		//------------------------------------------------------------
+		if decoder.eof {
+			decoder.err = io.EOF
+			continue
+		}
+		//------------------------------------------------------------
+		readCount, err := decoder.reader.Read(decoder.readBuf)
+		//------------------------------------------------------------
+		if readCount > 0 {
+			if decodeErr := decoder.decodeFunc(decoder, decoder.readBuf[:readCount]); decodeErr != nil {
+				decoder.err = decodeErr
+				continue
+			}
+		}
+		//------------------------------------------------------------
+		if err == io.EOF {
+			//------------------------------------------------------------
+			decoder.eof = true
+			//------------------------------------------------------------
+			if decoder.finishFunc != nil {
+				if finishErr := decoder.finishFunc(decoder); finishErr != nil {
+					decoder.err = finishErr
+				}
+			}
+			//------------------------------------------------------------
+		} else if err != nil {
+			decoder.err = err
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	count := copy(dataBytes, decoder.outputBuf[decoder.outputPos:])
+	decoder.outputPos += count
+	//------------------------------------------------------------
+	return count, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// NewBaseEncoder
+//------------------------------------------------------------
+
+// NewBaseEncoder returns a writer producing the same output as Base_encode
+//
+// Close must be called to flush the final partial group
+func NewBaseEncoder(writer io.Writer) io.WriteCloser {
+	//------------------------------------------------------------
+	var group [4]byte
+	groupLength := 0
+	//------------------------------------------------------------
+	return &streamEncoder{
+		writer: writer,
+		encodeFunc: func(encoder *streamEncoder, dataBytes []byte) {
+			for _, dataByte := range dataBytes {
+				group[groupLength] = dataByte
+				groupLength++
+				if groupLength == 4 {
+					encoder.outputBuf = appendBaseGroup(encoder.outputBuf, group, 4)
+					groupLength = 0
+				}
+			}
+		},
+		finishFunc: func(encoder *streamEncoder) {
+			if groupLength > 0 {
+				for index := groupLength; index < 4; index++ {
+					group[index] = 0
+				}
+				encoder.outputBuf = appendBaseGroup(encoder.outputBuf, group, groupLength)
+				groupLength = 0
+			}
+		},
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NewBaseDecoder
+//------------------------------------------------------------
+
+// NewBaseDecoder returns a reader decoding data produced by Base_encode
+func NewBaseDecoder(reader io.Reader) io.Reader {
+	//------------------------------------------------------------
+	var group [5]int
+	groupLength := 0
+	//------------------------------------------------------------
+	return &streamDecoder{
+		reader:  reader,
+		readBuf: make([]byte, streamBufferSize),
+		decodeFunc: func(decoder *streamDecoder, dataBytes []byte) error {
+			for _, dataByte := range dataBytes {
+				value := baseDecodeMap[dataByte]
+				if value == 0xFF {
+					return fmt.Errorf("data contains one or more invalid characters")
+				}
+				group[groupLength] = int(value)
+				groupLength++
+				if groupLength == 5 {
+					decoder.outputBuf = appendBaseChunk(decoder.outputBuf, group, 5)
+					groupLength = 0
+				}
+			}
+			return nil
+		},
+		finishFunc: func(decoder *streamDecoder) error {
+			if groupLength > 0 {
+				for index := groupLength; index < 5; index++ {
+					group[index] = 84
+				}
+				decoder.outputBuf = appendBaseChunk(decoder.outputBuf, group, groupLength)
+				groupLength = 0
+			}
+			return nil
+		},
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// NewBase64Encoder
+//------------------------------------------------------------
+
+// NewBase64Encoder returns a writer producing the same output as Base64_encode
+//
+// Close must be called to flush the final partial block and padding
+func NewBase64Encoder(writer io.Writer) io.WriteCloser {
+	return base64.NewEncoder(base64.StdEncoding, writer)
+}
+
+//------------------------------------------------------------
+// NewBase64Decoder
+//------------------------------------------------------------
+
+func NewBase64Decoder(reader io.Reader) io.Reader {
+	return base64.NewDecoder(base64.StdEncoding, reader)
+}
+
+//------------------------------------------------------------
+// NewBase64urlEncoder
+//------------------------------------------------------------
+
+// NewBase64urlEncoder returns a writer producing the same output as Base64url_encode
+//
+// Close must be called to flush the final partial block
+func NewBase64urlEncoder(writer io.Writer) io.WriteCloser {
+	return base64.NewEncoder(base64.RawURLEncoding, writer)
+}
+
+//------------------------------------------------------------
+// NewBase64urlDecoder
+//------------------------------------------------------------
+
+// NewBase64urlDecoder returns a reader decoding padded or unpadded base64url data
+func NewBase64urlDecoder(reader io.Reader) io.Reader {
+	//------------------------------------------------------------
+	translateReader := &streamDecoder{
+		reader:  reader,
+		readBuf: make([]byte, streamBufferSize),
+		decodeFunc: func(decoder *streamDecoder, dataBytes []byte) error {
+			for _, dataByte := range dataBytes {
+				switch dataByte {
+				case '-':
+					decoder.outputBuf = append(decoder.outputBuf, '+')
+				case '_':
+					decoder.outputBuf = append(decoder.outputBuf, '/')
+				case '=':
+					continue
+				default:
+					decoder.outputBuf = append(decoder.outputBuf, dataByte)
+				}
+			}
+			return nil
+		},
+	}
+	//------------------------------------------------------------
+	return base64.NewDecoder(base64.RawStdEncoding, translateReader)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// NewBase91Encoder
+//------------------------------------------------------------
+
+// NewBase91Encoder returns a writer producing the same output as Base91_encode
+//
+// Close must be called to flush the remaining bits
+func NewBase91Encoder(writer io.Writer, escapeBool bool) io.WriteCloser {
+	//------------------------------------------------------------
+	var queue, numBits uint
+	//------------------------------------------------------------
+	return &streamEncoder{
+		writer: writer,
+		encodeFunc: func(encoder *streamEncoder, dataBytes []byte) {
+			for _, dataByte := range dataBytes {
+				queue |= uint(dataByte) << numBits
+				numBits += 8
+				if numBits > 13 {
+					value := queue & 8191
+					if value > 88 {
+						queue >>= 13
+						numBits -= 13
+					} else {
+						value = queue & 16383
+						queue >>= 14
+						numBits -= 14
+					}
+					encoder.outputBuf = appendBase91Char(encoder.outputBuf, value%91, escapeBool)
+					encoder.outputBuf = appendBase91Char(encoder.outputBuf, value/91, escapeBool)
+				}
+			}
+		},
+		finishFunc: func(encoder *streamEncoder) {
+			if numBits > 0 {
+				encoder.outputBuf = appendBase91Char(encoder.outputBuf, queue%91, escapeBool)
+				if numBits > 7 || queue > 90 {
+					encoder.outputBuf = appendBase91Char(encoder.outputBuf, queue/91, escapeBool)
+				}
+				queue, numBits = 0, 0
+			}
+		},
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NewBase91Decoder
+//------------------------------------------------------------
+
+// NewBase91Decoder returns a reader decoding data produced by Base91_encode
+func NewBase91Decoder(reader io.Reader, unescapeBool bool) io.Reader {
+	//------------------------------------------------------------
+	var queue, numBits uint
+	value := -1
+	escaped := false
+	inputOffset := 0
+	//------------------------------------------------------------
+	return &streamDecoder{
+		reader:  reader,
+		readBuf: make([]byte, streamBufferSize),
+		decodeFunc: func(decoder *streamDecoder, dataBytes []byte) error {
+			for _, dataByte := range dataBytes {
+				//------------------------------------------------------------
+				inputOffset++
+				//------------------------------------------------------------
+				if unescapeBool {
+					if escaped {
+						escaped = false
+						switch dataByte {
+						case 'q':
+							dataByte = '\x22'
+						case 'd':
+							dataByte = '\x24'
+						case 'g':
+							dataByte = '\x60'
+						default:
+							return fmt.Errorf("illegal base91 data at input byte %d", inputOffset-2)
+						}
+					} else if dataByte == '-' {
+						escaped = true
+						continue
+					}
+				}
+				//------------------------------------------------------------
+				decodedByte := base91DecodeMap[dataByte]
+				if decodedByte == 0xFF {
+					return fmt.Errorf("illegal base91 data at input byte %d", inputOffset-1)
+				}
+				//------------------------------------------------------------
+				if value == -1 {
+					value = int(decodedByte)
+					continue
+				}
+				//------------------------------------------------------------
+				value += int(decodedByte) * 91
+				queue |= uint(value) << numBits
+				//------------------------------------------------------------
+				if (value & 8191) > 88 {
+					numBits += 13
+				} else {
+					numBits += 14
+				}
+				//------------------------------------------------------------
+				for ok := true; ok; ok = (numBits > 7) {
+					decoder.outputBuf = append(decoder.outputBuf, byte(queue))
+					queue >>= 8
+					numBits -= 8
+				}
+				//------------------------------------------------------------
+				value = -1
+				//------------------------------------------------------------
+			}
+			return nil
+		},
+		finishFunc: func(decoder *streamDecoder) error {
+			if escaped {
+				return fmt.Errorf("illegal base91 data at input byte %d", inputOffset-1)
+			}
+			if value != -1 {
+				decoder.outputBuf = append(decoder.outputBuf, byte(queue|uint(value)<<numBits))
+				value = -1
+			}
+			return nil
+		},
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// NewHexEncoder
+//------------------------------------------------------------
+
+// NewHexEncoder returns a writer producing the same upper case output as Hex_encode
+func NewHexEncoder(writer io.Writer) io.WriteCloser {
+	//------------------------------------------------------------
+	return &streamEncoder{
+		writer: writer,
+		encodeFunc: func(encoder *streamEncoder, dataBytes []byte) {
+			for _, dataByte := range dataBytes {
+				encoder.outputBuf = append(encoder.outputBuf, hexUpperCharset[dataByte>>4], hexUpperCharset[dataByte&0x0F])
+			}
+		},
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NewHexDecoder
+//------------------------------------------------------------
+
+// NewHexDecoder returns a reader decoding upper or lower case hex data
+func NewHexDecoder(reader io.Reader) io.Reader {
+	return hex.NewDecoder(reader)
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
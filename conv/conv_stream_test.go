@@ -0,0 +1,169 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type streamTestCase struct {
+	name       string
+	newEncoder func(io.Writer) io.WriteCloser
+	newDecoder func(io.Reader) io.Reader
+	encodeFunc func(string) string
+}
+
+//------------------------------------------------------------
+
+var streamTestCases = []streamTestCase{
+	{"base", NewBaseEncoder, NewBaseDecoder, Base_encode},
+	{"base64", NewBase64Encoder, NewBase64Decoder, Base64_encode},
+	{"base64url", NewBase64urlEncoder, NewBase64urlDecoder, Base64url_encode},
+	{
+		"base91",
+		func(writer io.Writer) io.WriteCloser { return NewBase91Encoder(writer, false) },
+		func(reader io.Reader) io.Reader { return NewBase91Decoder(reader, false) },
+		func(dataString string) string { return Base91_encode(dataString, false) },
+	},
+	{
+		"base91 escaped",
+		func(writer io.Writer) io.WriteCloser { return NewBase91Encoder(writer, true) },
+		func(reader io.Reader) io.Reader { return NewBase91Decoder(reader, true) },
+		func(dataString string) string { return Base91_encode(dataString, true) },
+	},
+	{"hex", NewHexEncoder, NewHexDecoder, Hex_encode},
+}
+
+//------------------------------------------------------------
+// streamTestData
+//------------------------------------------------------------
+
+func streamTestData() [][]byte {
+	//--------------------------------------------------
+	random := rand.New(rand.NewSource(1))
+	//--------------------------------------------------
+	testData := [][]byte{
+		[]byte("A"),
+		[]byte("AA"),
+		[]byte("AAA"),
+		[]byte("AAAA"),
+		[]byte("\x00\x00\x00\x00"),
+		[]byte("ABC\U0001f427"),
+		[]byte("May your trails be crooked, winding, lonesome, dangerous, leading to the most amazing view."),
+	}
+	//--------------------------------------------------
+	for _, length := range []int{1, 7, 255, 4096, 10007} {
+		dataBytes := make([]byte, length)
+		random.Read(dataBytes)
+		testData = append(testData, dataBytes)
+	}
+	//--------------------------------------------------
+	return testData
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NewEncoder
+//------------------------------------------------------------
+
+func TestStreamEncoders(t *testing.T) {
+	//--------------------------------------------------
+	for _, testCase := range streamTestCases {
+		for _, dataBytes := range streamTestData() {
+			for _, chunkSize := range []int{1, 3, 5, 4096} {
+				//--------------------------------------------------
+				var outputBuffer bytes.Buffer
+				//--------------------------------------------------
+				encoder := testCase.newEncoder(&outputBuffer)
+				//--------------------------------------------------
+				for index := 0; index < len(dataBytes); index += chunkSize {
+					end := min(index+chunkSize, len(dataBytes))
+					if _, err := encoder.Write(dataBytes[index:end]); err != nil {
+						t.Fatal(err)
+					}
+				}
+				//--------------------------------------------------
+				if err := encoder.Close(); err != nil {
+					t.Fatal(err)
+				}
+				//--------------------------------------------------
+				expectedString := testCase.encodeFunc(string(dataBytes))
+				//--------------------------------------------------
+				if outputBuffer.String() != expectedString {
+					t.Errorf("(%s, %d bytes, chunk %d) output = %q but should = %q", testCase.name, len(dataBytes), chunkSize, outputBuffer.String(), expectedString)
+				}
+				//--------------------------------------------------
+			}
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NewDecoder
+//------------------------------------------------------------
+
+func TestStreamDecoders(t *testing.T) {
+	//--------------------------------------------------
+	for _, testCase := range streamTestCases {
+		for _, dataBytes := range streamTestData() {
+			//--------------------------------------------------
+			encodedString := testCase.encodeFunc(string(dataBytes))
+			//--------------------------------------------------
+			for _, reader := range []io.Reader{
+				bytes.NewReader([]byte(encodedString)),
+				iotest.OneByteReader(bytes.NewReader([]byte(encodedString))),
+				iotest.DataErrReader(bytes.NewReader([]byte(encodedString))),
+			} {
+				//--------------------------------------------------
+				resultBytes, err := io.ReadAll(testCase.newDecoder(reader))
+				//--------------------------------------------------
+				if err != nil {
+					t.Errorf("(%s, %d bytes) %v", testCase.name, len(dataBytes), err)
+				} else if !bytes.Equal(resultBytes, dataBytes) {
+					t.Errorf("(%s, %d bytes) resultBytes = %q but should = %q", testCase.name, len(dataBytes), resultBytes, dataBytes)
+				}
+				//--------------------------------------------------
+			}
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NewDecoder invalid data
+//------------------------------------------------------------
+
+func TestStreamDecodersInvalid(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		name       string
+		decoder    io.Reader
+		dataString string
+	}{
+		{"base", NewBaseDecoder(bytes.NewReader([]byte("8x_j)~"))), "8x_j)~"},
+		{"base64", NewBase64Decoder(bytes.NewReader([]byte("QUJD?A=="))), "QUJD?A=="},
+		{"base91", NewBase91Decoder(bytes.NewReader([]byte("fG^F-")), false), "fG^F-"},
+		{"base91 escaped", NewBase91Decoder(bytes.NewReader([]byte("fG^F-x")), true), "fG^F-x"},
+		{"hex", NewHexDecoder(bytes.NewReader([]byte("4142ZZ"))), "4142ZZ"},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		if _, err := io.ReadAll(testCase.decoder); err == nil {
+			t.Errorf("(%s) err = nil for %q but should return an error", testCase.name, testCase.dataString)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,669 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package conv
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// XML documents convert to the same map/slice tree JSON_decode yields:
+//
+//	<user id="7"><name>Tim</name><role>a</role><role>b</role><note lang="en">hi</note><empty/></user>
+//
+// becomes
+//
+//	{"user": {"@id": "7", "name": "Tim", "role": ["a", "b"], "note": {"@lang": "en", "#text": "hi"}, "empty": null}}
+//
+//   - attributes are keys with the AttributePrefix ("@" by default)
+//   - an element with only text is a string, otherwise its text is under TextKey ("#text" by default)
+//   - repeated elements become a []any in document order, use ForceArray for elements that may appear once
+//   - empty elements are nil and whitespace between elements is dropped
+//   - namespace prefixes are kept as written, e.g. "soap:Body" and "@xmlns:soap"
+//   - comments, processing instructions and directives are skipped
+//
+// encoding reverses the convention with map keys in sorted order so the output is deterministic
+
+var ErrXMLRoot = errors.New("xml document must have a single root element")
+var ErrXMLName = errors.New("invalid xml name")
+
+//------------------------------------------------------------
+
+type XMLOption func(*XMLOptions)
+
+type XMLOptions struct {
+	// AttributePrefix marks the keys that are attributes
+	AttributePrefix string
+	// TextKey holds the text of elements that also have attributes or children
+	TextKey string
+	// Root wraps the value in this element when encoding and is required and removed when decoding
+	Root string
+	// ForceArray lists element names that always decode to a []any
+	ForceArray []string
+	// InferTypes decodes bool and float64 values instead of strings, as JSON_decode would
+	InferTypes bool
+	// Indent pretty prints the output with this string per level
+	Indent string
+	// Declaration writes an <?xml ...?> declaration before the root element
+	Declaration bool
+}
+
+var DefaultXMLOptions = XMLOptions{
+	AttributePrefix: "@",
+	TextKey:         "#text",
+}
+
+//------------------------------------------------------------
+
+func WithXMLAttributePrefix(attributePrefix string) XMLOption {
+	return func(options *XMLOptions) { options.AttributePrefix = attributePrefix }
+}
+
+func WithXMLTextKey(textKey string) XMLOption {
+	return func(options *XMLOptions) { options.TextKey = textKey }
+}
+
+func WithXMLRoot(root string) XMLOption {
+	return func(options *XMLOptions) { options.Root = root }
+}
+
+func WithXMLForceArray(names ...string) XMLOption {
+	return func(options *XMLOptions) { options.ForceArray = names }
+}
+
+func WithXMLInferTypes(inferTypes bool) XMLOption {
+	return func(options *XMLOptions) { options.InferTypes = inferTypes }
+}
+
+func WithXMLIndent(indent string) XMLOption {
+	return func(options *XMLOptions) { options.Indent = indent }
+}
+
+func WithXMLDeclaration(declaration bool) XMLOption {
+	return func(options *XMLOptions) { options.Declaration = declaration }
+}
+
+//------------------------------------------------------------
+
+func NewXMLOptions(options ...XMLOption) XMLOptions {
+	xmlOptions := DefaultXMLOptions
+	for _, optionFunc := range options {
+		optionFunc(&xmlOptions)
+	}
+	return xmlOptions
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// XML_decode
+//------------------------------------------------------------
+
+// XML_decode converts an XML document into a map holding the root element
+func XML_decode(xmlString string, options ...XMLOption) (any, error) {
+	//------------------------------------------------------------
+	xmlOptions := NewXMLOptions(options...)
+	//------------------------------------------------------------
+	decoder := xml.NewDecoder(strings.NewReader(xmlString))
+	//------------------------------------------------------------
+	var rootName string
+	var rootValue any
+	//------------------------------------------------------------
+	for {
+		//------------------------------------------------------------
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		//------------------------------------------------------------
+		switch typedToken := token.(type) {
+		case xml.StartElement:
+			//------------------------------------------------------------
+			if rootName != "" {
+				return nil, ErrXMLRoot
+			}
+			//------------------------------------------------------------
+			rootName = xmlName(typedToken.Name)
+			//------------------------------------------------------------
+			if rootValue, err = xmlDecodeElement(decoder, typedToken, xmlOptions); err != nil {
+				return nil, err
+			}
+			//------------------------------------------------------------
+		case xml.CharData:
+			if len(strings.TrimSpace(string(typedToken))) > 0 {
+				return nil, fmt.Errorf("xml text outside the root element at offset %d", decoder.InputOffset())
+			}
+		case xml.EndElement:
+			return nil, fmt.Errorf("xml unexpected end element </%s>", xmlName(typedToken.Name))
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if rootName == "" {
+		return nil, ErrXMLRoot
+	}
+	//------------------------------------------------------------
+	if xmlOptions.Root != "" {
+		if rootName != xmlOptions.Root {
+			return nil, fmt.Errorf("xml root element is <%s> but should be <%s>", rootName, xmlOptions.Root)
+		}
+		return rootValue, nil
+	}
+	//------------------------------------------------------------
+	return map[string]any{rootName: rootValue}, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// xmlDecodeElement
+//------------------------------------------------------------
+
+func xmlDecodeElement(decoder *xml.Decoder, startElement xml.StartElement, options XMLOptions) (any, error) {
+	//------------------------------------------------------------
+	elementMap := map[string]any{}
+	//------------------------------------------------------------
+	for _, attr := range startElement.Attr {
+		elementMap[options.AttributePrefix+xmlName(attr.Name)] = xmlParseValue(attr.Value, options)
+	}
+	//------------------------------------------------------------
+	var textBuilder strings.Builder
+	//------------------------------------------------------------
+	hasChildren := false
+	//------------------------------------------------------------
+	for {
+		//------------------------------------------------------------
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			return nil, fmt.Errorf("xml element <%s> is not closed", xmlName(startElement.Name))
+		}
+		if err != nil {
+			return nil, err
+		}
+		//------------------------------------------------------------
+		switch typedToken := token.(type) {
+		case xml.StartElement:
+			//------------------------------------------------------------
+			childValue, err := xmlDecodeElement(decoder, typedToken, options)
+			if err != nil {
+				return nil, err
+			}
+			//------------------------------------------------------------
+			hasChildren = true
+			//------------------------------------------------------------
+			xmlAddChild(elementMap, xmlName(typedToken.Name), childValue, options)
+			//------------------------------------------------------------
+		case xml.CharData:
+			textBuilder.Write(typedToken)
+		case xml.EndElement:
+			//------------------------------------------------------------
+			// RawToken keeps the namespace prefixes but does not check that the tags match
+			if xmlName(typedToken.Name) != xmlName(startElement.Name) {
+				return nil, fmt.Errorf("xml element <%s> closed by </%s>", xmlName(startElement.Name), xmlName(typedToken.Name))
+			}
+			//------------------------------------------------------------
+			text := textBuilder.String()
+			if hasChildren || strings.TrimSpace(text) == "" {
+				text = strings.TrimSpace(text)
+			}
+			//------------------------------------------------------------
+			if len(elementMap) == 0 {
+				if text == "" {
+					return nil, nil
+				}
+				return xmlParseValue(text, options), nil
+			}
+			//------------------------------------------------------------
+			if text != "" {
+				elementMap[options.TextKey] = xmlParseValue(text, options)
+			}
+			//------------------------------------------------------------
+			return elementMap, nil
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// xmlAddChild
+//------------------------------------------------------------
+
+func xmlAddChild(elementMap map[string]any, name string, value any, options XMLOptions) {
+	//------------------------------------------------------------
+	existingValue, exists := elementMap[name]
+	//------------------------------------------------------------
+	if exists {
+		if existingSlice, ok := existingValue.([]any); ok {
+			elementMap[name] = append(existingSlice, value)
+		} else {
+			elementMap[name] = []any{existingValue, value}
+		}
+		return
+	}
+	//------------------------------------------------------------
+	for _, forceName := range options.ForceArray {
+		if forceName == name {
+			elementMap[name] = []any{value}
+			return
+		}
+	}
+	//------------------------------------------------------------
+	elementMap[name] = value
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// xmlName
+//------------------------------------------------------------
+
+func xmlName(name xml.Name) string {
+	//------------------------------------------------------------
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+	//------------------------------------------------------------
+	return name.Local
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// xmlParseValue
+//------------------------------------------------------------
+
+func xmlParseValue(text string, options XMLOptions) any {
+	//------------------------------------------------------------
+	if !options.InferTypes {
+		return text
+	}
+	//------------------------------------------------------------
+	switch text {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	//------------------------------------------------------------
+	// numbers with leading zeros such as codes are left as strings
+	digits := strings.TrimPrefix(text, "-")
+	leadingZero := len(digits) > 1 && digits[0] == '0' && digits[1] != '.'
+	//------------------------------------------------------------
+	if digits != "" && digits[0] >= '0' && digits[0] <= '9' && !leadingZero && strings.Trim(digits, "0123456789.eE+-") == "" {
+		if floatValue, err := strconv.ParseFloat(text, 64); err == nil {
+			return floatValue
+		}
+	}
+	//------------------------------------------------------------
+	return text
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// XML_encode
+//------------------------------------------------------------
+
+// XML_encode converts a map holding a single root element into XML
+func XML_encode(value any, options ...XMLOption) (string, error) {
+	//------------------------------------------------------------
+	xmlOptions := NewXMLOptions(options...)
+	//------------------------------------------------------------
+	if xmlOptions.Root != "" {
+		value = map[string]any{xmlOptions.Root: value}
+	}
+	//------------------------------------------------------------
+	rootMap, ok := xmlNormalizeValue(value).(map[string]any)
+	if !ok || len(rootMap) != 1 {
+		return "", ErrXMLRoot
+	}
+	//------------------------------------------------------------
+	encoder := xmlEncoder{options: xmlOptions}
+	//------------------------------------------------------------
+	if xmlOptions.Declaration {
+		encoder.builder.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	}
+	//------------------------------------------------------------
+	for name, rootValue := range rootMap {
+		//------------------------------------------------------------
+		if _, isSlice := xmlNormalizeValue(rootValue).([]any); isSlice {
+			return "", ErrXMLRoot
+		}
+		//------------------------------------------------------------
+		if err := encoder.writeElement(name, rootValue, 0); err != nil {
+			return "", err
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return encoder.builder.String(), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+type xmlEncoder struct {
+	builder strings.Builder
+	options XMLOptions
+}
+
+//------------------------------------------------------------
+// writeElement
+//------------------------------------------------------------
+
+func (encoder *xmlEncoder) writeElement(name string, value any, depth int) error {
+	//------------------------------------------------------------
+	if !xmlValidName(name) {
+		return fmt.Errorf("%w: %q", ErrXMLName, name)
+	}
+	//------------------------------------------------------------
+	value = xmlNormalizeValue(value)
+	//------------------------------------------------------------
+	switch typedValue := value.(type) {
+	case []any:
+		//------------------------------------------------------------
+		for _, item := range typedValue {
+			if _, isSlice := xmlNormalizeValue(item).([]any); isSlice {
+				return fmt.Errorf("xml element <%s> cannot hold nested arrays", name)
+			}
+			if err := encoder.writeElement(name, item, depth); err != nil {
+				return err
+			}
+		}
+		//------------------------------------------------------------
+		return nil
+		//------------------------------------------------------------
+	case map[string]any:
+		return encoder.writeMapElement(name, typedValue, depth)
+	}
+	//------------------------------------------------------------
+	encoder.writeIndent(depth)
+	//------------------------------------------------------------
+	if value == nil {
+		encoder.builder.WriteString("<" + name + "/>")
+		return nil
+	}
+	//------------------------------------------------------------
+	textString, err := xmlFormatValue(value)
+	if err != nil {
+		return fmt.Errorf("xml element <%s>: %w", name, err)
+	}
+	//------------------------------------------------------------
+	encoder.builder.WriteString("<" + name + ">")
+	xml.EscapeText(&encoder.builder, []byte(textString))
+	encoder.builder.WriteString("</" + name + ">")
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// writeMapElement
+//------------------------------------------------------------
+
+func (encoder *xmlEncoder) writeMapElement(name string, elementMap map[string]any, depth int) error {
+	//------------------------------------------------------------
+	var attrKeys, childKeys []string
+	//------------------------------------------------------------
+	textValue, hasText := elementMap[encoder.options.TextKey]
+	textValue = xmlNormalizeValue(textValue)
+	//------------------------------------------------------------
+	for key := range elementMap {
+		if key == encoder.options.TextKey {
+			continue
+		}
+		if encoder.options.AttributePrefix != "" && strings.HasPrefix(key, encoder.options.AttributePrefix) {
+			attrKeys = append(attrKeys, key)
+		} else {
+			childKeys = append(childKeys, key)
+		}
+	}
+	//------------------------------------------------------------
+	sort.Strings(attrKeys)
+	sort.Strings(childKeys)
+	//------------------------------------------------------------
+	encoder.writeIndent(depth)
+	//------------------------------------------------------------
+	encoder.builder.WriteString("<" + name)
+	//------------------------------------------------------------
+	for _, key := range attrKeys {
+		//------------------------------------------------------------
+		attrName := strings.TrimPrefix(key, encoder.options.AttributePrefix)
+		//------------------------------------------------------------
+		if !xmlValidName(attrName) {
+			return fmt.Errorf("%w: %q", ErrXMLName, attrName)
+		}
+		//------------------------------------------------------------
+		attrValue := xmlNormalizeValue(elementMap[key])
+		//------------------------------------------------------------
+		switch attrValue.(type) {
+		case map[string]any, []any:
+			return fmt.Errorf("xml attribute %q of <%s> must be a scalar value", attrName, name)
+		}
+		//------------------------------------------------------------
+		var attrString string
+		//------------------------------------------------------------
+		if attrValue != nil {
+			//------------------------------------------------------------
+			var err error
+			//------------------------------------------------------------
+			if attrString, err = xmlFormatValue(attrValue); err != nil {
+				return fmt.Errorf("xml attribute %q of <%s>: %w", attrName, name, err)
+			}
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+		encoder.builder.WriteString(" " + attrName + `="`)
+		xml.EscapeText(&encoder.builder, []byte(attrString))
+		encoder.builder.WriteString(`"`)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if len(childKeys) == 0 && (!hasText || textValue == nil) {
+		encoder.builder.WriteString("/>")
+		return nil
+	}
+	//------------------------------------------------------------
+	encoder.builder.WriteString(">")
+	//------------------------------------------------------------
+	if hasText && textValue != nil {
+		switch textValue.(type) {
+		case map[string]any, []any:
+			return fmt.Errorf("xml text of <%s> must be a scalar value", name)
+		}
+		textString, err := xmlFormatValue(textValue)
+		if err != nil {
+			return fmt.Errorf("xml text of <%s>: %w", name, err)
+		}
+		xml.EscapeText(&encoder.builder, []byte(textString))
+	}
+	//------------------------------------------------------------
+	for _, key := range childKeys {
+		if err := encoder.writeElement(key, elementMap[key], depth+1); err != nil {
+			return err
+		}
+	}
+	//------------------------------------------------------------
+	if len(childKeys) > 0 {
+		encoder.writeIndent(depth)
+	}
+	//------------------------------------------------------------
+	encoder.builder.WriteString("</" + name + ">")
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// writeIndent
+//------------------------------------------------------------
+
+func (encoder *xmlEncoder) writeIndent(depth int) {
+	//------------------------------------------------------------
+	if encoder.options.Indent != "" && encoder.builder.Len() > 0 {
+		encoder.builder.WriteString("\n" + strings.Repeat(encoder.options.Indent, depth))
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// xmlNormalizeValue
+//------------------------------------------------------------
+
+// xmlNormalizeValue converts other slice and string keyed map types (e.g. []map[string]any or
+// map[string]string) to []any and map[string]any and follows pointers, other values are returned as they are
+func xmlNormalizeValue(value any) any {
+	//------------------------------------------------------------
+	switch value.(type) {
+	case nil, []any, map[string]any, []byte:
+		return value
+	}
+	//------------------------------------------------------------
+	reflectValue := reflect.ValueOf(value)
+	//------------------------------------------------------------
+	switch reflectValue.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		//------------------------------------------------------------
+		if reflectValue.IsNil() {
+			return nil
+		}
+		//------------------------------------------------------------
+		return xmlNormalizeValue(reflectValue.Elem().Interface())
+		//------------------------------------------------------------
+	case reflect.Slice, reflect.Array:
+		//------------------------------------------------------------
+		if reflectValue.Kind() == reflect.Slice && reflectValue.IsNil() {
+			return nil
+		}
+		//------------------------------------------------------------
+		sliceValue := make([]any, reflectValue.Len())
+		for index := range sliceValue {
+			sliceValue[index] = reflectValue.Index(index).Interface()
+		}
+		//------------------------------------------------------------
+		return sliceValue
+		//------------------------------------------------------------
+	case reflect.Map:
+		//------------------------------------------------------------
+		if reflectValue.Type().Key().Kind() != reflect.String {
+			return value
+		}
+		//------------------------------------------------------------
+		if reflectValue.IsNil() {
+			return nil
+		}
+		//------------------------------------------------------------
+		mapValue := make(map[string]any, reflectValue.Len())
+		iterator := reflectValue.MapRange()
+		for iterator.Next() {
+			mapValue[iterator.Key().String()] = iterator.Value().Interface()
+		}
+		//------------------------------------------------------------
+		return mapValue
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return value
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// xmlFormatValue
+//------------------------------------------------------------
+
+// xmlFormatValue returns the text of a scalar value, other types are an error rather than Go syntax
+func xmlFormatValue(value any) (string, error) {
+	//------------------------------------------------------------
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue, nil
+	case []byte:
+		return string(typedValue), nil
+	case time.Time:
+		return typedValue.Format(time.RFC3339Nano), nil
+	case json.Number:
+		return typedValue.String(), nil
+	case encoding.TextMarshaler:
+		textBytes, err := typedValue.MarshalText()
+		return string(textBytes), err
+	}
+	//------------------------------------------------------------
+	reflectValue := reflect.ValueOf(value)
+	//------------------------------------------------------------
+	switch reflectValue.Kind() {
+	case reflect.String:
+		return reflectValue.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(reflectValue.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(reflectValue.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(reflectValue.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(reflectValue.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(reflectValue.Float(), 'f', -1, 64), nil
+	}
+	//------------------------------------------------------------
+	return "", fmt.Errorf("unsupported value type %T", value)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// xmlValidName
+//------------------------------------------------------------
+
+func xmlValidName(name string) bool {
+	//------------------------------------------------------------
+	if name == "" {
+		return false
+	}
+	//------------------------------------------------------------
+	for index, char := range name {
+		//------------------------------------------------------------
+		switch {
+		case char == '_' || char == ':' || char >= 'A' && char <= 'Z' || char >= 'a' && char <= 'z' || char >= 0x80:
+		case index > 0 && (char == '-' || char == '.' || char >= '0' && char <= '9'):
+		default:
+			return false
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	// names starting with "xml" are reserved apart from the xmlns namespace declarations
+	return !strings.HasPrefix(strings.ToLower(name), "xml") || name == "xmlns" || strings.HasPrefix(name, "xmlns:") || strings.HasPrefix(name, "xml:")
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
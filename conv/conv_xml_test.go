@@ -0,0 +1,195 @@
+//------------------------------------------------------------
+
+package conv
+
+import (
+	"errors"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// XML_decode
+//------------------------------------------------------------
+
+func TestXML_decode(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		xmlString string
+		options   []XMLOption
+		expected  any
+	}{
+		{
+			`<?xml version="1.0"?><!-- comment --><user id="7"><name>Tim</name><role>a</role><role>b</role><note lang="en">hi</note><empty/></user>`,
+			nil,
+			map[string]any{"user": map[string]any{"@id": "7", "name": "Tim", "role": []any{"a", "b"}, "note": map[string]any{"@lang": "en", "#text": "hi"}, "empty": nil}},
+		},
+		{
+			"<a>\n  <b> x &amp; y </b>\n  <c><![CDATA[<raw>]]></c>\n</a>",
+			nil,
+			map[string]any{"a": map[string]any{"b": " x & y ", "c": "<raw>"}},
+		},
+		{
+			`<a>text<b/>more</a>`,
+			nil,
+			map[string]any{"a": map[string]any{"b": nil, "#text": "textmore"}},
+		},
+		{
+			`<soap:Envelope xmlns:soap="urn:x"><soap:Body>1</soap:Body></soap:Envelope>`,
+			nil,
+			map[string]any{"soap:Envelope": map[string]any{"@xmlns:soap": "urn:x", "soap:Body": "1"}},
+		},
+		{
+			`<a count="3"><item>1.5</item><flag>true</flag><code>007</code></a>`,
+			[]XMLOption{WithXMLInferTypes(true), WithXMLForceArray("item")},
+			map[string]any{"a": map[string]any{"@count": 3.0, "item": []any{1.5}, "flag": true, "code": "007"}},
+		},
+		{
+			`<a x="1">v</a>`,
+			[]XMLOption{WithXMLAttributePrefix("-"), WithXMLTextKey("_"), WithXMLRoot("a")},
+			map[string]any{"-x": "1", "_": "v"},
+		},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		result, err := XML_decode(testCase.xmlString, testCase.options...)
+		//--------------------------------------------------
+		if err != nil {
+			t.Errorf("(%q) returned error = %v", testCase.xmlString, err)
+		} else if !JSON_Equal(result, testCase.expected) {
+			t.Errorf("(%q) = %#v but should = %#v", testCase.xmlString, result, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	for _, invalidString := range []string{"", "text", "<a>", "<a></b>", "<a/><b/>", "<a/>text", "</a>", "<a><b></a></b>"} {
+		if _, err := XML_decode(invalidString); err == nil {
+			t.Errorf("(%q) should return an error", invalidString)
+		}
+	}
+	//--------------------------------------------------
+	if _, err := XML_decode("<a/>", WithXMLRoot("b")); err == nil {
+		t.Errorf("mismatched root should return an error")
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+// XML_encode
+//------------------------------------------------------------
+
+type xmlTestString string
+
+var xmlTestName = "Tim"
+
+func TestXML_encode(t *testing.T) {
+	//--------------------------------------------------
+	testCases := []struct {
+		value    any
+		options  []XMLOption
+		expected string
+	}{
+		{
+			map[string]any{"user": map[string]any{"@id": 7, "name": "Tim", "role": []any{"a", "b"}, "note": map[string]any{"@lang": "en", "#text": "hi"}, "empty": nil}},
+			nil,
+			`<user id="7"><empty/><name>Tim</name><note lang="en">hi</note><role>a</role><role>b</role></user>`,
+		},
+		{
+			map[string]any{"a": map[string]any{"b": "x & <y>", "@q": `"`, "c": 1.5, "d": true}},
+			nil,
+			`<a q="&#34;"><b>x &amp; &lt;y&gt;</b><c>1.5</c><d>true</d></a>`,
+		},
+		{
+			map[string]any{"a": map[string]any{"b": map[string]any{"c": "1"}, "d": []any{"2", "3"}}},
+			[]XMLOption{WithXMLIndent("  "), WithXMLDeclaration(true)},
+			"<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<a>\n  <b>\n    <c>1</c>\n  </b>\n  <d>2</d>\n  <d>3</d>\n</a>",
+		},
+		{
+			map[string]any{"method": "echo", "params": []any{1.0, 2.0}},
+			[]XMLOption{WithXMLRoot("request")},
+			`<request><method>echo</method><params>1</params><params>2</params></request>`,
+		},
+		{
+			// other slice, map and pointer types are walked rather than printed as Go syntax
+			map[string]any{"rows": map[string]any{"row": []map[string]any{{"id": 1}, {"id": 2}}}},
+			nil,
+			`<rows><row><id>1</id></row><row><id>2</id></row></rows>`,
+		},
+		{
+			map[string]map[string]string{"r": {"a": "b", "@c": "d"}},
+			nil,
+			`<r c="d"><a>b</a></r>`,
+		},
+		{
+			map[string]any{"r": map[string]any{"n": []int{1, 2}, "p": &xmlTestName, "q": (*string)(nil), "s": xmlTestString("x")}},
+			nil,
+			`<r><n>1</n><n>2</n><p>Tim</p><q/><s>x</s></r>`,
+		},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------------------------------------
+		result, err := XML_encode(testCase.value, testCase.options...)
+		//--------------------------------------------------
+		if err != nil {
+			t.Errorf("(%v) returned error = %v", testCase.value, err)
+		} else if result != testCase.expected {
+			t.Errorf("(%v) = %q but should = %q", testCase.value, result, testCase.expected)
+		}
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	for _, invalidValue := range []any{
+		"text",
+		map[string]any{},
+		map[string]any{"a": 1, "b": 2},
+		map[string]any{"a": []any{1, 2}},
+		map[string]any{"1a": 1},
+		map[string]any{"a": map[string]any{"b c": 1}},
+		map[string]any{"a": map[string]any{"@x": []any{1}}},
+		map[string]any{"a": map[string]any{"b": []any{[]any{1}}}},
+		map[string]any{"a": map[string]any{"b": [][]int{{1}}}},
+		map[string]any{"a": struct{ B int }{1}},
+		map[string]any{"a": map[string]any{"@x": map[string]string{"y": "z"}}},
+		map[string]any{"a": map[int]string{1: "b"}},
+	} {
+		if _, err := XML_encode(invalidValue); err == nil {
+			t.Errorf("(%v) should return an error", invalidValue)
+		}
+	}
+	//--------------------------------------------------
+	if _, err := XML_encode(map[string]any{"a b": 1}); !errors.Is(err, ErrXMLName) {
+		t.Errorf("error = %v but should = %v", err, ErrXMLName)
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+
+func TestXML_RoundTrip(t *testing.T) {
+	//--------------------------------------------------
+	xmlString := `<order id="12" xmlns:x="urn:x"><item sku="a"><qty>2</qty></item><item sku="b"><qty>1</qty></item><note>line 1` + "&#xA;" + `line 2</note><x:flag>true</x:flag></order>`
+	//--------------------------------------------------
+	document, err := XML_decode(xmlString)
+	if err != nil {
+		t.Fatalf("XML_decode returned error = %v", err)
+	}
+	//--------------------------------------------------
+	result, err := XML_encode(document)
+	if err != nil {
+		t.Fatalf("XML_encode returned error = %v", err)
+	}
+	//--------------------------------------------------
+	if result != xmlString {
+		t.Errorf("result = %q but should = %q", result, xmlString)
+	}
+	//--------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
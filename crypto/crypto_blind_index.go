@@ -0,0 +1,152 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// a blind index is a keyed HMAC-SHA256 of a value stored in its own column beside the encrypted value,
+// which can then use the normal random encryption (Seal, Keyring.Encrypt):
+//
+//	CREATE TABLE users(id INTEGER PRIMARY KEY, email TEXT, email_index TEXT)
+//	CREATE INDEX users_email_index ON users(email_index)
+//
+//	index, _ := crypto.BlindIndex(email, indexKey, crypto.NormalizeEmail)
+//	conn.QueryRecords("SELECT id, email FROM users WHERE email_index = ?", index)
+//
+// the index key must be separate from the encryption key, e.g. DeriveSubkey(masterKey, "users.email index"),
+// a shorter Length gives deliberate collisions so an index matches a few rows (which are then decrypted
+// and compared) and reveals less about which rows share a value
+
+const BLIND_INDEX_SIZE = 16
+
+var ErrBlindIndexKey = errors.New("blind index key must be at least 32 bytes")
+
+//------------------------------------------------------------
+
+// BlindIndexNormalizer prepares a value so that equal values give equal indexes
+type BlindIndexNormalizer func(string) string
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// BlindIndexBytes
+//------------------------------------------------------------
+
+// BlindIndexBytes returns the first length bytes (1 to 32) of HMAC-SHA256(key, dataBytes)
+func BlindIndexBytes(dataBytes []byte, key []byte, length int) ([]byte, error) {
+	//------------------------------------------------------------
+	if len(key) < 32 {
+		return nil, ErrBlindIndexKey
+	}
+	//------------------------------------------------------------
+	if length < 1 || length > sha256.Size {
+		return nil, errors.New("blind index length must be 1 to 32 bytes")
+	}
+	//------------------------------------------------------------
+	mac := hmac.New(sha256.New, key)
+	mac.Write(dataBytes)
+	//------------------------------------------------------------
+	return mac.Sum(nil)[:length], nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// BlindIndex
+//------------------------------------------------------------
+
+// BlindIndex returns the unpadded base64url blind index of dataString (BLIND_INDEX_SIZE bytes),
+// Normalizers are applied in order first, e.g. NormalizeEmail
+func BlindIndex(dataString string, key []byte, Normalizers ...BlindIndexNormalizer) (string, error) {
+	//------------------------------------------------------------
+	return BlindIndexLength(dataString, key, BLIND_INDEX_SIZE, Normalizers...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// BlindIndexLength
+//------------------------------------------------------------
+
+// BlindIndexLength is BlindIndex with a chosen length in bytes
+func BlindIndexLength(dataString string, key []byte, length int, Normalizers ...BlindIndexNormalizer) (string, error) {
+	//------------------------------------------------------------
+	for _, normalizer := range Normalizers {
+		dataString = normalizer(dataString)
+	}
+	//------------------------------------------------------------
+	indexBytes, err := BlindIndexBytes([]byte(dataString), key, length)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return base64.RawURLEncoding.EncodeToString(indexBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// VerifyBlindIndex
+//------------------------------------------------------------
+
+// VerifyBlindIndex reports whether indexString is the blind index of dataString, the comparison is constant time
+func VerifyBlindIndex(dataString string, indexString string, key []byte, Normalizers ...BlindIndexNormalizer) bool {
+	//------------------------------------------------------------
+	indexBytes, err := base64.RawURLEncoding.DecodeString(indexString)
+	if err != nil || len(indexBytes) < 1 || len(indexBytes) > sha256.Size {
+		return false
+	}
+	//------------------------------------------------------------
+	expectedString, err := BlindIndexLength(dataString, key, len(indexBytes), Normalizers...)
+	if err != nil {
+		return false
+	}
+	//------------------------------------------------------------
+	return hmac.Equal([]byte(expectedString), []byte(indexString))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// NormalizeEmail
+//------------------------------------------------------------
+
+// NormalizeEmail trims spaces and lower cases the whole address
+func NormalizeEmail(dataString string) string {
+	//------------------------------------------------------------
+	return strings.ToLower(strings.TrimSpace(dataString))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NormalizeIdentifier
+//------------------------------------------------------------
+
+// NormalizeIdentifier upper cases and removes spaces, dashes and dots, e.g. for national ID or account numbers
+func NormalizeIdentifier(dataString string) string {
+	//------------------------------------------------------------
+	return strings.NewReplacer(" ", "", "-", "", ".", "").Replace(strings.ToUpper(strings.TrimSpace(dataString)))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
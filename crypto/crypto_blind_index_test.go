@@ -0,0 +1,113 @@
+//------------------------------------------------------------
+
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/timbrockley/golang-main/database/sqldb"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//--------------------------------------------------
+// BlindIndexBytes
+//--------------------------------------------------
+
+func TestBlindIndexBytes(t *testing.T) {
+	//------------------------------------------------------------
+	key := []byte("0123456789abcdef0123456789abcdef")
+	//------------------------------------------------------------
+	indexBytes1, err := BlindIndexBytes([]byte("value"), key, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexBytes2, _ := BlindIndexBytes([]byte("value"), key, 8)
+	//------------------------------------------------------------
+	if len(indexBytes1) != 32 || !bytes.Equal(indexBytes1[:8], indexBytes2) {
+		t.Error("shorter indexes should be a prefix of the full HMAC")
+	}
+	//------------------------------------------------------------
+	if _, err := BlindIndexBytes([]byte("value"), key[:16], 16); !errors.Is(err, ErrBlindIndexKey) {
+		t.Errorf("err = %v but should = %v", err, ErrBlindIndexKey)
+	}
+	//------------------------------------------------------------
+	for _, length := range []int{0, 33} {
+		if _, err := BlindIndexBytes([]byte("value"), key, length); err == nil {
+			t.Errorf("length %d should be rejected", length)
+		}
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+// BlindIndex
+//--------------------------------------------------
+
+func TestBlindIndex(t *testing.T) {
+	//------------------------------------------------------------
+	masterKey, _ := GenerateKey()
+	indexKey, _ := DeriveSubkey(masterKey, "users.email index")
+	otherKey, _ := DeriveSubkey(masterKey, "users.phone index")
+	//------------------------------------------------------------
+	index1, err := BlindIndex("Someone@Example.com ", indexKey, NormalizeEmail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	index2, _ := BlindIndex("someone@example.com", indexKey, NormalizeEmail)
+	index3, _ := BlindIndex("someone@example.com", otherKey, NormalizeEmail)
+	//------------------------------------------------------------
+	if index1 != index2 || index1 == index3 || len(index1) != 22 {
+		t.Errorf("index1 = %q should be 22 characters, match after normalizing and depend on the key", index1)
+	}
+	//------------------------------------------------------------
+	if !VerifyBlindIndex("SOMEONE@example.com", index1, indexKey, NormalizeEmail) || VerifyBlindIndex("other@example.com", index1, indexKey, NormalizeEmail) {
+		t.Error("VerifyBlindIndex should only match the same normalized value")
+	}
+	//------------------------------------------------------------
+	if index, _ := BlindIndexLength("AB 12-34.56 c", indexKey, 2, NormalizeIdentifier); !VerifyBlindIndex("ab123456C", index, indexKey, NormalizeIdentifier) || len(index) != 3 {
+		t.Errorf("index = %q should be a 2 byte index of the normalized identifier", index)
+	}
+	//------------------------------------------------------------
+	// equality lookups through a blind index column beside randomly encrypted values
+	conn, err := sqldb.Connect(sqldb.SQLdb{Database: ":memory:"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	//------------------------------------------------------------
+	if _, err = conn.Exec("CREATE TABLE users(id INTEGER PRIMARY KEY, email TEXT, email_index TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	for id, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		//------------------------------------------------------------
+		emailString, _ := Seal(email, masterKey, WithAAD([]byte("users.email")))
+		emailIndex, _ := BlindIndex(email, indexKey, NormalizeEmail)
+		//------------------------------------------------------------
+		if _, err = conn.Exec("INSERT INTO users(id, email, email_index) VALUES(?, ?, ?)", id+1, emailString, emailIndex); err != nil {
+			t.Fatal(err)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	emailIndex, _ := BlindIndex(" B@Example.com", indexKey, NormalizeEmail)
+	records, err := conn.QueryRecords("SELECT id, email FROM users WHERE email_index = ?", emailIndex)
+	//------------------------------------------------------------
+	if err != nil || len(records) != 1 {
+		t.Fatalf("records = %v, err = %v but should find one row", records, err)
+	}
+	//------------------------------------------------------------
+	if email, err := Open(records[0]["email"].(string), masterKey, WithAAD([]byte("users.email"))); err != nil || email != "b@example.com" {
+		t.Errorf("email = %q, err = %v but should = %q", email, err, "b@example.com")
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,192 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// a sealed box is encrypted to a recipient's X25519 public key without identifying the sender:
+//
+//	version (1 byte) | ephemeral public key (32 bytes) | envelope
+//
+// the envelope key is HKDF-SHA256 of the X25519 shared secret with the ephemeral and recipient
+// public keys as the salt, a new ephemeral key is made for every box so only the recipient can open it
+//
+// the text form is BOX_PREFIX followed by the unpadded base64url box
+
+const BOX_VERSION = 1
+
+const BOX_PREFIX = "box:"
+
+const boxInfo = "crypto sealed box v1"
+
+var ErrBoxInvalid = errors.New("invalid sealed box")
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// GenerateBoxKey
+//------------------------------------------------------------
+
+// GenerateBoxKey returns a new X25519 key pair for receiving sealed boxes
+func GenerateBoxKey() (*ecdh.PublicKey, *ecdh.PrivateKey, error) {
+	//------------------------------------------------------------
+	privateKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	//------------------------------------------------------------
+	return privateKey.PublicKey(), privateKey, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// SealBoxBytes
+//------------------------------------------------------------
+
+// SealBoxBytes encrypts dataBytes to publicKey, WithAlgorithm and WithAAD are used for the envelope
+func SealBoxBytes(dataBytes []byte, publicKey *ecdh.PublicKey, options ...SealOption) ([]byte, error) {
+	//------------------------------------------------------------
+	if publicKey == nil || publicKey.Curve() != ecdh.X25519() {
+		return nil, errors.New("public key must be X25519")
+	}
+	//------------------------------------------------------------
+	ephemeralKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	keyBytes, err := boxKey(ephemeralKey, publicKey, ephemeralKey.PublicKey(), publicKey)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	envelopeBytes, err := SealBytes(dataBytes, keyBytes, options...)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	boxBytes := append([]byte{BOX_VERSION}, ephemeralKey.PublicKey().Bytes()...)
+	//------------------------------------------------------------
+	return append(boxBytes, envelopeBytes...), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// OpenBoxBytes
+//------------------------------------------------------------
+
+// OpenBoxBytes decrypts a box from SealBoxBytes with the recipient's private key
+func OpenBoxBytes(boxBytes []byte, privateKey *ecdh.PrivateKey, options ...SealOption) ([]byte, error) {
+	//------------------------------------------------------------
+	if privateKey == nil || privateKey.Curve() != ecdh.X25519() {
+		return nil, errors.New("private key must be X25519")
+	}
+	//------------------------------------------------------------
+	if len(boxBytes) < 33 || boxBytes[0] != BOX_VERSION {
+		return nil, ErrBoxInvalid
+	}
+	//------------------------------------------------------------
+	ephemeralPublicKey, err := ecdh.X25519().NewPublicKey(boxBytes[1:33])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBoxInvalid, err)
+	}
+	//------------------------------------------------------------
+	keyBytes, err := boxKey(privateKey, ephemeralPublicKey, ephemeralPublicKey, privateKey.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return OpenBytes(boxBytes[33:], keyBytes, options...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// SealBox
+//------------------------------------------------------------
+
+// SealBox encrypts dataString to publicKey and returns the text form
+func SealBox(dataString string, publicKey *ecdh.PublicKey, options ...SealOption) (string, error) {
+	//------------------------------------------------------------
+	boxBytes, err := SealBoxBytes([]byte(dataString), publicKey, options...)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return BOX_PREFIX + base64.RawURLEncoding.EncodeToString(boxBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// OpenBox
+//------------------------------------------------------------
+
+func OpenBox(boxString string, privateKey *ecdh.PrivateKey, options ...SealOption) (string, error) {
+	//------------------------------------------------------------
+	if !strings.HasPrefix(boxString, BOX_PREFIX) {
+		return "", ErrBoxInvalid
+	}
+	//------------------------------------------------------------
+	boxBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(boxString, BOX_PREFIX))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrBoxInvalid, err)
+	}
+	//------------------------------------------------------------
+	dataBytes, err := OpenBoxBytes(boxBytes, privateKey, options...)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return string(dataBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// boxKey
+//------------------------------------------------------------
+
+// both sides derive the same key as X25519(e, R) == X25519(r, E)
+func boxKey(privateKey *ecdh.PrivateKey, peerPublicKey *ecdh.PublicKey, ephemeralPublicKey *ecdh.PublicKey, recipientPublicKey *ecdh.PublicKey) ([]byte, error) {
+	//------------------------------------------------------------
+	// ECDH rejects low order points which would give an all zero secret
+	sharedBytes, err := privateKey.ECDH(peerPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBoxInvalid, err)
+	}
+	//------------------------------------------------------------
+	saltBytes := append(ephemeralPublicKey.Bytes(), recipientPublicKey.Bytes()...)
+	keyBytes := make([]byte, 32)
+	//------------------------------------------------------------
+	if _, err = io.ReadFull(hkdf.New(sha256.New, sharedBytes, saltBytes, []byte(boxInfo)), keyBytes); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return keyBytes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,101 @@
+//------------------------------------------------------------
+
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//--------------------------------------------------
+// SealBox / OpenBox
+//--------------------------------------------------
+
+func TestSealBox(t *testing.T) {
+	//------------------------------------------------------------
+	publicKey, privateKey, err := GenerateBoxKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	for _, algorithm := range []byte{AlgorithmAES256GCM, AlgorithmXChaCha20Poly1305} {
+		//------------------------------------------------------------
+		boxString, err := SealBox("api-token-1234", publicKey, WithAlgorithm(algorithm))
+		if err != nil {
+			t.Fatal(err)
+		}
+		//------------------------------------------------------------
+		if result, err := OpenBox(boxString, privateKey); err != nil || result != "api-token-1234" {
+			t.Errorf("%s result = %q, err = %v", AlgorithmName(algorithm), result, err)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	boxString1, _ := SealBox("api-token-1234", publicKey)
+	boxString2, _ := SealBox("api-token-1234", publicKey)
+	//------------------------------------------------------------
+	if boxString1 == boxString2 {
+		t.Error("each box should use a new ephemeral key")
+	}
+	//------------------------------------------------------------
+	_, otherPrivateKey, _ := GenerateBoxKey()
+	//------------------------------------------------------------
+	if _, err := OpenBox(boxString1, otherPrivateKey); err == nil {
+		t.Error("other private key should fail")
+	}
+	//------------------------------------------------------------
+	if _, err := OpenBox("enc:AAAA", privateKey); !errors.Is(err, ErrBoxInvalid) {
+		t.Errorf("err = %v but should = %v", err, ErrBoxInvalid)
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+
+func TestSealBoxBytes(t *testing.T) {
+	//------------------------------------------------------------
+	publicKey, privateKey, _ := GenerateBoxKey()
+	//------------------------------------------------------------
+	boxBytes, err := SealBoxBytes([]byte("secret"), publicKey, WithAAD([]byte("service-a")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	if result, err := OpenBoxBytes(boxBytes, privateKey, WithAAD([]byte("service-a"))); err != nil || string(result) != "secret" {
+		t.Errorf("result = %q, err = %v", result, err)
+	}
+	if _, err := OpenBoxBytes(boxBytes, privateKey, WithAAD([]byte("service-b"))); err == nil {
+		t.Error("different AAD should fail")
+	}
+	//------------------------------------------------------------
+	for index := range boxBytes {
+		//------------------------------------------------------------
+		tamperedBytes := bytes.Clone(boxBytes)
+		tamperedBytes[index] ^= 1
+		//------------------------------------------------------------
+		if _, err := OpenBoxBytes(tamperedBytes, privateKey, WithAAD([]byte("service-a"))); err == nil {
+			t.Errorf("byte %d tampered should fail", index)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	// an all zero (low order) ephemeral key must be rejected
+	zeroBytes := append([]byte{BOX_VERSION}, make([]byte, 32)...)
+	if _, err := OpenBoxBytes(append(zeroBytes, boxBytes[33:]...), privateKey); !errors.Is(err, ErrBoxInvalid) {
+		t.Errorf("err = %v but should = %v", err, ErrBoxInvalid)
+	}
+	//------------------------------------------------------------
+	if _, err := SealBoxBytes([]byte("secret"), nil); err == nil {
+		t.Error("nil public key should fail")
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
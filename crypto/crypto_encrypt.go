@@ -14,6 +14,9 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
 )
 
 //------------------------------------------------------------
@@ -24,7 +27,9 @@ import (
 // EncryptBytes
 //------------------------------------------------------------
 
-func EncryptBytes(dataBytes []byte, keyBytes []byte, ivBytes []byte) ([]byte, error) {
+// EncryptBytes uses AES-GCM, see EncryptBytesAlgorithm for XChaCha20-Poly1305
+// optional AAD (additional authenticated data) must be passed unchanged to DecryptBytes
+func EncryptBytes(dataBytes []byte, keyBytes []byte, ivBytes []byte, AAD ...[]byte) ([]byte, error) {
 	//------------------------------------------------------------
 	var err error
 	//----------------------------------------
@@ -41,7 +46,7 @@ func EncryptBytes(dataBytes []byte, keyBytes []byte, ivBytes []byte) ([]byte, er
 		//----------------------------------------
 		if err == nil {
 			//----------------------------------------
-			cipherBytes = cipherAEAD.Seal(nil, ivBytes, dataBytes, nil)
+			cipherBytes = cipherAEAD.Seal(nil, ivBytes, dataBytes, firstAAD(AAD))
 			//----------------------------------------
 		}
 		//----------------------------------------
@@ -55,7 +60,7 @@ func EncryptBytes(dataBytes []byte, keyBytes []byte, ivBytes []byte) ([]byte, er
 // DecryptBytes
 //------------------------------------------------------------
 
-func DecryptBytes(cipherBytes []byte, keyBytes []byte, ivBytes []byte) ([]byte, error) {
+func DecryptBytes(cipherBytes []byte, keyBytes []byte, ivBytes []byte, AAD ...[]byte) ([]byte, error) {
 	//------------------------------------------------------------
 	var err error
 	//----------------------------------------
@@ -72,7 +77,7 @@ func DecryptBytes(cipherBytes []byte, keyBytes []byte, ivBytes []byte) ([]byte,
 		//----------------------------------------
 		if err == nil {
 			//----------------------------------------
-			dataBytes, err = cipherAEAD.Open(nil, ivBytes, cipherBytes, nil)
+			dataBytes, err = cipherAEAD.Open(nil, ivBytes, cipherBytes, firstAAD(AAD))
 			//----------------------------------------
 		}
 		//----------------------------------------
@@ -82,10 +87,75 @@ func DecryptBytes(cipherBytes []byte, keyBytes []byte, ivBytes []byte) ([]byte,
 	//------------------------------------------------------------
 }
 
+//------------------------------------------------------------
+// EncryptBytesAlgorithm
+//------------------------------------------------------------
+
+// EncryptBytesAlgorithm is EncryptBytes with the algorithm named explicitly (AlgorithmAES256GCM or
+// AlgorithmXChaCha20Poly1305), the IV must be the algorithm's nonce size (see GenerateIV)
+func EncryptBytesAlgorithm(dataBytes []byte, keyBytes []byte, ivBytes []byte, algorithm byte, AAD ...[]byte) ([]byte, error) {
+	//------------------------------------------------------------
+	cipherAEAD, err := algorithmAEAD(algorithm, keyBytes, ivBytes)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return cipherAEAD.Seal(nil, ivBytes, dataBytes, firstAAD(AAD)), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// DecryptBytesAlgorithm
+//------------------------------------------------------------
+
+func DecryptBytesAlgorithm(cipherBytes []byte, keyBytes []byte, ivBytes []byte, algorithm byte, AAD ...[]byte) ([]byte, error) {
+	//------------------------------------------------------------
+	cipherAEAD, err := algorithmAEAD(algorithm, keyBytes, ivBytes)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return cipherAEAD.Open(nil, ivBytes, cipherBytes, firstAAD(AAD))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// algorithmAEAD
+//------------------------------------------------------------
+
+// the AEADs panic on a wrong nonce size so it is checked here
+func algorithmAEAD(algorithm byte, keyBytes []byte, ivBytes []byte) (cipher.AEAD, error) {
+	//------------------------------------------------------------
+	cipherAEAD, err := newAEAD(algorithm, keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if len(ivBytes) != cipherAEAD.NonceSize() {
+		return nil, fmt.Errorf("%s IV must be %d bytes", AlgorithmName(algorithm), cipherAEAD.NonceSize())
+	}
+	//------------------------------------------------------------
+	return cipherAEAD, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// firstAAD
+//------------------------------------------------------------
+
+func firstAAD(AAD [][]byte) []byte {
+	if len(AAD) > 0 {
+		return AAD[0]
+	}
+	return nil
+}
+
 //------------------------------------------------------------
 // EncryptString
 //------------------------------------------------------------
 
+// EncryptString always uses AES-GCM since the output does not record the algorithm,
+// use Seal for XChaCha20-Poly1305
 func EncryptString(dataString string, keyBytes []byte) (string, error) {
 	//------------------------------------------------------------
 	var err error
@@ -155,8 +225,13 @@ func GenerateKey() ([]byte, error) {
 // GenerateIV
 //------------------------------------------------------------
 
-func GenerateIV() ([]byte, error) {
-	key := make([]byte, 12)
+// GenerateIV returns a 12 byte IV for AES-GCM or a 24 byte nonce for XChaCha20-Poly1305
+func GenerateIV(Algorithm ...byte) ([]byte, error) {
+	size := 12
+	if len(Algorithm) > 0 && Algorithm[0] == AlgorithmXChaCha20Poly1305 {
+		size = chacha20poly1305.NonceSizeX
+	}
+	key := make([]byte, size)
 	_, err := rand.Read(key)
 	return key, err
 }
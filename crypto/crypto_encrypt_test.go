@@ -5,6 +5,7 @@ package crypto
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/hex"
 	"testing"
 )
 
@@ -112,6 +113,57 @@ func TestEncryptDecryptString(t *testing.T) {
 	//--------------------------------------------------
 }
 
+//--------------------------------------------------
+// XChaCha20-Poly1305
+//--------------------------------------------------
+
+func TestEncryptXChaCha20Poly1305(t *testing.T) {
+	//------------------------------------------------------------
+	// test vector from draft-irtf-cfrg-xchacha (A.3.1)
+	keyBytes, _ := hex.DecodeString("808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f")
+	ivBytes, _ := hex.DecodeString("404142434445464748494a4b4c4d4e4f5051525354555657")
+	AAD, _ := hex.DecodeString("50515253c0c1c2c3c4c5c6c7")
+	dataBytes := []byte("Ladies and Gentlemen of the class of '99: If I could offer you only one tip for the future, sunscreen would be it.")
+	//------------------------------------------------------------
+	expected := "bd6d179d3e83d43b9576579493c0e939572a1700252bfaccbed2902c21396cbb731c7f1b0b4aa6440bf3a82f4eda7e39ae64c6708c54c216cb96b72e1213b4522f8c9ba40db5d945b11b69b982c1bb9e3f3fac2bc369488f76b2383565d3fff921f9664c97637da9768812f615c68b13b52ec0875924c1c7987947deafd8780acf49"
+	//------------------------------------------------------------
+	cipherBytes, err := EncryptBytesAlgorithm(dataBytes, keyBytes, ivBytes, AlgorithmXChaCha20Poly1305, AAD)
+	//------------------------------------------------------------
+	if err != nil {
+		t.Error(err)
+	} else if hex.EncodeToString(cipherBytes) != expected {
+		t.Errorf("cipherBytes = %x but expected %s", cipherBytes, expected)
+	}
+	//------------------------------------------------------------
+	if plainBytes, err := DecryptBytesAlgorithm(cipherBytes, keyBytes, ivBytes, AlgorithmXChaCha20Poly1305, AAD); err != nil || !bytes.Equal(plainBytes, dataBytes) {
+		t.Errorf("plainBytes = %s, err = %v", plainBytes, err)
+	}
+	//------------------------------------------------------------
+	if ivBytes, _ := GenerateIV(AlgorithmXChaCha20Poly1305); len(ivBytes) != 24 {
+		t.Errorf("len(ivBytes) = %d but expected 24", len(ivBytes))
+	}
+	//------------------------------------------------------------
+	// the algorithm is never guessed from the IV length, a mismatched IV is an error rather than a panic
+	if _, err := EncryptBytesAlgorithm(dataBytes, keyBytes, ivBytes[:12], AlgorithmXChaCha20Poly1305); err == nil {
+		t.Error("a 12 byte IV should be rejected for XChaCha20-Poly1305")
+	}
+	if _, err := DecryptBytesAlgorithm(cipherBytes, keyBytes, ivBytes, AlgorithmAES256GCM, AAD); err == nil {
+		t.Error("a 24 byte IV should be rejected for AES-256-GCM")
+	}
+	if _, err := EncryptBytesAlgorithm(dataBytes, keyBytes, ivBytes, 99); err == nil {
+		t.Error("an unknown algorithm should be rejected")
+	}
+	//------------------------------------------------------------
+	// AlgorithmAES256GCM gives the same output as EncryptBytes
+	gcmIVBytes := ivBytes[:12]
+	gcmBytes, _ := EncryptBytes(dataBytes, keyBytes, gcmIVBytes, AAD)
+	//------------------------------------------------------------
+	if algorithmBytes, err := EncryptBytesAlgorithm(dataBytes, keyBytes, gcmIVBytes, AlgorithmAES256GCM, AAD); err != nil || !bytes.Equal(algorithmBytes, gcmBytes) {
+		t.Errorf("algorithmBytes = %x, err = %v but should = %x, nil", algorithmBytes, err, gcmBytes)
+	}
+	//------------------------------------------------------------
+}
+
 //------------------------------------------------------------
 //############################################################
 //------------------------------------------------------------
@@ -0,0 +1,438 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/sys/cpu"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// an envelope is self describing binary data:
+//
+//	version (1 byte) | algorithm (1 byte) | key ID length (1 byte) | key ID | nonce | ciphertext and tag
+//
+// the version, algorithm and key ID are authenticated together with any caller AAD, so a
+// ciphertext only opens with the same header and context (e.g. a "table.column" name)
+//
+// the text form is ENVELOPE_PREFIX followed by the unpadded base64url envelope, the prefix
+// contains ":" which never appears in the legacy base64(iv || ciphertext) EncryptString output
+
+const ENVELOPE_VERSION = 1
+
+const ENVELOPE_PREFIX = "enc:"
+
+const (
+	AlgorithmAES256GCM         byte = 1
+	AlgorithmXChaCha20Poly1305 byte = 2
+)
+
+var ErrEnvelopeInvalid = errors.New("invalid envelope")
+var ErrEnvelopeVersion = errors.New("unsupported envelope version")
+var ErrEnvelopeAlgorithm = errors.New("unsupported envelope algorithm")
+var ErrLegacyAAD = errors.New("legacy ciphertext cannot be bound to associated data")
+
+//------------------------------------------------------------
+
+type Envelope struct {
+	Version    byte
+	Algorithm  byte
+	KeyID      string
+	Nonce      []byte
+	Ciphertext []byte
+}
+
+//------------------------------------------------------------
+
+type SealOption func(*SealOptions)
+
+type SealOptions struct {
+	// Algorithm selects the AEAD used to seal, opening always uses the algorithm in the envelope
+	Algorithm byte
+	// KeyID is stored in the envelope so the right key can be found when opening
+	KeyID string
+	// AAD binds the ciphertext to a context which must be given again to open it
+	AAD []byte
+	// AllowLegacy lets Open decrypt EncryptString output that has no envelope
+	AllowLegacy bool
+}
+
+var DefaultSealOptions = SealOptions{
+	Algorithm:   AlgorithmAES256GCM,
+	AllowLegacy: true,
+}
+
+//------------------------------------------------------------
+
+func WithAlgorithm(algorithm byte) SealOption {
+	return func(options *SealOptions) { options.Algorithm = algorithm }
+}
+
+func WithKeyID(keyID string) SealOption {
+	return func(options *SealOptions) { options.KeyID = keyID }
+}
+
+func WithAAD(AAD []byte) SealOption {
+	return func(options *SealOptions) { options.AAD = AAD }
+}
+
+func WithAllowLegacy(allowLegacy bool) SealOption {
+	return func(options *SealOptions) { options.AllowLegacy = allowLegacy }
+}
+
+//------------------------------------------------------------
+
+func NewSealOptions(options ...SealOption) SealOptions {
+	sealOptions := DefaultSealOptions
+	for _, optionFunc := range options {
+		optionFunc(&sealOptions)
+	}
+	return sealOptions
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// SealBytes
+//------------------------------------------------------------
+
+// SealBytes encrypts dataBytes into a binary envelope with a random nonce
+func SealBytes(dataBytes []byte, keyBytes []byte, options ...SealOption) ([]byte, error) {
+	//------------------------------------------------------------
+	sealOptions := NewSealOptions(options...)
+	//------------------------------------------------------------
+	if len(sealOptions.KeyID) > 255 {
+		return nil, errors.New("key ID is longer than 255 bytes")
+	}
+	//------------------------------------------------------------
+	cipherAEAD, err := newAEAD(sealOptions.Algorithm, keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	envelope := Envelope{
+		Version:   ENVELOPE_VERSION,
+		Algorithm: sealOptions.Algorithm,
+		KeyID:     sealOptions.KeyID,
+		Nonce:     make([]byte, cipherAEAD.NonceSize()),
+	}
+	//------------------------------------------------------------
+	if _, err = rand.Read(envelope.Nonce); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	envelope.Ciphertext = cipherAEAD.Seal(nil, envelope.Nonce, dataBytes, envelope.additionalData(sealOptions.AAD))
+	//------------------------------------------------------------
+	return envelope.MarshalBinary()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// OpenBytes
+//------------------------------------------------------------
+
+// OpenBytes decrypts a binary envelope created by SealBytes
+func OpenBytes(envelopeBytes []byte, keyBytes []byte, options ...SealOption) ([]byte, error) {
+	//------------------------------------------------------------
+	sealOptions := NewSealOptions(options...)
+	//------------------------------------------------------------
+	envelope, err := ParseEnvelope(envelopeBytes)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	cipherAEAD, err := newAEAD(envelope.Algorithm, keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if len(envelope.Nonce) != cipherAEAD.NonceSize() {
+		return nil, ErrEnvelopeInvalid
+	}
+	//------------------------------------------------------------
+	return cipherAEAD.Open(nil, envelope.Nonce, envelope.Ciphertext, envelope.additionalData(sealOptions.AAD))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Seal
+//------------------------------------------------------------
+
+// Seal encrypts dataString into the text form of an envelope
+func Seal(dataString string, keyBytes []byte, options ...SealOption) (string, error) {
+	//------------------------------------------------------------
+	envelopeBytes, err := SealBytes([]byte(dataString), keyBytes, options...)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return ENVELOPE_PREFIX + base64.RawURLEncoding.EncodeToString(envelopeBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Open
+//------------------------------------------------------------
+
+// Open decrypts the output of Seal, or of the legacy EncryptString when AllowLegacy is set and no AAD is given
+func Open(sealedString string, keyBytes []byte, options ...SealOption) (string, error) {
+	//------------------------------------------------------------
+	sealOptions := NewSealOptions(options...)
+	//------------------------------------------------------------
+	if !IsEnvelope(sealedString) {
+		//------------------------------------------------------------
+		if !sealOptions.AllowLegacy {
+			return "", ErrEnvelopeInvalid
+		}
+		// a legacy ciphertext has no context, accepting it would let one replace a bound value
+		if sealOptions.AAD != nil {
+			return "", ErrLegacyAAD
+		}
+		//------------------------------------------------------------
+		return DecryptString(sealedString, keyBytes)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	envelopeBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(sealedString, ENVELOPE_PREFIX))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrEnvelopeInvalid, err)
+	}
+	//------------------------------------------------------------
+	dataBytes, err := OpenBytes(envelopeBytes, keyBytes, options...)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return string(dataBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// IsEnvelope
+//------------------------------------------------------------
+
+// IsEnvelope reports whether sealedString is the text form of an envelope rather than legacy EncryptString output
+func IsEnvelope(sealedString string) bool {
+	return strings.HasPrefix(sealedString, ENVELOPE_PREFIX)
+}
+
+//------------------------------------------------------------
+// ParseEnvelopeString
+//------------------------------------------------------------
+
+// ParseEnvelopeString parses the text form of an envelope without decrypting it, e.g. to read the key ID
+func ParseEnvelopeString(sealedString string) (*Envelope, error) {
+	//------------------------------------------------------------
+	if !IsEnvelope(sealedString) {
+		return nil, ErrEnvelopeInvalid
+	}
+	//------------------------------------------------------------
+	envelopeBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(sealedString, ENVELOPE_PREFIX))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEnvelopeInvalid, err)
+	}
+	//------------------------------------------------------------
+	return ParseEnvelope(envelopeBytes)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ParseEnvelope
+//------------------------------------------------------------
+
+// ParseEnvelope parses a binary envelope without decrypting it
+func ParseEnvelope(envelopeBytes []byte) (*Envelope, error) {
+	//------------------------------------------------------------
+	if len(envelopeBytes) < 3 {
+		return nil, ErrEnvelopeInvalid
+	}
+	//------------------------------------------------------------
+	envelope := &Envelope{Version: envelopeBytes[0], Algorithm: envelopeBytes[1]}
+	//------------------------------------------------------------
+	if envelope.Version != ENVELOPE_VERSION {
+		return nil, fmt.Errorf("%w: %d", ErrEnvelopeVersion, envelope.Version)
+	}
+	//------------------------------------------------------------
+	nonceSize, err := algorithmNonceSize(envelope.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	keyIDEnd := 3 + int(envelopeBytes[2])
+	//------------------------------------------------------------
+	if len(envelopeBytes) < keyIDEnd+nonceSize {
+		return nil, ErrEnvelopeInvalid
+	}
+	//------------------------------------------------------------
+	envelope.KeyID = string(envelopeBytes[3:keyIDEnd])
+	envelope.Nonce = envelopeBytes[keyIDEnd : keyIDEnd+nonceSize]
+	envelope.Ciphertext = envelopeBytes[keyIDEnd+nonceSize:]
+	//------------------------------------------------------------
+	return envelope, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// MarshalBinary
+//------------------------------------------------------------
+
+func (envelope *Envelope) MarshalBinary() ([]byte, error) {
+	//------------------------------------------------------------
+	if len(envelope.KeyID) > 255 {
+		return nil, errors.New("key ID is longer than 255 bytes")
+	}
+	//------------------------------------------------------------
+	envelopeBytes := make([]byte, 0, 3+len(envelope.KeyID)+len(envelope.Nonce)+len(envelope.Ciphertext))
+	//------------------------------------------------------------
+	envelopeBytes = append(envelopeBytes, envelope.header()...)
+	envelopeBytes = append(envelopeBytes, envelope.Nonce...)
+	envelopeBytes = append(envelopeBytes, envelope.Ciphertext...)
+	//------------------------------------------------------------
+	return envelopeBytes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// header
+//------------------------------------------------------------
+
+func (envelope *Envelope) header() []byte {
+	//------------------------------------------------------------
+	return append([]byte{envelope.Version, envelope.Algorithm, byte(len(envelope.KeyID))}, envelope.KeyID...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// additionalData
+//------------------------------------------------------------
+
+// the header is authenticated so the key ID or algorithm cannot be swapped
+func (envelope *Envelope) additionalData(AAD []byte) []byte {
+	//------------------------------------------------------------
+	return append(envelope.header(), AAD...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// newAEAD
+//------------------------------------------------------------
+
+func newAEAD(algorithm byte, keyBytes []byte) (cipher.AEAD, error) {
+	//------------------------------------------------------------
+	switch algorithm {
+	case AlgorithmAES256GCM:
+		//------------------------------------------------------------
+		if len(keyBytes) != 32 {
+			return nil, errors.New("AES-256-GCM key must be 32 bytes")
+		}
+		//------------------------------------------------------------
+		cipherBlock, err := aes.NewCipher(keyBytes)
+		if err != nil {
+			return nil, err
+		}
+		//------------------------------------------------------------
+		return cipher.NewGCM(cipherBlock)
+		//------------------------------------------------------------
+	case AlgorithmXChaCha20Poly1305:
+		//------------------------------------------------------------
+		return chacha20poly1305.NewX(keyBytes)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return nil, fmt.Errorf("%w: %d", ErrEnvelopeAlgorithm, algorithm)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// PreferredAlgorithm
+//------------------------------------------------------------
+
+// PreferredAlgorithm returns AES-256-GCM when the CPU has AES instructions and XChaCha20-Poly1305 otherwise,
+// e.g. crypto.DefaultSealOptions.Algorithm = crypto.PreferredAlgorithm()
+func PreferredAlgorithm() byte {
+	//------------------------------------------------------------
+	if (cpu.X86.HasAES && cpu.X86.HasPCLMULQDQ) || (cpu.ARM64.HasAES && cpu.ARM64.HasPMULL) || cpu.S390X.HasAESGCM {
+		return AlgorithmAES256GCM
+	}
+	//------------------------------------------------------------
+	return AlgorithmXChaCha20Poly1305
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// AlgorithmName
+//------------------------------------------------------------
+
+func AlgorithmName(algorithm byte) string {
+	//------------------------------------------------------------
+	switch algorithm {
+	case AlgorithmAES256GCM:
+		return "aes-256-gcm"
+	case AlgorithmXChaCha20Poly1305:
+		return "xchacha20-poly1305"
+	}
+	//------------------------------------------------------------
+	return fmt.Sprintf("unknown(%d)", algorithm)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ParseAlgorithm
+//------------------------------------------------------------
+
+// ParseAlgorithm accepts the names returned by AlgorithmName (case insensitive), e.g. from a config file
+func ParseAlgorithm(name string) (byte, error) {
+	//------------------------------------------------------------
+	for _, algorithm := range []byte{AlgorithmAES256GCM, AlgorithmXChaCha20Poly1305} {
+		if strings.EqualFold(name, AlgorithmName(algorithm)) {
+			return algorithm, nil
+		}
+	}
+	//------------------------------------------------------------
+	return 0, fmt.Errorf("%w: %q", ErrEnvelopeAlgorithm, name)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// algorithmNonceSize
+//------------------------------------------------------------
+
+func algorithmNonceSize(algorithm byte) (int, error) {
+	//------------------------------------------------------------
+	switch algorithm {
+	case AlgorithmAES256GCM:
+		return 12, nil
+	case AlgorithmXChaCha20Poly1305:
+		return chacha20poly1305.NonceSizeX, nil
+	}
+	//------------------------------------------------------------
+	return 0, fmt.Errorf("%w: %d", ErrEnvelopeAlgorithm, algorithm)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,237 @@
+//------------------------------------------------------------
+
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//--------------------------------------------------
+// Seal / Open
+//--------------------------------------------------
+
+func TestSealOpen(t *testing.T) {
+	//------------------------------------------------------------
+	keyBytes, _ := base64.StdEncoding.DecodeString("uGMs769fAJVJhonxf7q3gXYkRWKimax/vRpZ3JKHaME=")
+	//------------------------------------------------------------
+	for _, dataString := range []string{"", "test1234", strings.Repeat("x", 1000)} {
+		//------------------------------------------------------------
+		sealedString, err := Seal(dataString, keyBytes, WithKeyID("key-1"), WithAAD([]byte("users.email")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		//------------------------------------------------------------
+		if !IsEnvelope(sealedString) {
+			t.Errorf("sealedString = %q should start with %q", sealedString, ENVELOPE_PREFIX)
+		}
+		//------------------------------------------------------------
+		envelope, err := ParseEnvelopeString(sealedString)
+		if err != nil {
+			t.Fatal(err)
+		} else if envelope.KeyID != "key-1" || envelope.Version != ENVELOPE_VERSION || envelope.Algorithm != AlgorithmAES256GCM {
+			t.Errorf("envelope = %+v", envelope)
+		}
+		//------------------------------------------------------------
+		result, err := Open(sealedString, keyBytes, WithAAD([]byte("users.email")))
+		if err != nil {
+			t.Error(err)
+		} else if result != dataString {
+			t.Errorf("result = %q but should = %q", result, dataString)
+		}
+		//------------------------------------------------------------
+		// a value copied to another column must not open
+		if _, err = Open(sealedString, keyBytes, WithAAD([]byte("users.phone"))); err == nil {
+			t.Error("different AAD should fail")
+		}
+		if _, err = Open(sealedString, keyBytes); err == nil {
+			t.Error("missing AAD should fail")
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	sealedString1, _ := Seal("test1234", keyBytes)
+	sealedString2, _ := Seal("test1234", keyBytes)
+	//------------------------------------------------------------
+	if sealedString1 == sealedString2 {
+		t.Error("sealing twice should use different nonces")
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+
+func TestSealOpenTamper(t *testing.T) {
+	//------------------------------------------------------------
+	keyBytes, _ := GenerateKey()
+	//------------------------------------------------------------
+	envelopeBytes, err := SealBytes([]byte("test1234"), keyBytes, WithKeyID("key-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	if dataBytes, err := OpenBytes(envelopeBytes, keyBytes); err != nil || !bytes.Equal(dataBytes, []byte("test1234")) {
+		t.Errorf("dataBytes = %q, err = %v", dataBytes, err)
+	}
+	//------------------------------------------------------------
+	// every byte including the header is authenticated
+	for index := range envelopeBytes {
+		//------------------------------------------------------------
+		tamperedBytes := bytes.Clone(envelopeBytes)
+		tamperedBytes[index] ^= 1
+		//------------------------------------------------------------
+		if _, err := OpenBytes(tamperedBytes, keyBytes); err == nil {
+			t.Errorf("byte %d tampered should fail", index)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	// swapping the key ID for another of the same length
+	envelope, _ := ParseEnvelope(envelopeBytes)
+	envelope.KeyID = "key-2"
+	swappedBytes, _ := envelope.MarshalBinary()
+	//------------------------------------------------------------
+	if _, err := OpenBytes(swappedBytes, keyBytes); err == nil {
+		t.Error("changed key ID should fail")
+	}
+	//------------------------------------------------------------
+	otherKeyBytes, _ := GenerateKey()
+	//------------------------------------------------------------
+	if _, err := OpenBytes(envelopeBytes, otherKeyBytes); err == nil {
+		t.Error("wrong key should fail")
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+
+func TestOpenLegacy(t *testing.T) {
+	//------------------------------------------------------------
+	keyBytes, _ := GenerateKey()
+	//------------------------------------------------------------
+	legacyString, err := EncryptString("test1234", keyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	if IsEnvelope(legacyString) {
+		t.Errorf("legacyString = %q should not be an envelope", legacyString)
+	}
+	//------------------------------------------------------------
+	if result, err := Open(legacyString, keyBytes); err != nil || result != "test1234" {
+		t.Errorf("result = %q, err = %v", result, err)
+	}
+	//------------------------------------------------------------
+	if _, err := Open(legacyString, keyBytes, WithAllowLegacy(false)); !errors.Is(err, ErrEnvelopeInvalid) {
+		t.Errorf("err = %v but should = %v", err, ErrEnvelopeInvalid)
+	}
+	//------------------------------------------------------------
+	if _, err := Open(legacyString, keyBytes, WithAAD([]byte("users.email"))); !errors.Is(err, ErrLegacyAAD) {
+		t.Errorf("err = %v but should = %v", err, ErrLegacyAAD)
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+
+func TestSealOpenAlgorithm(t *testing.T) {
+	//------------------------------------------------------------
+	keyBytes, _ := GenerateKey()
+	//------------------------------------------------------------
+	sealedGCM, _ := Seal("test1234", keyBytes)
+	sealedXChaCha, err := Seal("test1234", keyBytes, WithAlgorithm(AlgorithmXChaCha20Poly1305), WithAAD([]byte("users.email")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	if envelope, _ := ParseEnvelopeString(sealedXChaCha); envelope == nil || envelope.Algorithm != AlgorithmXChaCha20Poly1305 || len(envelope.Nonce) != 24 {
+		t.Errorf("envelope = %+v", envelope)
+	}
+	//------------------------------------------------------------
+	// opening uses the algorithm in the envelope whatever the option says
+	for _, sealedString := range []string{sealedGCM, sealedXChaCha} {
+		//------------------------------------------------------------
+		var AAD []byte
+		if sealedString == sealedXChaCha {
+			AAD = []byte("users.email")
+		}
+		//------------------------------------------------------------
+		if result, err := Open(sealedString, keyBytes, WithAlgorithm(AlgorithmAES256GCM), WithAAD(AAD)); err != nil || result != "test1234" {
+			t.Errorf("result = %q, err = %v", result, err)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if _, err := Open(sealedXChaCha, keyBytes, WithAAD([]byte("users.phone"))); err == nil {
+		t.Error("different AAD should fail")
+	}
+	//------------------------------------------------------------
+	cipherBytes, _ := EncryptStream([]byte("stream data"), keyBytes, WithStreamAlgorithm(AlgorithmXChaCha20Poly1305), WithChunkSize(4))
+	//------------------------------------------------------------
+	if result, err := DecryptStream(cipherBytes, keyBytes); err != nil || string(result) != "stream data" {
+		t.Errorf("result = %q, err = %v", result, err)
+	}
+	//------------------------------------------------------------
+	for _, algorithm := range []byte{AlgorithmAES256GCM, AlgorithmXChaCha20Poly1305} {
+		if result, err := ParseAlgorithm(strings.ToUpper(AlgorithmName(algorithm))); err != nil || result != algorithm {
+			t.Errorf("ParseAlgorithm(%s) = %d, err = %v", AlgorithmName(algorithm), result, err)
+		}
+	}
+	//------------------------------------------------------------
+	if _, err := ParseAlgorithm("rot13"); !errors.Is(err, ErrEnvelopeAlgorithm) {
+		t.Errorf("err = %v but should = %v", err, ErrEnvelopeAlgorithm)
+	}
+	//------------------------------------------------------------
+	if algorithm := PreferredAlgorithm(); algorithm != AlgorithmAES256GCM && algorithm != AlgorithmXChaCha20Poly1305 {
+		t.Errorf("PreferredAlgorithm() = %d", algorithm)
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+
+func TestParseEnvelopeInvalid(t *testing.T) {
+	//------------------------------------------------------------
+	testCases := []struct {
+		envelopeBytes []byte
+		expected      error
+	}{
+		{nil, ErrEnvelopeInvalid},
+		{[]byte{1, 1}, ErrEnvelopeInvalid},
+		{[]byte{2, 1, 0}, ErrEnvelopeVersion},
+		{[]byte{1, 99, 0}, ErrEnvelopeAlgorithm},
+		{[]byte{1, 1, 5, 'k'}, ErrEnvelopeInvalid},
+		{append([]byte{1, 1, 0}, make([]byte, 11)...), ErrEnvelopeInvalid},
+	}
+	//------------------------------------------------------------
+	for _, testCase := range testCases {
+		if _, err := ParseEnvelope(testCase.envelopeBytes); !errors.Is(err, testCase.expected) {
+			t.Errorf("ParseEnvelope(%v) err = %v but should = %v", testCase.envelopeBytes, err, testCase.expected)
+		}
+	}
+	//------------------------------------------------------------
+	if _, err := ParseEnvelopeString(ENVELOPE_PREFIX + "!!!"); !errors.Is(err, ErrEnvelopeInvalid) {
+		t.Errorf("err = %v but should = %v", err, ErrEnvelopeInvalid)
+	}
+	//------------------------------------------------------------
+	keyBytes, _ := GenerateKey()
+	//------------------------------------------------------------
+	if _, err := Seal("test1234", keyBytes, WithKeyID(strings.Repeat("k", 256))); err == nil {
+		t.Error("long key ID should fail")
+	}
+	if _, err := Seal("test1234", keyBytes[:16]); err == nil {
+		t.Error("short key should fail")
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
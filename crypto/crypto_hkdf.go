@@ -0,0 +1,114 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// HKDF-SHA256 (RFC 5869) for deriving independent keys from one master key, e.g.
+//
+//	encryptionKey, _ := crypto.DeriveSubkey(masterKey, "users.email encryption")
+//	indexKey, _ := crypto.DeriveSubkey(masterKey, "users.email index")
+//
+// each label gives an unrelated key so one purpose's key reveals nothing about another's,
+// labels are never secret but must not change once data has been written with the key
+
+const HKDF_MAX_LENGTH = 255 * sha256.Size
+
+var ErrHKDFLength = fmt.Errorf("HKDF length must be 1 to %d bytes", HKDF_MAX_LENGTH)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// HKDFExtract
+//------------------------------------------------------------
+
+// HKDFExtract returns the 32 byte pseudorandom key for secretBytes, saltBytes may be nil
+func HKDFExtract(secretBytes []byte, saltBytes []byte) []byte {
+	//------------------------------------------------------------
+	return hkdf.Extract(sha256.New, secretBytes, saltBytes)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// HKDFExpand
+//------------------------------------------------------------
+
+// HKDFExpand returns length bytes of key material from a pseudorandom key and infoBytes
+func HKDFExpand(prkBytes []byte, infoBytes []byte, length int) ([]byte, error) {
+	//------------------------------------------------------------
+	if length < 1 || length > HKDF_MAX_LENGTH {
+		return nil, ErrHKDFLength
+	}
+	//------------------------------------------------------------
+	if len(prkBytes) < sha256.Size {
+		return nil, fmt.Errorf("HKDF pseudorandom key must be at least %d bytes", sha256.Size)
+	}
+	//------------------------------------------------------------
+	keyBytes := make([]byte, length)
+	//------------------------------------------------------------
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prkBytes, infoBytes), keyBytes); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return keyBytes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// HKDF
+//------------------------------------------------------------
+
+// HKDF runs extract then expand
+func HKDF(secretBytes []byte, saltBytes []byte, infoBytes []byte, length int) ([]byte, error) {
+	//------------------------------------------------------------
+	return HKDFExpand(HKDFExtract(secretBytes, saltBytes), infoBytes, length)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// DeriveSubkey
+//------------------------------------------------------------
+
+// DeriveSubkey returns a key for the purpose named by label (default 32 bytes)
+func DeriveSubkey(masterKey []byte, label string, Length ...int) ([]byte, error) {
+	//------------------------------------------------------------
+	length := 32
+	if len(Length) > 0 {
+		length = Length[0]
+	}
+	//------------------------------------------------------------
+	// HKDF cannot add entropy so a short master key would give weak subkeys
+	if len(masterKey) < 16 {
+		return nil, errors.New("master key must be at least 16 bytes")
+	}
+	//------------------------------------------------------------
+	if label == "" {
+		return nil, errors.New("subkey label must not be empty")
+	}
+	//------------------------------------------------------------
+	return HKDF(masterKey, nil, []byte(label), length)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
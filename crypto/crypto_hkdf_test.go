@@ -0,0 +1,94 @@
+//------------------------------------------------------------
+
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//--------------------------------------------------
+// HKDF
+//--------------------------------------------------
+
+func TestHKDF(t *testing.T) {
+	//------------------------------------------------------------
+	// RFC 5869 test case 1
+	secretBytes, _ := hex.DecodeString("0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b")
+	saltBytes, _ := hex.DecodeString("000102030405060708090a0b0c")
+	infoBytes, _ := hex.DecodeString("f0f1f2f3f4f5f6f7f8f9")
+	//------------------------------------------------------------
+	expectedPRK := "077709362c2e32df0ddc3f0dc47bba6390b6c73bb50f9c3122ec844ad7c2b3e5"
+	expectedOKM := "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865"
+	//------------------------------------------------------------
+	prkBytes := HKDFExtract(secretBytes, saltBytes)
+	//------------------------------------------------------------
+	if hex.EncodeToString(prkBytes) != expectedPRK {
+		t.Errorf("prkBytes = %x but should = %s", prkBytes, expectedPRK)
+	}
+	//------------------------------------------------------------
+	okmBytes, err := HKDFExpand(prkBytes, infoBytes, 42)
+	//------------------------------------------------------------
+	if err != nil || hex.EncodeToString(okmBytes) != expectedOKM {
+		t.Errorf("okmBytes = %x, err = %v but should = %s", okmBytes, err, expectedOKM)
+	}
+	//------------------------------------------------------------
+	if okmBytes, _ = HKDF(secretBytes, saltBytes, infoBytes, 42); hex.EncodeToString(okmBytes) != expectedOKM {
+		t.Errorf("okmBytes = %x but should = %s", okmBytes, expectedOKM)
+	}
+	//------------------------------------------------------------
+	for _, length := range []int{0, HKDF_MAX_LENGTH + 1} {
+		if _, err := HKDFExpand(prkBytes, infoBytes, length); !errors.Is(err, ErrHKDFLength) {
+			t.Errorf("%d err = %v but should = %v", length, err, ErrHKDFLength)
+		}
+	}
+	//------------------------------------------------------------
+	if _, err := HKDFExpand(prkBytes[:16], infoBytes, 32); err == nil {
+		t.Error("short pseudorandom key should be rejected")
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+// DeriveSubkey
+//--------------------------------------------------
+
+func TestDeriveSubkey(t *testing.T) {
+	//------------------------------------------------------------
+	masterKey, _ := GenerateKey()
+	//------------------------------------------------------------
+	key1, err := DeriveSubkey(masterKey, "encryption")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, _ := DeriveSubkey(masterKey, "encryption")
+	key3, _ := DeriveSubkey(masterKey, "index")
+	key4, _ := DeriveSubkey(masterKey, "index", 64)
+	//------------------------------------------------------------
+	if len(key1) != 32 || !bytes.Equal(key1, key2) || bytes.Equal(key1, key3) {
+		t.Error("subkeys should be 32 bytes, repeatable and depend on the label")
+	}
+	//------------------------------------------------------------
+	if len(key4) != 64 || !bytes.Equal(key4[:32], key3) {
+		t.Errorf("len(key4) = %d but should = 64 and start with the 32 byte key", len(key4))
+	}
+	//------------------------------------------------------------
+	if _, err := DeriveSubkey(masterKey[:8], "encryption"); err == nil {
+		t.Error("short master key should be rejected")
+	}
+	//------------------------------------------------------------
+	if _, err := DeriveSubkey(masterKey, ""); err == nil {
+		t.Error("empty label should be rejected")
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
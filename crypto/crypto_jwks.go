@@ -0,0 +1,183 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// JWK sets (RFC 7517) with Ed25519 "OKP" keys (RFC 8037) and "oct" HMAC secrets, keys of other
+// types in a parsed set (e.g. RSA) are skipped so a shared set can still be used
+
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	Use       string `json:"use,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	D         string `json:"d,omitempty"`
+	K         string `json:"k,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// BuildJWKS
+//------------------------------------------------------------
+
+// BuildJWKS returns a JWK set document for keys, only public keys are included unless IncludeSecrets is set
+// (HMAC secrets and Ed25519 private keys), so the default output is safe to publish
+func BuildJWKS(keys []JWTKey, IncludeSecrets ...bool) (string, error) {
+	//------------------------------------------------------------
+	includeSecrets := len(IncludeSecrets) > 0 && IncludeSecrets[0]
+	//------------------------------------------------------------
+	jwks := JWKS{Keys: []JWK{}}
+	//------------------------------------------------------------
+	for _, key := range keys {
+		//------------------------------------------------------------
+		jwk := JWK{KeyID: key.KeyID, Algorithm: key.Algorithm, Use: "sig"}
+		//------------------------------------------------------------
+		switch typedKey := key.Key.(type) {
+		case []byte:
+			//------------------------------------------------------------
+			if !includeSecrets {
+				continue
+			}
+			//------------------------------------------------------------
+			jwk.KeyType = "oct"
+			jwk.K = base64.RawURLEncoding.EncodeToString(typedKey)
+			//------------------------------------------------------------
+		case ed25519.PublicKey:
+			//------------------------------------------------------------
+			jwk.KeyType, jwk.Curve = "OKP", "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(typedKey)
+			//------------------------------------------------------------
+		case ed25519.PrivateKey:
+			//------------------------------------------------------------
+			jwk.KeyType, jwk.Curve = "OKP", "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(typedKey.Public().(ed25519.PublicKey))
+			//------------------------------------------------------------
+			if includeSecrets {
+				jwk.D = base64.RawURLEncoding.EncodeToString(typedKey.Seed())
+			}
+			//------------------------------------------------------------
+		default:
+			return "", fmt.Errorf("%w: %T", ErrKeyType, key.Key)
+		}
+		//------------------------------------------------------------
+		jwks.Keys = append(jwks.Keys, jwk)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	jwksBytes, err := json.Marshal(jwks)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return string(jwksBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ParseJWKS
+//------------------------------------------------------------
+
+// ParseJWKS returns the usable keys of a JWK set document for VerifyJWT (or SignJWT when secrets are present)
+func ParseJWKS(jwksString string) ([]JWTKey, error) {
+	//------------------------------------------------------------
+	var jwks JWKS
+	//------------------------------------------------------------
+	if err := json.Unmarshal([]byte(jwksString), &jwks); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	keys := []JWTKey{}
+	//------------------------------------------------------------
+	for index, jwk := range jwks.Keys {
+		//------------------------------------------------------------
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		//------------------------------------------------------------
+		key := JWTKey{KeyID: jwk.KeyID, Algorithm: jwk.Algorithm}
+		//------------------------------------------------------------
+		switch {
+		case jwk.KeyType == "oct":
+			//------------------------------------------------------------
+			// a secret could be used with either HMAC algorithm so alg must say which
+			if jwk.Algorithm != JWT_HS256 && jwk.Algorithm != JWT_HS512 {
+				continue
+			}
+			//------------------------------------------------------------
+			secretBytes, err := base64.RawURLEncoding.DecodeString(jwk.K)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: %v", index, err)
+			}
+			//------------------------------------------------------------
+			key.Key = secretBytes
+			//------------------------------------------------------------
+		case jwk.KeyType == "OKP" && jwk.Curve == "Ed25519":
+			//------------------------------------------------------------
+			if jwk.Algorithm != "" && jwk.Algorithm != JWT_EDDSA {
+				continue
+			}
+			key.Algorithm = JWT_EDDSA
+			//------------------------------------------------------------
+			publicBytes, err := base64.RawURLEncoding.DecodeString(jwk.X)
+			if err != nil || len(publicBytes) != ed25519.PublicKeySize {
+				return nil, fmt.Errorf("key %d: invalid Ed25519 public key", index)
+			}
+			//------------------------------------------------------------
+			key.Key = ed25519.PublicKey(publicBytes)
+			//------------------------------------------------------------
+			if jwk.D != "" {
+				//------------------------------------------------------------
+				seedBytes, err := base64.RawURLEncoding.DecodeString(jwk.D)
+				if err != nil || len(seedBytes) != ed25519.SeedSize {
+					return nil, fmt.Errorf("key %d: invalid Ed25519 private key", index)
+				}
+				//------------------------------------------------------------
+				privateKey := ed25519.NewKeyFromSeed(seedBytes)
+				if !privateKey.Public().(ed25519.PublicKey).Equal(key.Key) {
+					return nil, fmt.Errorf("key %d: Ed25519 public key does not match the private key", index)
+				}
+				//------------------------------------------------------------
+				key.Key = privateKey
+				//------------------------------------------------------------
+			}
+			//------------------------------------------------------------
+		default:
+			continue
+		}
+		//------------------------------------------------------------
+		keys = append(keys, key)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return keys, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,512 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// compact JWS (RFC 7515) and JWT (RFC 7519) for HS256, HS512 and EdDSA (RFC 8037)
+//
+// the algorithm of the key is always used, the "alg" header only has to agree with it, so a token
+// cannot switch a public key into an HMAC secret or disable signing with "none"
+
+const (
+	JWT_HS256 = "HS256"
+	JWT_HS512 = "HS512"
+	JWT_EDDSA = "EdDSA"
+)
+
+var ErrJWTInvalid = errors.New("invalid token")
+var ErrJWTAlgorithm = errors.New("unsupported token algorithm")
+var ErrJWTKeyNotFound = errors.New("no key for token")
+var ErrJWTSignature = errors.New("invalid token signature")
+var ErrJWTExpired = errors.New("token has expired")
+var ErrJWTNotYetValid = errors.New("token is not valid yet")
+var ErrJWTIssuedAt = errors.New("token is issued in the future")
+var ErrJWTAudience = errors.New("token audience is not accepted")
+var ErrJWTIssuer = errors.New("token issuer is not accepted")
+var ErrJWTClaimMissing = errors.New("token claim is missing")
+
+//------------------------------------------------------------
+
+type JWTKey struct {
+	KeyID     string
+	Algorithm string
+	// Key is []byte for HS256 / HS512, ed25519.PrivateKey to sign or ed25519.PublicKey to verify EdDSA
+	Key any
+}
+
+//------------------------------------------------------------
+
+type JWTOption func(*JWTOptions)
+
+type JWTOptions struct {
+	// Leeway allows for clock differences when checking exp, nbf and iat
+	Leeway time.Duration
+	// Audience must be in the aud claim when set
+	Audience string
+	// Issuer must equal the iss claim when set
+	Issuer string
+	// RequiredClaims must be present
+	RequiredClaims []string
+	// Now returns the current time
+	Now func() time.Time
+}
+
+var DefaultJWTOptions = JWTOptions{
+	RequiredClaims: []string{"exp"},
+	Now:            time.Now,
+}
+
+//------------------------------------------------------------
+
+func WithLeeway(leeway time.Duration) JWTOption {
+	return func(options *JWTOptions) { options.Leeway = leeway }
+}
+
+func WithAudience(audience string) JWTOption {
+	return func(options *JWTOptions) { options.Audience = audience }
+}
+
+func WithIssuer(issuer string) JWTOption {
+	return func(options *JWTOptions) { options.Issuer = issuer }
+}
+
+func WithRequiredClaims(claims ...string) JWTOption {
+	return func(options *JWTOptions) { options.RequiredClaims = claims }
+}
+
+func WithNow(now func() time.Time) JWTOption {
+	return func(options *JWTOptions) { options.Now = now }
+}
+
+//------------------------------------------------------------
+
+func NewJWTOptions(options ...JWTOption) JWTOptions {
+	jwtOptions := DefaultJWTOptions
+	for _, optionFunc := range options {
+		optionFunc(&jwtOptions)
+	}
+	return jwtOptions
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// NewJWTClaims
+//------------------------------------------------------------
+
+// NewJWTClaims returns the registered claims for a token valid for ttl from now, empty values are left out
+func NewJWTClaims(issuer string, subject string, audience string, ttl time.Duration) map[string]any {
+	//------------------------------------------------------------
+	now := time.Now().Unix()
+	//------------------------------------------------------------
+	claims := map[string]any{"iat": now, "nbf": now, "exp": now + int64(ttl/time.Second)}
+	//------------------------------------------------------------
+	for name, value := range map[string]string{"iss": issuer, "sub": subject, "aud": audience} {
+		if value != "" {
+			claims[name] = value
+		}
+	}
+	//------------------------------------------------------------
+	return claims
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// SignJWT
+//------------------------------------------------------------
+
+// SignJWT returns a compact JWT of claims signed with key
+func SignJWT(claims map[string]any, key JWTKey) (string, error) {
+	//------------------------------------------------------------
+	payloadBytes, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return SignJWS(payloadBytes, key, map[string]any{"typ": "JWT"})
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// VerifyJWT
+//------------------------------------------------------------
+
+// VerifyJWT checks the signature with the key selected by kid (or alg when there is no kid) and validates the claims
+func VerifyJWT(token string, keys []JWTKey, options ...JWTOption) (map[string]any, error) {
+	//------------------------------------------------------------
+	_, payloadBytes, err := VerifyJWS(token, keys)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	var claims map[string]any
+	//------------------------------------------------------------
+	if err = json.Unmarshal(payloadBytes, &claims); err != nil || claims == nil {
+		return nil, fmt.Errorf("%w: claims are not a JSON object", ErrJWTInvalid)
+	}
+	//------------------------------------------------------------
+	return claims, ValidateJWTClaims(claims, options...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ValidateJWTClaims
+//------------------------------------------------------------
+
+func ValidateJWTClaims(claims map[string]any, options ...JWTOption) error {
+	//------------------------------------------------------------
+	jwtOptions := NewJWTOptions(options...)
+	//------------------------------------------------------------
+	for _, name := range jwtOptions.RequiredClaims {
+		if _, exists := claims[name]; !exists {
+			return fmt.Errorf("%w: %s", ErrJWTClaimMissing, name)
+		}
+	}
+	//------------------------------------------------------------
+	now := jwtOptions.Now()
+	//------------------------------------------------------------
+	// exp is exclusive, nbf and iat are inclusive (RFC 7519 4.1)
+	for _, check := range []struct {
+		name    string
+		invalid func(claimTime time.Time) bool
+		err     error
+	}{
+		{"exp", func(claimTime time.Time) bool { return !now.Add(-jwtOptions.Leeway).Before(claimTime) }, ErrJWTExpired},
+		{"nbf", func(claimTime time.Time) bool { return now.Add(jwtOptions.Leeway).Before(claimTime) }, ErrJWTNotYetValid},
+		{"iat", func(claimTime time.Time) bool { return now.Add(jwtOptions.Leeway).Before(claimTime) }, ErrJWTIssuedAt},
+	} {
+		//------------------------------------------------------------
+		value, exists := claims[check.name]
+		if !exists {
+			continue
+		}
+		//------------------------------------------------------------
+		claimTime, ok := jwtNumericDate(value)
+		if !ok {
+			return fmt.Errorf("%w: %s is not a number", ErrJWTInvalid, check.name)
+		}
+		//------------------------------------------------------------
+		if check.invalid(claimTime) {
+			return check.err
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if jwtOptions.Issuer != "" && claims["iss"] != jwtOptions.Issuer {
+		return ErrJWTIssuer
+	}
+	//------------------------------------------------------------
+	if jwtOptions.Audience != "" {
+		//------------------------------------------------------------
+		// aud is a string or an array of strings
+		accepted := claims["aud"] == jwtOptions.Audience
+		//------------------------------------------------------------
+		if audiences, ok := claims["aud"].([]any); ok {
+			accepted = slices.Contains(audiences, any(jwtOptions.Audience))
+		}
+		//------------------------------------------------------------
+		if !accepted {
+			return ErrJWTAudience
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jwtNumericDate
+//------------------------------------------------------------
+
+func jwtNumericDate(value any) (time.Time, bool) {
+	//------------------------------------------------------------
+	var seconds float64
+	//------------------------------------------------------------
+	switch typedValue := value.(type) {
+	case float64:
+		seconds = typedValue
+	case json.Number:
+		var err error
+		if seconds, err = typedValue.Float64(); err != nil {
+			return time.Time{}, false
+		}
+	case int64:
+		return time.Unix(typedValue, 0), true
+	case int:
+		return time.Unix(int64(typedValue), 0), true
+	default:
+		return time.Time{}, false
+	}
+	//------------------------------------------------------------
+	if math.IsNaN(seconds) || math.Abs(seconds) > math.MaxInt64/2 {
+		return time.Time{}, false
+	}
+	//------------------------------------------------------------
+	wholeSeconds, fraction := math.Modf(seconds)
+	//------------------------------------------------------------
+	return time.Unix(int64(wholeSeconds), int64(fraction*1e9)), true
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// SignJWS
+//------------------------------------------------------------
+
+// SignJWS returns a compact JWS of payloadBytes, the alg and kid headers are set from key
+func SignJWS(payloadBytes []byte, key JWTKey, Header ...map[string]any) (string, error) {
+	//------------------------------------------------------------
+	header := map[string]any{}
+	//------------------------------------------------------------
+	if len(Header) > 0 {
+		for name, value := range Header[0] {
+			header[name] = value
+		}
+	}
+	//------------------------------------------------------------
+	header["alg"] = key.Algorithm
+	//------------------------------------------------------------
+	if key.KeyID != "" {
+		header["kid"] = key.KeyID
+	} else {
+		delete(header, "kid")
+	}
+	//------------------------------------------------------------
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	signingInput := base64.RawURLEncoding.EncodeToString(headerBytes) + "." + base64.RawURLEncoding.EncodeToString(payloadBytes)
+	//------------------------------------------------------------
+	signatureBytes, err := jwsSign([]byte(signingInput), key)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signatureBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// VerifyJWS
+//------------------------------------------------------------
+
+// VerifyJWS returns the header and payload of a compact JWS once its signature is verified
+func VerifyJWS(token string, keys []JWTKey) (map[string]any, []byte, error) {
+	//------------------------------------------------------------
+	header, payloadBytes, signatureBytes, err := ParseJWS(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	//------------------------------------------------------------
+	algorithm, _ := header["alg"].(string)
+	//------------------------------------------------------------
+	if !slices.Contains([]string{JWT_HS256, JWT_HS512, JWT_EDDSA}, algorithm) {
+		return nil, nil, fmt.Errorf("%w: %q", ErrJWTAlgorithm, header["alg"])
+	}
+	//------------------------------------------------------------
+	keyID, hasKeyID := header["kid"].(string)
+	signingInput := []byte(token[:strings.LastIndex(token, ".")])
+	//------------------------------------------------------------
+	found := false
+	//------------------------------------------------------------
+	for _, key := range keys {
+		//------------------------------------------------------------
+		if key.Algorithm != algorithm || (hasKeyID && key.KeyID != keyID) {
+			continue
+		}
+		//------------------------------------------------------------
+		found = true
+		//------------------------------------------------------------
+		if jwsVerify(signingInput, signatureBytes, key) {
+			return header, payloadBytes, nil
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if !found {
+		return nil, nil, fmt.Errorf("%w: kid %q alg %q", ErrJWTKeyNotFound, keyID, algorithm)
+	}
+	//------------------------------------------------------------
+	return nil, nil, ErrJWTSignature
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ParseJWS
+//------------------------------------------------------------
+
+// ParseJWS splits a compact JWS WITHOUT verifying it, e.g. to read the kid before choosing keys
+func ParseJWS(token string) (map[string]any, []byte, []byte, error) {
+	//------------------------------------------------------------
+	parts := strings.Split(token, ".")
+	//------------------------------------------------------------
+	if len(parts) != 3 {
+		return nil, nil, nil, fmt.Errorf("%w: expected 3 parts", ErrJWTInvalid)
+	}
+	//------------------------------------------------------------
+	var decodedParts [3][]byte
+	//------------------------------------------------------------
+	for index, part := range parts {
+		//------------------------------------------------------------
+		decodedBytes, err := base64.RawURLEncoding.DecodeString(part)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: %v", ErrJWTInvalid, err)
+		}
+		//------------------------------------------------------------
+		decodedParts[index] = decodedBytes
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	var header map[string]any
+	//------------------------------------------------------------
+	if err := json.Unmarshal(decodedParts[0], &header); err != nil || header == nil {
+		return nil, nil, nil, fmt.Errorf("%w: header is not a JSON object", ErrJWTInvalid)
+	}
+	//------------------------------------------------------------
+	// critical extensions are not supported so must be rejected (RFC 7515 4.1.11)
+	if _, exists := header["crit"]; exists {
+		return nil, nil, nil, fmt.Errorf("%w: crit header is not supported", ErrJWTInvalid)
+	}
+	//------------------------------------------------------------
+	return header, decodedParts[1], decodedParts[2], nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jwsSign
+//------------------------------------------------------------
+
+func jwsSign(signingInput []byte, key JWTKey) ([]byte, error) {
+	//------------------------------------------------------------
+	switch key.Algorithm {
+	case JWT_HS256, JWT_HS512:
+		//------------------------------------------------------------
+		secretBytes, err := jwsSecret(key)
+		if err != nil {
+			return nil, err
+		}
+		//------------------------------------------------------------
+		return jwsHMAC(signingInput, secretBytes, key.Algorithm), nil
+		//------------------------------------------------------------
+	case JWT_EDDSA:
+		//------------------------------------------------------------
+		privateKey, ok := key.Key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: EdDSA signing needs an ed25519.PrivateKey", ErrKeyType)
+		}
+		//------------------------------------------------------------
+		return Sign(signingInput, privateKey)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return nil, fmt.Errorf("%w: %q", ErrJWTAlgorithm, key.Algorithm)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jwsVerify
+//------------------------------------------------------------
+
+func jwsVerify(signingInput []byte, signatureBytes []byte, key JWTKey) bool {
+	//------------------------------------------------------------
+	switch key.Algorithm {
+	case JWT_HS256, JWT_HS512:
+		//------------------------------------------------------------
+		secretBytes, err := jwsSecret(key)
+		//------------------------------------------------------------
+		return err == nil && hmac.Equal(signatureBytes, jwsHMAC(signingInput, secretBytes, key.Algorithm))
+		//------------------------------------------------------------
+	case JWT_EDDSA:
+		//------------------------------------------------------------
+		switch typedKey := key.Key.(type) {
+		case ed25519.PublicKey:
+			return Verify(signingInput, signatureBytes, typedKey)
+		case ed25519.PrivateKey:
+			return Verify(signingInput, signatureBytes, typedKey.Public().(ed25519.PublicKey))
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return false
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jwsSecret
+//------------------------------------------------------------
+
+// RFC 7518 3.2 requires HMAC keys at least as long as the hash output
+func jwsSecret(key JWTKey) ([]byte, error) {
+	//------------------------------------------------------------
+	secretBytes, ok := key.Key.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s needs a []byte secret", ErrKeyType, key.Algorithm)
+	}
+	//------------------------------------------------------------
+	minimum := 32
+	if key.Algorithm == JWT_HS512 {
+		minimum = 64
+	}
+	//------------------------------------------------------------
+	if len(secretBytes) < minimum {
+		return nil, fmt.Errorf("%s secret must be at least %d bytes", key.Algorithm, minimum)
+	}
+	//------------------------------------------------------------
+	return secretBytes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// jwsHMAC
+//------------------------------------------------------------
+
+func jwsHMAC(signingInput []byte, secretBytes []byte, algorithm string) []byte {
+	//------------------------------------------------------------
+	hashFunc := sha256.New
+	if algorithm == JWT_HS512 {
+		hashFunc = func() hash.Hash { return sha512.New() }
+	}
+	//------------------------------------------------------------
+	mac := hmac.New(hashFunc, secretBytes)
+	mac.Write(signingInput)
+	//------------------------------------------------------------
+	return mac.Sum(nil)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,700 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/fslock"
+	"github.com/timbrockley/golang-main/file"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// a keyring holds versioned keys ("v1", "v2" ...) with one marked primary, values are sealed
+// with the primary key and its ID is stored in the envelope so older values still open after a rotation
+
+const KEYRING_AAD = "keyring"
+
+var ErrKeyNotFound = errors.New("key not found")
+var ErrKeyExists = errors.New("key already exists")
+var ErrKeyPrimary = errors.New("primary key cannot be removed")
+var ErrNoPrimaryKey = errors.New("keyring has no primary key")
+
+//------------------------------------------------------------
+
+type KeyringKey struct {
+	ID      string    `json:"id"`
+	Version int       `json:"version"`
+	Key     []byte    `json:"key"`
+	Created time.Time `json:"created"`
+}
+
+type Keyring struct {
+	mutex   sync.RWMutex
+	keys    []KeyringKey
+	primary string
+}
+
+type keyringJSON struct {
+	Primary string       `json:"primary"`
+	Keys    []KeyringKey `json:"keys"`
+}
+
+//------------------------------------------------------------
+
+// KeyringDB is the part of a database connection used by RotateColumn, *sqldb.SQLdb satisfies it
+type KeyringDB interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	Placeholder(index int) string
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// NewKeyring
+//------------------------------------------------------------
+
+// NewKeyring returns a keyring with a new random primary key
+func NewKeyring() (*Keyring, error) {
+	//------------------------------------------------------------
+	keyring := &Keyring{}
+	//------------------------------------------------------------
+	if _, err := keyring.Rotate(); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return keyring, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Rotate method
+//------------------------------------------------------------
+
+// Rotate adds a new random key with the next version and makes it primary, existing keys are kept for decryption
+func (keyring *Keyring) Rotate() (string, error) {
+	//------------------------------------------------------------
+	keyBytes, err := GenerateKey()
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	keyring.mutex.Lock()
+	defer keyring.mutex.Unlock()
+	//------------------------------------------------------------
+	version := 1
+	for _, keyringKey := range keyring.keys {
+		version = max(version, keyringKey.Version+1)
+	}
+	//------------------------------------------------------------
+	keyID := fmt.Sprintf("v%d", version)
+	//------------------------------------------------------------
+	keyring.keys = append(keyring.keys, KeyringKey{ID: keyID, Version: version, Key: keyBytes, Created: time.Now().UTC()})
+	keyring.primary = keyID
+	//------------------------------------------------------------
+	return keyID, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// AddKey method
+//------------------------------------------------------------
+
+// AddKey adds an existing 32 byte key, e.g. one issued before the keyring was used
+func (keyring *Keyring) AddKey(keyID string, keyBytes []byte, Primary ...bool) error {
+	//------------------------------------------------------------
+	if keyID == "" || len(keyID) > 255 {
+		return errors.New("key ID must be 1 to 255 bytes")
+	}
+	if len(keyBytes) != 32 {
+		return errors.New("key must be 32 bytes")
+	}
+	//------------------------------------------------------------
+	keyring.mutex.Lock()
+	defer keyring.mutex.Unlock()
+	//------------------------------------------------------------
+	if keyring.find(keyID) >= 0 {
+		return fmt.Errorf("%w: %s", ErrKeyExists, keyID)
+	}
+	//------------------------------------------------------------
+	version := 0
+	fmt.Sscanf(keyID, "v%d", &version)
+	//------------------------------------------------------------
+	keyring.keys = append(keyring.keys, KeyringKey{ID: keyID, Version: version, Key: slices.Clone(keyBytes), Created: time.Now().UTC()})
+	//------------------------------------------------------------
+	if keyring.primary == "" || (len(Primary) > 0 && Primary[0]) {
+		keyring.primary = keyID
+	}
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// RemoveKey method
+//------------------------------------------------------------
+
+// RemoveKey removes a retired key, values still sealed with it will no longer open
+func (keyring *Keyring) RemoveKey(keyID string) error {
+	//------------------------------------------------------------
+	keyring.mutex.Lock()
+	defer keyring.mutex.Unlock()
+	//------------------------------------------------------------
+	if keyID == keyring.primary {
+		return ErrKeyPrimary
+	}
+	//------------------------------------------------------------
+	index := keyring.find(keyID)
+	if index < 0 {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, keyID)
+	}
+	//------------------------------------------------------------
+	keyring.keys = slices.Delete(keyring.keys, index, index+1)
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// SetPrimary method
+//------------------------------------------------------------
+
+func (keyring *Keyring) SetPrimary(keyID string) error {
+	//------------------------------------------------------------
+	keyring.mutex.Lock()
+	defer keyring.mutex.Unlock()
+	//------------------------------------------------------------
+	if keyring.find(keyID) < 0 {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, keyID)
+	}
+	//------------------------------------------------------------
+	keyring.primary = keyID
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Primary method
+//------------------------------------------------------------
+
+func (keyring *Keyring) Primary() string {
+	//------------------------------------------------------------
+	keyring.mutex.RLock()
+	defer keyring.mutex.RUnlock()
+	//------------------------------------------------------------
+	return keyring.primary
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// KeyIDs method
+//------------------------------------------------------------
+
+// KeyIDs returns the key IDs in the order they were added
+func (keyring *Keyring) KeyIDs() []string {
+	//------------------------------------------------------------
+	keyring.mutex.RLock()
+	defer keyring.mutex.RUnlock()
+	//------------------------------------------------------------
+	keyIDs := make([]string, len(keyring.keys))
+	for index, keyringKey := range keyring.keys {
+		keyIDs[index] = keyringKey.ID
+	}
+	//------------------------------------------------------------
+	return keyIDs
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Key method
+//------------------------------------------------------------
+
+func (keyring *Keyring) Key(keyID string) ([]byte, error) {
+	//------------------------------------------------------------
+	keyring.mutex.RLock()
+	defer keyring.mutex.RUnlock()
+	//------------------------------------------------------------
+	index := keyring.find(keyID)
+	if index < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyID)
+	}
+	//------------------------------------------------------------
+	return slices.Clone(keyring.keys[index].Key), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// find
+//------------------------------------------------------------
+
+func (keyring *Keyring) find(keyID string) int {
+	//------------------------------------------------------------
+	return slices.IndexFunc(keyring.keys, func(keyringKey KeyringKey) bool { return keyringKey.ID == keyID })
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Encrypt method
+//------------------------------------------------------------
+
+// Encrypt seals dataString with the primary key, any WithKeyID option is replaced by the primary key ID
+func (keyring *Keyring) Encrypt(dataString string, options ...SealOption) (string, error) {
+	//------------------------------------------------------------
+	primary := keyring.Primary()
+	//------------------------------------------------------------
+	if primary == "" {
+		return "", ErrNoPrimaryKey
+	}
+	//------------------------------------------------------------
+	keyBytes, err := keyring.Key(primary)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return Seal(dataString, keyBytes, append(slices.Clone(options), WithKeyID(primary))...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Decrypt method
+//------------------------------------------------------------
+
+// Decrypt opens an envelope with the key named by its key ID, legacy EncryptString values are tried against each key
+func (keyring *Keyring) Decrypt(sealedString string, options ...SealOption) (string, error) {
+	//------------------------------------------------------------
+	if IsEnvelope(sealedString) {
+		//------------------------------------------------------------
+		envelope, err := ParseEnvelopeString(sealedString)
+		if err != nil {
+			return "", err
+		}
+		//------------------------------------------------------------
+		keyBytes, err := keyring.Key(envelope.KeyID)
+		if err != nil {
+			return "", err
+		}
+		//------------------------------------------------------------
+		return Open(sealedString, keyBytes, options...)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	// legacy values carry no key ID, GCM authentication rejects the wrong keys
+	var err error = ErrNoPrimaryKey
+	//------------------------------------------------------------
+	for _, keyID := range keyring.keyIDsPrimaryFirst() {
+		//------------------------------------------------------------
+		keyBytes, keyErr := keyring.Key(keyID)
+		if keyErr != nil {
+			continue
+		}
+		//------------------------------------------------------------
+		var dataString string
+		//------------------------------------------------------------
+		if dataString, err = Open(sealedString, keyBytes, options...); err == nil {
+			return dataString, nil
+		}
+		//------------------------------------------------------------
+		if errors.Is(err, ErrEnvelopeInvalid) || errors.Is(err, ErrLegacyAAD) {
+			break
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return "", err
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// keyIDsPrimaryFirst
+//------------------------------------------------------------
+
+func (keyring *Keyring) keyIDsPrimaryFirst() []string {
+	//------------------------------------------------------------
+	primary := keyring.Primary()
+	keyIDs := keyring.KeyIDs()
+	//------------------------------------------------------------
+	slices.Reverse(keyIDs)
+	//------------------------------------------------------------
+	if index := slices.Index(keyIDs, primary); index > 0 {
+		keyIDs = append([]string{primary}, slices.Delete(keyIDs, index, index+1)...)
+	}
+	//------------------------------------------------------------
+	return keyIDs
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NeedsReencrypt method
+//------------------------------------------------------------
+
+// NeedsReencrypt reports whether sealedString is legacy or sealed with a key other than the primary
+func (keyring *Keyring) NeedsReencrypt(sealedString string) bool {
+	//------------------------------------------------------------
+	envelope, err := ParseEnvelopeString(sealedString)
+	//------------------------------------------------------------
+	return err != nil || envelope.KeyID != keyring.Primary()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Reencrypt method
+//------------------------------------------------------------
+
+// Reencrypt seals sealedString again with the primary key, the bool result is false when it already used the primary key
+func (keyring *Keyring) Reencrypt(sealedString string, options ...SealOption) (string, bool, error) {
+	//------------------------------------------------------------
+	if !keyring.NeedsReencrypt(sealedString) {
+		return sealedString, false, nil
+	}
+	//------------------------------------------------------------
+	dataString, err := keyring.Decrypt(sealedString, options...)
+	if err != nil {
+		return sealedString, false, err
+	}
+	//------------------------------------------------------------
+	newString, err := keyring.Encrypt(dataString, options...)
+	if err != nil {
+		return sealedString, false, err
+	}
+	//------------------------------------------------------------
+	return newString, true, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ReencryptValues method
+//------------------------------------------------------------
+
+// ReencryptValues re-encrypts the values in place and returns how many changed, empty values are skipped
+func (keyring *Keyring) ReencryptValues(values []string, options ...SealOption) (int, error) {
+	//------------------------------------------------------------
+	count := 0
+	//------------------------------------------------------------
+	for index, value := range values {
+		//------------------------------------------------------------
+		if value == "" {
+			continue
+		}
+		//------------------------------------------------------------
+		newValue, changed, err := keyring.Reencrypt(value, options...)
+		if err != nil {
+			return count, fmt.Errorf("value %d: %w", index, err)
+		}
+		//------------------------------------------------------------
+		if changed {
+			values[index] = newValue
+			count++
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return count, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// RotateColumn method
+//------------------------------------------------------------
+
+// RotateColumn re-encrypts a column batchSize rows at a time ordered by idColumn, NULL and empty values are skipped
+//
+// each row is only updated if the value is unchanged since it was read, so it is safe to run
+// while the table is in use and to run again after an error
+func (keyring *Keyring) RotateColumn(db KeyringDB, tableName string, idColumn string, valueColumn string, batchSize int, options ...SealOption) (int, error) {
+	//------------------------------------------------------------
+	for _, name := range []string{tableName, idColumn, valueColumn} {
+		if !keyringIdentifierRegExp.MatchString(name) {
+			return 0, fmt.Errorf("invalid identifier: %q", name)
+		}
+	}
+	//------------------------------------------------------------
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	//------------------------------------------------------------
+	count := 0
+	//------------------------------------------------------------
+	var lastID any
+	//------------------------------------------------------------
+	for {
+		//------------------------------------------------------------
+		query := fmt.Sprintf("SELECT %s, %s FROM %s", idColumn, valueColumn, tableName)
+		args := []any{}
+		//------------------------------------------------------------
+		if lastID != nil {
+			query += fmt.Sprintf(" WHERE %s > %s", idColumn, db.Placeholder(1))
+			args = append(args, lastID)
+		}
+		//------------------------------------------------------------
+		query += fmt.Sprintf(" ORDER BY %s LIMIT %d", idColumn, batchSize)
+		//------------------------------------------------------------
+		type row struct {
+			id    any
+			value sql.NullString
+		}
+		//------------------------------------------------------------
+		rows, err := db.Query(query, args...)
+		if err != nil {
+			return count, err
+		}
+		//------------------------------------------------------------
+		var batch []row
+		//------------------------------------------------------------
+		for rows.Next() {
+			var batchRow row
+			if err = rows.Scan(&batchRow.id, &batchRow.value); err != nil {
+				rows.Close()
+				return count, err
+			}
+			batch = append(batch, batchRow)
+		}
+		//------------------------------------------------------------
+		rows.Close()
+		//------------------------------------------------------------
+		if err = rows.Err(); err != nil {
+			return count, err
+		}
+		//------------------------------------------------------------
+		updateQuery := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s AND %s = %s",
+			tableName, valueColumn, db.Placeholder(1), idColumn, db.Placeholder(2), valueColumn, db.Placeholder(3))
+		//------------------------------------------------------------
+		for _, batchRow := range batch {
+			//------------------------------------------------------------
+			if !batchRow.value.Valid || batchRow.value.String == "" {
+				continue
+			}
+			//------------------------------------------------------------
+			newValue, changed, err := keyring.Reencrypt(batchRow.value.String, options...)
+			if err != nil {
+				return count, fmt.Errorf("%s %v: %w", idColumn, batchRow.id, err)
+			}
+			//------------------------------------------------------------
+			if !changed {
+				continue
+			}
+			//------------------------------------------------------------
+			result, err := db.Exec(updateQuery, newValue, batchRow.id, batchRow.value.String)
+			if err != nil {
+				return count, err
+			}
+			//------------------------------------------------------------
+			if rowsAffected, err := result.RowsAffected(); err == nil && rowsAffected > 0 {
+				count++
+			}
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+		if len(batch) < batchSize {
+			break
+		}
+		//------------------------------------------------------------
+		lastID = batch[len(batch)-1].id
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return count, nil
+	//------------------------------------------------------------
+}
+
+var keyringIdentifierRegExp = regexp.MustCompile(`^[_A-Za-z][_A-Za-z0-9]*(\.[_A-Za-z][_A-Za-z0-9]*)?$`)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// MarshalJSON method
+//------------------------------------------------------------
+
+// MarshalJSON includes the raw keys, only store the result encrypted (see Save)
+func (keyring *Keyring) MarshalJSON() ([]byte, error) {
+	//------------------------------------------------------------
+	keyring.mutex.RLock()
+	defer keyring.mutex.RUnlock()
+	//------------------------------------------------------------
+	return json.Marshal(keyringJSON{Primary: keyring.primary, Keys: keyring.keys})
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// UnmarshalJSON method
+//------------------------------------------------------------
+
+func (keyring *Keyring) UnmarshalJSON(dataBytes []byte) error {
+	//------------------------------------------------------------
+	var data keyringJSON
+	//------------------------------------------------------------
+	if err := json.Unmarshal(dataBytes, &data); err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	newKeyring := &Keyring{}
+	//------------------------------------------------------------
+	for _, keyringKey := range data.Keys {
+		//------------------------------------------------------------
+		if err := newKeyring.AddKey(keyringKey.ID, keyringKey.Key); err != nil {
+			return err
+		}
+		//------------------------------------------------------------
+		newKeyring.keys[len(newKeyring.keys)-1].Version = keyringKey.Version
+		newKeyring.keys[len(newKeyring.keys)-1].Created = keyringKey.Created
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if len(data.Keys) > 0 {
+		//------------------------------------------------------------
+		if data.Primary == "" {
+			return ErrNoPrimaryKey
+		}
+		//------------------------------------------------------------
+		if err := newKeyring.SetPrimary(data.Primary); err != nil {
+			return err
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	keyring.mutex.Lock()
+	defer keyring.mutex.Unlock()
+	//------------------------------------------------------------
+	keyring.keys, keyring.primary = newKeyring.keys, newKeyring.primary
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Save method
+//------------------------------------------------------------
+
+// Save writes the keyring to filePath sealed with masterKey (32 bytes), the file is replaced atomically
+// with owner only permissions so a failed write never leaves the keys half written
+func (keyring *Keyring) Save(filePath string, masterKey []byte) error {
+	//------------------------------------------------------------
+	dataBytes, err := keyring.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	sealedString, err := Seal(string(dataBytes), masterKey, WithAAD([]byte(KEYRING_AAD)))
+	if err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	return saveFileAtomic(filePath, []byte(sealedString+"\n"))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// saveFileAtomic
+//------------------------------------------------------------
+
+// saveFileAtomic writes a 0600 temporary file in the same directory, syncs it and renames it over filePath
+//
+// concurrent saves are serialised with a separate filePath + ".lock" file, locking filePath itself would
+// create it before the rename, leave the lock on the replaced file and stop the rename on Windows
+func saveFileAtomic(filePath string, dataBytes []byte) (err error) {
+	//------------------------------------------------------------
+	filePath = filepath.FromSlash(filePath)
+	//------------------------------------------------------------
+	fileLock := fslock.New(filePath + ".lock")
+	if err = fileLock.Lock(); err != nil {
+		return err
+	}
+	defer fileLock.Unlock()
+	//------------------------------------------------------------
+	// CreateTemp uses 0600 permissions
+	tempFile, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".tmp*")
+	if err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	defer func() {
+		if err != nil {
+			tempFile.Close()
+			os.Remove(tempFile.Name())
+		}
+	}()
+	//------------------------------------------------------------
+	if _, err = tempFile.Write(dataBytes); err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	if err = tempFile.Sync(); err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	if err = tempFile.Close(); err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	if err = os.Rename(tempFile.Name(), filePath); err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	// sync the directory so the rename itself survives a crash, not supported on every platform
+	if dirFile, dirErr := os.Open(filepath.Dir(filePath)); dirErr == nil {
+		dirFile.Sync()
+		dirFile.Close()
+	}
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// LoadKeyring
+//------------------------------------------------------------
+
+// LoadKeyring reads a keyring written by Save
+func LoadKeyring(filePath string, masterKey []byte) (*Keyring, error) {
+	//------------------------------------------------------------
+	sealedString, err := file.FileLoad(filePath)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	dataString, err := Open(strings.TrimSpace(sealedString), masterKey, WithAAD([]byte(KEYRING_AAD)))
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	keyring := &Keyring{}
+	//------------------------------------------------------------
+	if err = keyring.UnmarshalJSON([]byte(dataString)); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return keyring, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
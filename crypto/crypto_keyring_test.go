@@ -0,0 +1,276 @@
+//------------------------------------------------------------
+
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/timbrockley/golang-main/database/sqldb"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//--------------------------------------------------
+// Keyring
+//--------------------------------------------------
+
+func TestKeyring(t *testing.T) {
+	//------------------------------------------------------------
+	keyring, err := NewKeyring()
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	if keyring.Primary() != "v1" {
+		t.Errorf("primary = %q but should = %q", keyring.Primary(), "v1")
+	}
+	//------------------------------------------------------------
+	sealedString1, err := keyring.Encrypt("test1234", WithAAD([]byte("users.email")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	if keyID, err := keyring.Rotate(); err != nil || keyID != "v2" {
+		t.Errorf("keyID = %q, err = %v", keyID, err)
+	}
+	//------------------------------------------------------------
+	sealedString2, _ := keyring.Encrypt("test5678", WithKeyID("ignored"))
+	//------------------------------------------------------------
+	if envelope, _ := ParseEnvelopeString(sealedString2); envelope == nil || envelope.KeyID != "v2" {
+		t.Errorf("envelope = %+v should use key v2", envelope)
+	}
+	//------------------------------------------------------------
+	if result, err := keyring.Decrypt(sealedString1, WithAAD([]byte("users.email"))); err != nil || result != "test1234" {
+		t.Errorf("result = %q, err = %v", result, err)
+	}
+	if result, err := keyring.Decrypt(sealedString2); err != nil || result != "test5678" {
+		t.Errorf("result = %q, err = %v", result, err)
+	}
+	//------------------------------------------------------------
+	if !keyring.NeedsReencrypt(sealedString1) || keyring.NeedsReencrypt(sealedString2) {
+		t.Error("only sealedString1 should need re-encrypting")
+	}
+	//------------------------------------------------------------
+	if err := keyring.RemoveKey("v2"); !errors.Is(err, ErrKeyPrimary) {
+		t.Errorf("err = %v but should = %v", err, ErrKeyPrimary)
+	}
+	if err := keyring.RemoveKey("v1"); err != nil {
+		t.Error(err)
+	}
+	if _, err := keyring.Decrypt(sealedString1, WithAAD([]byte("users.email"))); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("err = %v but should = %v", err, ErrKeyNotFound)
+	}
+	//------------------------------------------------------------
+	if err := keyring.SetPrimary("v9"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("err = %v but should = %v", err, ErrKeyNotFound)
+	}
+	//------------------------------------------------------------
+	if _, err := (&Keyring{}).Encrypt("test1234"); !errors.Is(err, ErrNoPrimaryKey) {
+		t.Errorf("err = %v but should = %v", err, ErrNoPrimaryKey)
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+
+func TestKeyringLegacy(t *testing.T) {
+	//------------------------------------------------------------
+	legacyKey, _ := GenerateKey()
+	legacyString, _ := EncryptString("test1234", legacyKey)
+	//------------------------------------------------------------
+	keyring := &Keyring{}
+	//------------------------------------------------------------
+	if err := keyring.AddKey("legacy", legacyKey); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keyring.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := keyring.AddKey("legacy", legacyKey); !errors.Is(err, ErrKeyExists) {
+		t.Errorf("err = %v but should = %v", err, ErrKeyExists)
+	}
+	//------------------------------------------------------------
+	if result, err := keyring.Decrypt(legacyString); err != nil || result != "test1234" {
+		t.Errorf("result = %q, err = %v", result, err)
+	}
+	//------------------------------------------------------------
+	values := []string{legacyString, "", legacyString}
+	//------------------------------------------------------------
+	count, err := keyring.ReencryptValues(values)
+	if err != nil || count != 2 {
+		t.Errorf("count = %d, err = %v", count, err)
+	}
+	//------------------------------------------------------------
+	for _, value := range []string{values[0], values[2]} {
+		if keyring.NeedsReencrypt(value) {
+			t.Errorf("value = %q should use the primary key", value)
+		} else if result, _ := keyring.Decrypt(value); result != "test1234" {
+			t.Errorf("result = %q but should = %q", result, "test1234")
+		}
+	}
+	//------------------------------------------------------------
+	if values[1] != "" {
+		t.Errorf("empty value = %q should be skipped", values[1])
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+
+func TestKeyringSaveLoad(t *testing.T) {
+	//------------------------------------------------------------
+	keyring, _ := NewKeyring()
+	keyring.Rotate()
+	keyring.SetPrimary("v1")
+	//------------------------------------------------------------
+	sealedString, _ := keyring.Encrypt("test1234")
+	//------------------------------------------------------------
+	masterKey, _ := GenerateKey()
+	filePath := filepath.Join(t.TempDir(), "keyring.enc")
+	//------------------------------------------------------------
+	if err := keyring.Save(filePath, masterKey); err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	// saving again replaces the file in place and leaves no temporary files behind
+	if err := keyring.Save(filePath, masterKey); err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	if fileInfo, err := os.Stat(filePath); err != nil || fileInfo.Mode().Perm() != 0600 {
+		t.Errorf("fileInfo = %v, err = %v but should have 0600 permissions", fileInfo, err)
+	}
+	//------------------------------------------------------------
+	dirEntries, _ := os.ReadDir(filepath.Dir(filePath))
+	entryNames := []string{}
+	for _, dirEntry := range dirEntries {
+		entryNames = append(entryNames, dirEntry.Name())
+	}
+	//------------------------------------------------------------
+	if !slices.Equal(entryNames, []string{"keyring.enc", "keyring.enc.lock"}) {
+		t.Errorf("entryNames = %v but should only hold the keyring and its lock file", entryNames)
+	}
+	//------------------------------------------------------------
+	// a failed save removes its temporary file
+	dirPath := filepath.Join(filepath.Dir(filePath), "keyring.dir")
+	os.MkdirAll(filepath.Join(dirPath, "keyring.enc", "sub"), 0700)
+	//------------------------------------------------------------
+	if err := keyring.Save(filepath.Join(dirPath, "keyring.enc"), masterKey); err == nil {
+		t.Error("save over a directory should fail")
+	}
+	//------------------------------------------------------------
+	if dirEntries, _ := os.ReadDir(dirPath); len(dirEntries) != 2 {
+		t.Errorf("directory has %d entries but should only hold the directory and the lock file", len(dirEntries))
+	}
+	//------------------------------------------------------------
+	if err := keyring.Save(filepath.Join(filePath, "missing", "keyring.enc"), masterKey); err == nil {
+		t.Error("save into a missing directory should fail")
+	}
+	//------------------------------------------------------------
+	loadedKeyring, err := LoadKeyring(filePath, masterKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	if loadedKeyring.Primary() != "v1" || !slices.Equal(loadedKeyring.KeyIDs(), []string{"v1", "v2"}) {
+		t.Errorf("primary = %q, keyIDs = %v", loadedKeyring.Primary(), loadedKeyring.KeyIDs())
+	}
+	//------------------------------------------------------------
+	if result, err := loadedKeyring.Decrypt(sealedString); err != nil || result != "test1234" {
+		t.Errorf("result = %q, err = %v", result, err)
+	}
+	//------------------------------------------------------------
+	if keyID, _ := loadedKeyring.Rotate(); keyID != "v3" {
+		t.Errorf("keyID = %q but should = %q", keyID, "v3")
+	}
+	//------------------------------------------------------------
+	otherKey, _ := GenerateKey()
+	//------------------------------------------------------------
+	if _, err := LoadKeyring(filePath, otherKey); err == nil {
+		t.Error("wrong master key should fail")
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+
+func TestKeyringRotateColumn(t *testing.T) {
+	//------------------------------------------------------------
+	conn, err := sqldb.Connect(sqldb.SQLdb{Database: ":memory:"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	//------------------------------------------------------------
+	if _, err = conn.Exec("CREATE TABLE users(id INTEGER PRIMARY KEY, email TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	keyring, _ := NewKeyring()
+	aad := WithAAD([]byte("users.email"))
+	//------------------------------------------------------------
+	for id := 1; id <= 25; id++ {
+		//------------------------------------------------------------
+		var value any
+		//------------------------------------------------------------
+		if id%10 != 0 {
+			value, _ = keyring.Encrypt(fmt.Sprintf("user%d@example.com", id), aad)
+		}
+		//------------------------------------------------------------
+		if _, err = conn.Exec("INSERT INTO users(id, email) VALUES(?, ?)", id, value); err != nil {
+			t.Fatal(err)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	keyring.Rotate()
+	//------------------------------------------------------------
+	count, err := keyring.RotateColumn(&conn, "users", "id", "email", 7, aad)
+	if err != nil || count != 23 {
+		t.Errorf("count = %d, err = %v", count, err)
+	}
+	//------------------------------------------------------------
+	records, _ := conn.QueryRecords("SELECT id, email FROM users ORDER BY id")
+	//------------------------------------------------------------
+	checked := 0
+	//------------------------------------------------------------
+	for _, record := range records {
+		//------------------------------------------------------------
+		value := fmt.Sprintf("%s", record["email"])
+		if record["email"] == nil {
+			continue
+		}
+		checked++
+		//------------------------------------------------------------
+		if keyring.NeedsReencrypt(value) {
+			t.Errorf("id %v should use the primary key", record["id"])
+		} else if result, err := keyring.Decrypt(value, aad); err != nil || result != fmt.Sprintf("user%v@example.com", record["id"]) {
+			t.Errorf("result = %q, err = %v", result, err)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if checked != 23 {
+		t.Errorf("checked = %d but should = 23", checked)
+	}
+	//------------------------------------------------------------
+	if count, err = keyring.RotateColumn(&conn, "users", "id", "email", 7, aad); err != nil || count != 0 {
+		t.Errorf("second run count = %d, err = %v", count, err)
+	}
+	//------------------------------------------------------------
+	if _, err = keyring.RotateColumn(&conn, "users; DROP TABLE users", "id", "email", 7); err == nil {
+		t.Error("invalid table name should fail")
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
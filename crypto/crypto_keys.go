@@ -0,0 +1,275 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// keys are exported either as PEM (PKCS #8 "PRIVATE KEY" and PKIX "PUBLIC KEY", as used by OpenSSL)
+// or as raw standard base64 in libsodium's layout: 32 byte public keys, 32 byte X25519 private keys
+// and 64 byte Ed25519 private keys (seed followed by the public key)
+
+var ErrKeyType = errors.New("unsupported key type")
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// MarshalPrivateKeyPEM
+//------------------------------------------------------------
+
+// MarshalPrivateKeyPEM encodes an ed25519.PrivateKey or X25519 *ecdh.PrivateKey as a PKCS #8 PEM block
+func MarshalPrivateKeyPEM(privateKey any) (string, error) {
+	//------------------------------------------------------------
+	if err := checkKeyType(privateKey); err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	derBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: derBytes})), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// MarshalPublicKeyPEM
+//------------------------------------------------------------
+
+// MarshalPublicKeyPEM encodes an ed25519.PublicKey or X25519 *ecdh.PublicKey as a PKIX PEM block
+func MarshalPublicKeyPEM(publicKey any) (string, error) {
+	//------------------------------------------------------------
+	if err := checkKeyType(publicKey); err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	derBytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: derBytes})), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ParsePrivateKeyPEM
+//------------------------------------------------------------
+
+// ParsePrivateKeyPEM returns an ed25519.PrivateKey or X25519 *ecdh.PrivateKey
+func ParsePrivateKeyPEM(pemString string) (any, error) {
+	//------------------------------------------------------------
+	derBytes, err := decodePEM(pemString, "PRIVATE KEY")
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	privateKey, err := x509.ParsePKCS8PrivateKey(derBytes)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return privateKey, checkKeyType(privateKey)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ParsePublicKeyPEM
+//------------------------------------------------------------
+
+// ParsePublicKeyPEM returns an ed25519.PublicKey or X25519 *ecdh.PublicKey
+func ParsePublicKeyPEM(pemString string) (any, error) {
+	//------------------------------------------------------------
+	derBytes, err := decodePEM(pemString, "PUBLIC KEY")
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	publicKey, err := x509.ParsePKIXPublicKey(derBytes)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return publicKey, checkKeyType(publicKey)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// decodePEM
+//------------------------------------------------------------
+
+func decodePEM(pemString string, blockType string) ([]byte, error) {
+	//------------------------------------------------------------
+	block, _ := pem.Decode([]byte(strings.TrimSpace(pemString)))
+	//------------------------------------------------------------
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	//------------------------------------------------------------
+	if block.Type != blockType {
+		return nil, fmt.Errorf("PEM type is %q but should be %q", block.Type, blockType)
+	}
+	//------------------------------------------------------------
+	return block.Bytes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// checkKeyType
+//------------------------------------------------------------
+
+func checkKeyType(key any) error {
+	//------------------------------------------------------------
+	switch typedKey := key.(type) {
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return nil
+	case *ecdh.PrivateKey:
+		if typedKey.Curve() == ecdh.X25519() {
+			return nil
+		}
+	case *ecdh.PublicKey:
+		if typedKey.Curve() == ecdh.X25519() {
+			return nil
+		}
+	}
+	//------------------------------------------------------------
+	return fmt.Errorf("%w: %T", ErrKeyType, key)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// MarshalKeyBase64
+//------------------------------------------------------------
+
+// MarshalKeyBase64 encodes an Ed25519 or X25519 key as raw standard base64
+func MarshalKeyBase64(key any) (string, error) {
+	//------------------------------------------------------------
+	if err := checkKeyType(key); err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	var keyBytes []byte
+	//------------------------------------------------------------
+	switch typedKey := key.(type) {
+	case ed25519.PrivateKey:
+		keyBytes = typedKey
+	case ed25519.PublicKey:
+		keyBytes = typedKey
+	case *ecdh.PrivateKey:
+		keyBytes = typedKey.Bytes()
+	case *ecdh.PublicKey:
+		keyBytes = typedKey.Bytes()
+	}
+	//------------------------------------------------------------
+	return base64.StdEncoding.EncodeToString(keyBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ParseSigningPrivateKeyBase64
+//------------------------------------------------------------
+
+// ParseSigningPrivateKeyBase64 accepts a 64 byte libsodium secret key or a 32 byte seed
+func ParseSigningPrivateKeyBase64(keyBase64 string) (ed25519.PrivateKey, error) {
+	//------------------------------------------------------------
+	keyBytes, err := base64.StdEncoding.DecodeString(strings.TrimSpace(keyBase64))
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	switch len(keyBytes) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(keyBytes), nil
+	case ed25519.PrivateKeySize:
+		//------------------------------------------------------------
+		// the public half must match the seed or signatures would not verify
+		privateKey := ed25519.NewKeyFromSeed(keyBytes[:ed25519.SeedSize])
+		if !privateKey.Equal(ed25519.PrivateKey(keyBytes)) {
+			return nil, errors.New("Ed25519 public key does not match the seed")
+		}
+		//------------------------------------------------------------
+		return privateKey, nil
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return nil, errors.New("Ed25519 private key must be 32 or 64 bytes")
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ParseSigningPublicKeyBase64
+//------------------------------------------------------------
+
+func ParseSigningPublicKeyBase64(keyBase64 string) (ed25519.PublicKey, error) {
+	//------------------------------------------------------------
+	keyBytes, err := base64.StdEncoding.DecodeString(strings.TrimSpace(keyBase64))
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if len(keyBytes) != ed25519.PublicKeySize {
+		return nil, errors.New("Ed25519 public key must be 32 bytes")
+	}
+	//------------------------------------------------------------
+	return ed25519.PublicKey(keyBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ParseBoxPrivateKeyBase64
+//------------------------------------------------------------
+
+func ParseBoxPrivateKeyBase64(keyBase64 string) (*ecdh.PrivateKey, error) {
+	//------------------------------------------------------------
+	keyBytes, err := base64.StdEncoding.DecodeString(strings.TrimSpace(keyBase64))
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return ecdh.X25519().NewPrivateKey(keyBytes)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ParseBoxPublicKeyBase64
+//------------------------------------------------------------
+
+func ParseBoxPublicKeyBase64(keyBase64 string) (*ecdh.PublicKey, error) {
+	//------------------------------------------------------------
+	keyBytes, err := base64.StdEncoding.DecodeString(strings.TrimSpace(keyBase64))
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return ecdh.X25519().NewPublicKey(keyBytes)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -112,32 +112,23 @@ func ObfuscateV0Encode(dataString string, Encoding ...string) string {
 	//------------------------------------------------------------
 	dataString = ObfuscateV0(dataString)
 	//------------------------------------------------------------
-	switch encoding {
-	case "base":
-		return conv.Base_encode(dataString)
-	case "base64":
-		return conv.Base64_encode(dataString)
-	case "base64url":
-		return conv.Base64url_encode(dataString)
-	case "base91":
-		return conv.Base91_encode(dataString, true)
-	case "hex":
-		return conv.Hex_encode(dataString)
-	default:
-		replacer := strings.NewReplacer(
-			"-", "--",
-			"\x09", "-t", // tab
-			"\x0A", "-n", // new line
-			"\x0D", "-r", // carriage return
-			"\x20", "-s", // space
-			"\x22", "-q", // double quote
-			"\x24", "-d", // dollar sign
-			"\x27", "-a", // apostrophy
-			"\x5C", "-b", // backslash
-			"\x60", "-g", // grave accent
-		)
-		return replacer.Replace(dataString)
-	}
+	if encodedString, found := codecEncode(dataString, encoding); found {
+		return encodedString
+	}
+	//------------------------------------------------------------
+	replacer := strings.NewReplacer(
+		"-", "--",
+		"\x09", "-t", // tab
+		"\x0A", "-n", // new line
+		"\x0D", "-r", // carriage return
+		"\x20", "-s", // space
+		"\x22", "-q", // double quote
+		"\x24", "-d", // dollar sign
+		"\x27", "-a", // apostrophy
+		"\x5C", "-b", // backslash
+		"\x60", "-g", // grave accent
+	)
+	return replacer.Replace(dataString)
 	//------------------------------------------------------------
 }
 
@@ -158,18 +149,9 @@ func ObfuscateV0Decode(dataString string, Encoding ...string) (string, error) {
 		encoding = Encoding[0]
 	}
 	//------------------------------------------------------------
-	switch encoding {
-	case "base":
-		dataString, err = conv.Base_decode(dataString)
-	case "base64":
-		dataString, err = conv.Base64_decode(dataString)
-	case "base64url":
-		dataString, err = conv.Base64url_decode(dataString)
-	case "base91":
-		dataString, err = conv.Base91_decode(dataString, true)
-	case "hex":
-		dataString, err = conv.Hex_decode(dataString)
-	default:
+	if decodedString, found, decodeErr := codecDecode(dataString, encoding); found {
+		dataString, err = decodedString, decodeErr
+	} else {
 		replacer := strings.NewReplacer(
 			"--", "-SUB",
 			"-g", "\x60", // grave accent
@@ -184,7 +166,6 @@ func ObfuscateV0Decode(dataString string, Encoding ...string) (string, error) {
 		)
 		dataString = replacer.Replace(dataString)
 		dataString = strings.ReplaceAll(dataString, "-SUB", "-")
-		//------------------------------------------------------------
 	}
 	//------------------------------------------------------------
 	if err != nil {
@@ -284,30 +265,21 @@ func ObfuscateV4Encode(dataString string, Options ...Option) string {
 	//------------------------------------------------------------
 	dataString = ObfuscateV4(dataString, Options...)
 	//------------------------------------------------------------
-	switch options.Encoding {
-	case "base":
-		return conv.Base_encode(dataString)
-	case "base64":
-		return conv.Base64_encode(dataString)
-	case "base64url":
-		return conv.Base64url_encode(dataString)
-	case "base91":
-		return conv.Base91_encode(dataString, true)
-	case "hex":
-		return conv.Hex_encode(dataString)
-	default:
-		replacer := strings.NewReplacer(
-			"\\", "\\\\",
-			"\x09", "\\t", // tab
-			"\x0A", "\\n", // new line
-			"\x0D", "\\r", // carriage return
-			"\x22", "\\q", // double quote
-			"\x27", "\\a", // apostrophe
-			"\x60", "\\g", // grave accent
-		)
-		return replacer.Replace(dataString)
+	if encodedString, found := codecEncode(dataString, options.Encoding); found {
+		return encodedString
 	}
 	//------------------------------------------------------------
+	replacer := strings.NewReplacer(
+		"\\", "\\\\",
+		"\x09", "\\t", // tab
+		"\x0A", "\\n", // new line
+		"\x0D", "\\r", // carriage return
+		"\x22", "\\q", // double quote
+		"\x27", "\\a", // apostrophe
+		"\x60", "\\g", // grave accent
+	)
+	return replacer.Replace(dataString)
+	//------------------------------------------------------------
 }
 
 //------------------------------------------------------------
@@ -328,18 +300,9 @@ func ObfuscateV4Decode(dataString string, Options ...Option) (string, error) {
 	//------------------------------------------------------------
 	options := NewOptions(Options...)
 	//------------------------------------------------------------
-	switch options.Encoding {
-	case "base":
-		dataString, err = conv.Base_decode(dataString)
-	case "base64":
-		dataString, err = conv.Base64_decode(dataString)
-	case "base64url":
-		dataString, err = conv.Base64url_decode(dataString)
-	case "base91":
-		dataString, err = conv.Base91_decode(dataString, true)
-	case "hex":
-		dataString, err = conv.Hex_decode(dataString)
-	default:
+	if decodedString, found, decodeErr := codecDecode(dataString, options.Encoding); found {
+		dataString, err = decodedString, decodeErr
+	} else {
 		replacer := strings.NewReplacer(
 			"\\\\", "\\SUB",
 			"\\g", "\x60", // grave accent
@@ -351,7 +314,6 @@ func ObfuscateV4Decode(dataString string, Options ...Option) (string, error) {
 		)
 		dataString = replacer.Replace(dataString)
 		dataString = strings.ReplaceAll(dataString, "\\SUB", "\\")
-		//------------------------------------------------------------
 	}
 	//------------------------------------------------------------
 	if err != nil {
@@ -449,33 +411,24 @@ func ObfuscateV5Encode(dataString string, Encoding ...string) string {
 	//------------------------------------------------------------
 	dataString = ObfuscateV5(dataString)
 	//------------------------------------------------------------
-	switch encoding {
-	case "base":
-		return conv.Base_encode(dataString)
-	case "base64":
-		return conv.Base64_encode(dataString)
-	case "base64url":
-		return conv.Base64url_encode(dataString)
-	case "base91":
-		return conv.Base91_encode(dataString, true)
-	case "hex":
-		return conv.Hex_encode(dataString)
-	default:
-		replacer := strings.NewReplacer(
-			"-", "--",
-			"\x09", "-t", // tab
-			"\x0A", "-n", // new line
-			"\x0D", "-r", // carriage return
-			"\x20", "-s", // space
-			"\x22", "-q", // double quote
-			"\x24", "-d", // dollar sign
-			"\x27", "-a", // apostrophy
-			"\x5C", "-b", // backslash
-			"\x60", "-g", // grave accent
-		)
-		return replacer.Replace(dataString)
+	if encodedString, found := codecEncode(dataString, encoding); found {
+		return encodedString
 	}
 	//------------------------------------------------------------
+	replacer := strings.NewReplacer(
+		"-", "--",
+		"\x09", "-t", // tab
+		"\x0A", "-n", // new line
+		"\x0D", "-r", // carriage return
+		"\x20", "-s", // space
+		"\x22", "-q", // double quote
+		"\x24", "-d", // dollar sign
+		"\x27", "-a", // apostrophy
+		"\x5C", "-b", // backslash
+		"\x60", "-g", // grave accent
+	)
+	return replacer.Replace(dataString)
+	//------------------------------------------------------------
 }
 
 //------------------------------------------------------------
@@ -495,18 +448,9 @@ func ObfuscateV5Decode(dataString string, Encoding ...string) (string, error) {
 		encoding = Encoding[0]
 	}
 	//------------------------------------------------------------
-	switch encoding {
-	case "base":
-		dataString, err = conv.Base_decode(dataString)
-	case "base64":
-		dataString, err = conv.Base64_decode(dataString)
-	case "base64url":
-		dataString, err = conv.Base64url_decode(dataString)
-	case "base91":
-		dataString, err = conv.Base91_decode(dataString, true)
-	case "hex":
-		dataString, err = conv.Hex_decode(dataString)
-	default:
+	if decodedString, found, decodeErr := codecDecode(dataString, encoding); found {
+		dataString, err = decodedString, decodeErr
+	} else {
 		replacer := strings.NewReplacer(
 			"--", "-SUB",
 			"-g", "\x60", // grave accent
@@ -521,7 +465,6 @@ func ObfuscateV5Decode(dataString string, Encoding ...string) (string, error) {
 		)
 		dataString = replacer.Replace(dataString)
 		dataString = strings.ReplaceAll(dataString, "-SUB", "-")
-		//------------------------------------------------------------
 	}
 	//------------------------------------------------------------
 	if err != nil {
@@ -592,33 +535,24 @@ func ObfuscateXOREncode(dataString string, value byte, Encoding ...string) (stri
 		return "", err
 	}
 	//------------------------------------------------------------
-	switch encoding {
-	case "base":
-		return conv.Base_encode(dataString), nil
-	case "base64":
-		return conv.Base64_encode(dataString), nil
-	case "base64url":
-		return conv.Base64url_encode(dataString), nil
-	case "base91":
-		return conv.Base91_encode(dataString, true), nil
-	case "hex":
-		return conv.Hex_encode(dataString), nil
-	default:
-		replacer := strings.NewReplacer(
-			"-", "--",
-			"\x09", "-t", // tab
-			"\x0A", "-n", // new line
-			"\x0D", "-r", // carriage return
-			"\x20", "-s", // space
-			"\x22", "-q", // double quote
-			"\x24", "-d", // dollar sign
-			"\x27", "-a", // apostrophy
-			"\x5C", "-b", // backslash
-			"\x60", "-g", // grave accent
-		)
-		return replacer.Replace(dataString), nil
+	if encodedString, found := codecEncode(dataString, encoding); found {
+		return encodedString, nil
 	}
 	//------------------------------------------------------------
+	replacer := strings.NewReplacer(
+		"-", "--",
+		"\x09", "-t", // tab
+		"\x0A", "-n", // new line
+		"\x0D", "-r", // carriage return
+		"\x20", "-s", // space
+		"\x22", "-q", // double quote
+		"\x24", "-d", // dollar sign
+		"\x27", "-a", // apostrophy
+		"\x5C", "-b", // backslash
+		"\x60", "-g", // grave accent
+	)
+	return replacer.Replace(dataString), nil
+	//------------------------------------------------------------
 }
 
 //------------------------------------------------------------
@@ -638,18 +572,9 @@ func ObfuscateXORDecode(dataString string, value byte, Encoding ...string) (stri
 		encoding = Encoding[0]
 	}
 	//------------------------------------------------------------
-	switch encoding {
-	case "base":
-		dataString, err = conv.Base_decode(dataString)
-	case "base64":
-		dataString, err = conv.Base64_decode(dataString)
-	case "base64url":
-		dataString, err = conv.Base64url_decode(dataString)
-	case "base91":
-		dataString, err = conv.Base91_decode(dataString, true)
-	case "hex":
-		dataString, err = conv.Hex_decode(dataString)
-	default:
+	if decodedString, found, decodeErr := codecDecode(dataString, encoding); found {
+		dataString, err = decodedString, decodeErr
+	} else {
 		replacer := strings.NewReplacer(
 			"--", "-SUB",
 			"-g", "\x60", // grave accent
@@ -664,7 +589,6 @@ func ObfuscateXORDecode(dataString string, value byte, Encoding ...string) (stri
 		)
 		dataString = replacer.Replace(dataString)
 		dataString = strings.ReplaceAll(dataString, "-SUB", "-")
-		//------------------------------------------------------------
 	}
 	//------------------------------------------------------------
 	if err != nil {
@@ -678,3 +602,64 @@ func ObfuscateXORDecode(dataString string, value byte, Encoding ...string) (stri
 //----------------------------------------------------------------------
 //######################################################################
 //----------------------------------------------------------------------
+
+// the encodings are a fixed set rather than the conv codec registry so the output
+// never depends on which codecs other packages have registered
+
+var obfuscateEncoders = map[string]func(string) string{
+	"base":      conv.Base_encode,
+	"base64":    conv.Base64_encode,
+	"base64url": conv.Base64url_encode,
+	"base91":    func(dataString string) string { return conv.Base91_encode(dataString, true) },
+	"hex":       conv.Hex_encode,
+}
+
+var obfuscateDecoders = map[string]func(string) (string, error){
+	"base":      conv.Base_decode,
+	"base64":    conv.Base64_decode,
+	"base64url": conv.Base64url_decode,
+	"base91":    func(dataString string) (string, error) { return conv.Base91_decode(dataString, true) },
+	"hex":       conv.Hex_decode,
+}
+
+//------------------------------------------------------------
+// codecEncode
+//------------------------------------------------------------
+
+// codecEncode encodes dataString with the named encoding
+//
+// found is false for any other name so callers can fall back to escaping
+func codecEncode(dataString string, encoding string) (encodedString string, found bool) {
+	//------------------------------------------------------------
+	encodeFunc, found := obfuscateEncoders[encoding]
+	if !found {
+		return "", false
+	}
+	//------------------------------------------------------------
+	return encodeFunc(dataString), true
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// codecDecode
+//------------------------------------------------------------
+
+// codecDecode decodes dataString with the named encoding
+//
+// found is false for any other name so callers can fall back to unescaping
+func codecDecode(dataString string, encoding string) (decodedString string, found bool, err error) {
+	//------------------------------------------------------------
+	decodeFunc, found := obfuscateDecoders[encoding]
+	if !found {
+		return "", false, nil
+	}
+	//------------------------------------------------------------
+	decodedString, err = decodeFunc(dataString)
+	//------------------------------------------------------------
+	return decodedString, true, err
+	//------------------------------------------------------------
+}
+
+//----------------------------------------------------------------------
+//######################################################################
+//----------------------------------------------------------------------
@@ -4,6 +4,8 @@ package crypto
 
 import (
 	"testing"
+
+	"github.com/timbrockley/golang-main/conv"
 )
 
 //----------------------------------------------------------------------
@@ -104,6 +106,32 @@ func TestObfuscateV0Encode(t *testing.T) {
 	//------------------------------------------------------------
 }
 
+//------------------------------------------------------------
+// ObfuscateV0Encode registered codecs
+//------------------------------------------------------------
+
+func TestObfuscateV0EncodeRegisteredCodec(t *testing.T) {
+	//------------------------------------------------------------
+	registeredFunc := func(dataBytes []byte) ([]byte, error) { return []byte("REGISTERED"), nil }
+	//------------------------------------------------------------
+	if err := conv.RegisterCodec(conv.NewCodec("test_obfuscate_codec", registeredFunc, registeredFunc)); err != nil {
+		t.Fatal(err)
+	}
+	defer conv.UnregisterCodec("test_obfuscate_codec")
+	//------------------------------------------------------------
+	// a name outside the fixed set is escaped whatever other packages have registered
+	expected := ObfuscateV0Encode("hello world")
+	//------------------------------------------------------------
+	if result := ObfuscateV0Encode("hello world", "test_obfuscate_codec"); result != expected {
+		t.Errorf("result = %q but should = %q", result, expected)
+	}
+	//------------------------------------------------------------
+	if result, err := ObfuscateV0Decode(expected, "test_obfuscate_codec"); err != nil || result != "hello world" {
+		t.Errorf("result = %q, err = %v but should = %q, nil", result, err, "hello world")
+	}
+	//------------------------------------------------------------
+}
+
 //------------------------------------------------------------
 // ObfuscateV0Decode
 //------------------------------------------------------------
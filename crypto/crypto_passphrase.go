@@ -0,0 +1,416 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// passphrase encrypted data has a header holding everything needed to derive the key again:
+//
+//	version (1 byte) | KDF (1 byte) | 3 x uint32 big endian parameters | salt length (1 byte) | salt | envelope
+//
+// the parameters are time, memory (KiB) and threads for Argon2id or log2(N), r and p for scrypt,
+// the key is sealed in an envelope (see SealBytes) which authenticates the header as its AAD
+//
+// the text form is PASSPHRASE_PREFIX followed by the unpadded base64url data
+
+const PASSPHRASE_VERSION = 1
+
+const PASSPHRASE_PREFIX = "pass:"
+
+const PASSPHRASE_SALT_SIZE = 16
+
+const (
+	KDFArgon2id byte = 1
+	KDFScrypt   byte = 2
+)
+
+// limits applied when decrypting so a crafted header cannot demand unbounded time or memory,
+// the memory cost is m for Argon2id and 128 * r * N * p bytes for scrypt
+const (
+	KDF_MAX_MEMORY_BYTES = 1 << 30 // 1 GiB
+	KDF_MAX_TIME         = 16
+	KDF_MAX_MEMORY       = KDF_MAX_MEMORY_BYTES >> 10 // KiB
+	KDF_MAX_THREADS      = 255
+	KDF_MAX_SCRYPT_N     = 24 // log2
+	KDF_MAX_SCRYPT_RP    = 1 << 20
+)
+
+var ErrPassphraseInvalid = errors.New("invalid passphrase data")
+var ErrKDFParams = errors.New("invalid KDF parameters")
+
+//------------------------------------------------------------
+
+type KDFParams struct {
+	KDF byte
+	// Argon2id
+	Time    uint32
+	Memory  uint32 // KiB
+	Threads uint8
+	// scrypt
+	LogN uint8
+	R    uint32
+	P    uint32
+}
+
+// DefaultArgon2idParams follows the second recommended option in RFC 9106
+var DefaultArgon2idParams = KDFParams{KDF: KDFArgon2id, Time: 3, Memory: 64 * 1024, Threads: 4}
+
+var DefaultScryptParams = KDFParams{KDF: KDFScrypt, LogN: 15, R: 8, P: 1}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Validate method
+//------------------------------------------------------------
+
+func (params KDFParams) Validate() error {
+	//------------------------------------------------------------
+	switch params.KDF {
+	case KDFArgon2id:
+		//------------------------------------------------------------
+		if params.Time < 1 || params.Time > KDF_MAX_TIME {
+			return fmt.Errorf("%w: time must be 1 to %d", ErrKDFParams, KDF_MAX_TIME)
+		}
+		if params.Threads < 1 {
+			return fmt.Errorf("%w: threads must be at least 1", ErrKDFParams)
+		}
+		if params.Memory < 8*uint32(params.Threads) || params.Memory > KDF_MAX_MEMORY {
+			return fmt.Errorf("%w: memory must be %d to %d KiB", ErrKDFParams, 8*uint32(params.Threads), KDF_MAX_MEMORY)
+		}
+		//------------------------------------------------------------
+	case KDFScrypt:
+		//------------------------------------------------------------
+		if params.LogN < 1 || params.LogN > KDF_MAX_SCRYPT_N {
+			return fmt.Errorf("%w: log2(N) must be 1 to %d", ErrKDFParams, KDF_MAX_SCRYPT_N)
+		}
+		if params.R < 1 || params.P < 1 || uint64(params.R)*uint64(params.P) > KDF_MAX_SCRYPT_RP {
+			return fmt.Errorf("%w: r and p must be at least 1 and r * p at most %d", ErrKDFParams, KDF_MAX_SCRYPT_RP)
+		}
+		if scryptMemory(params) > KDF_MAX_MEMORY_BYTES {
+			return fmt.Errorf("%w: 128 * r * N * p must be at most %d bytes", ErrKDFParams, KDF_MAX_MEMORY_BYTES)
+		}
+		//------------------------------------------------------------
+	default:
+		return fmt.Errorf("%w: unknown KDF %d", ErrKDFParams, params.KDF)
+	}
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// scryptMemory
+//------------------------------------------------------------
+
+// r and p are already limited to 2^20 and N to 2^24 so 128 * r * N fits, p is checked before multiplying
+func scryptMemory(params KDFParams) uint64 {
+	//------------------------------------------------------------
+	memory := 128 * uint64(params.R) << params.LogN
+	//------------------------------------------------------------
+	if uint64(params.P) > KDF_MAX_MEMORY_BYTES/memory {
+		return KDF_MAX_MEMORY_BYTES + 1
+	}
+	//------------------------------------------------------------
+	return memory * uint64(params.P)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// String method
+//------------------------------------------------------------
+
+func (params KDFParams) String() string {
+	//------------------------------------------------------------
+	switch params.KDF {
+	case KDFArgon2id:
+		return fmt.Sprintf("argon2id(t=%d, m=%dKiB, p=%d)", params.Time, params.Memory, params.Threads)
+	case KDFScrypt:
+		return fmt.Sprintf("scrypt(N=2^%d, r=%d, p=%d)", params.LogN, params.R, params.P)
+	}
+	//------------------------------------------------------------
+	return fmt.Sprintf("unknown(%d)", params.KDF)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// DeriveKey
+//------------------------------------------------------------
+
+// DeriveKey derives a 32 byte key from passphraseBytes and saltBytes
+func DeriveKey(passphraseBytes []byte, saltBytes []byte, params KDFParams) ([]byte, error) {
+	//------------------------------------------------------------
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if params.KDF == KDFScrypt {
+		return scrypt.Key(passphraseBytes, saltBytes, 1<<params.LogN, int(params.R), int(params.P), 32)
+	}
+	//------------------------------------------------------------
+	return argon2.IDKey(passphraseBytes, saltBytes, params.Time, params.Memory, params.Threads, 32), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// EncryptPassphraseBytes
+//------------------------------------------------------------
+
+// EncryptPassphraseBytes encrypts dataBytes with a key derived from passphraseBytes and a random salt (default Argon2id)
+func EncryptPassphraseBytes(dataBytes []byte, passphraseBytes []byte, Params ...KDFParams) ([]byte, error) {
+	//------------------------------------------------------------
+	params := DefaultArgon2idParams
+	if len(Params) > 0 {
+		params = Params[0]
+	}
+	//------------------------------------------------------------
+	saltBytes := make([]byte, PASSPHRASE_SALT_SIZE)
+	//------------------------------------------------------------
+	if _, err := rand.Read(saltBytes); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	keyBytes, err := DeriveKey(passphraseBytes, saltBytes, params)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	headerBytes := passphraseHeader(params, saltBytes)
+	//------------------------------------------------------------
+	envelopeBytes, err := SealBytes(dataBytes, keyBytes, WithAAD(headerBytes))
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return append(headerBytes, envelopeBytes...), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// DecryptPassphraseBytes
+//------------------------------------------------------------
+
+// DecryptPassphraseBytes decrypts the output of EncryptPassphraseBytes using the parameters stored in its header
+func DecryptPassphraseBytes(cipherBytes []byte, passphraseBytes []byte) ([]byte, error) {
+	//------------------------------------------------------------
+	params, saltBytes, headerSize, err := ParsePassphraseHeader(cipherBytes)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	keyBytes, err := DeriveKey(passphraseBytes, saltBytes, params)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return OpenBytes(cipherBytes[headerSize:], keyBytes, WithAAD(cipherBytes[:headerSize]))
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// EncryptPassphrase
+//------------------------------------------------------------
+
+// EncryptPassphrase encrypts dataString into the text form, suitable for backup files and .env values
+func EncryptPassphrase(dataString string, passphrase string, Params ...KDFParams) (string, error) {
+	//------------------------------------------------------------
+	cipherBytes, err := EncryptPassphraseBytes([]byte(dataString), []byte(passphrase), Params...)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return PASSPHRASE_PREFIX + base64.RawURLEncoding.EncodeToString(cipherBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// DecryptPassphrase
+//------------------------------------------------------------
+
+func DecryptPassphrase(cipherString string, passphrase string) (string, error) {
+	//------------------------------------------------------------
+	if !strings.HasPrefix(cipherString, PASSPHRASE_PREFIX) {
+		return "", ErrPassphraseInvalid
+	}
+	//------------------------------------------------------------
+	cipherBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(cipherString, PASSPHRASE_PREFIX))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrPassphraseInvalid, err)
+	}
+	//------------------------------------------------------------
+	dataBytes, err := DecryptPassphraseBytes(cipherBytes, []byte(passphrase))
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return string(dataBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ParsePassphraseHeader
+//------------------------------------------------------------
+
+// ParsePassphraseHeader returns the KDF parameters, salt and header size of passphrase encrypted data
+func ParsePassphraseHeader(cipherBytes []byte) (KDFParams, []byte, int, error) {
+	//------------------------------------------------------------
+	var params KDFParams
+	//------------------------------------------------------------
+	if len(cipherBytes) < 15 || cipherBytes[0] != PASSPHRASE_VERSION {
+		return params, nil, 0, ErrPassphraseInvalid
+	}
+	//------------------------------------------------------------
+	params.KDF = cipherBytes[1]
+	//------------------------------------------------------------
+	value1 := binary.BigEndian.Uint32(cipherBytes[2:6])
+	value2 := binary.BigEndian.Uint32(cipherBytes[6:10])
+	value3 := binary.BigEndian.Uint32(cipherBytes[10:14])
+	//------------------------------------------------------------
+	switch params.KDF {
+	case KDFArgon2id:
+		if value3 > KDF_MAX_THREADS {
+			return params, nil, 0, fmt.Errorf("%w: threads %d", ErrKDFParams, value3)
+		}
+		params.Time, params.Memory, params.Threads = value1, value2, uint8(value3)
+	case KDFScrypt:
+		if value1 > KDF_MAX_SCRYPT_N {
+			return params, nil, 0, fmt.Errorf("%w: log2(N) %d", ErrKDFParams, value1)
+		}
+		params.LogN, params.R, params.P = uint8(value1), value2, value3
+	}
+	//------------------------------------------------------------
+	if err := params.Validate(); err != nil {
+		return params, nil, 0, err
+	}
+	//------------------------------------------------------------
+	headerSize := 15 + int(cipherBytes[14])
+	//------------------------------------------------------------
+	if len(cipherBytes) < headerSize {
+		return params, nil, 0, ErrPassphraseInvalid
+	}
+	//------------------------------------------------------------
+	return params, cipherBytes[15:headerSize], headerSize, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// passphraseHeader
+//------------------------------------------------------------
+
+func passphraseHeader(params KDFParams, saltBytes []byte) []byte {
+	//------------------------------------------------------------
+	headerBytes := []byte{PASSPHRASE_VERSION, params.KDF}
+	//------------------------------------------------------------
+	if params.KDF == KDFScrypt {
+		headerBytes = binary.BigEndian.AppendUint32(headerBytes, uint32(params.LogN))
+		headerBytes = binary.BigEndian.AppendUint32(headerBytes, params.R)
+		headerBytes = binary.BigEndian.AppendUint32(headerBytes, params.P)
+	} else {
+		headerBytes = binary.BigEndian.AppendUint32(headerBytes, params.Time)
+		headerBytes = binary.BigEndian.AppendUint32(headerBytes, params.Memory)
+		headerBytes = binary.BigEndian.AppendUint32(headerBytes, uint32(params.Threads))
+	}
+	//------------------------------------------------------------
+	headerBytes = append(headerBytes, byte(len(saltBytes)))
+	//------------------------------------------------------------
+	return append(headerBytes, saltBytes...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// BenchmarkKDF
+//------------------------------------------------------------
+
+// BenchmarkKDF raises the cost of params until one derivation takes at least target on this machine
+//
+// Argon2id keeps its memory and threads and raises the time (passes), scrypt raises N, the result
+// is capped at the decrypt limits and the duration of the last derivation is also returned
+func BenchmarkKDF(params KDFParams, target time.Duration) (KDFParams, time.Duration, error) {
+	//------------------------------------------------------------
+	switch params.KDF {
+	case KDFArgon2id:
+		params.Time = 1
+	case KDFScrypt:
+		params.LogN = 10
+	}
+	//------------------------------------------------------------
+	if err := params.Validate(); err != nil {
+		return params, 0, err
+	}
+	//------------------------------------------------------------
+	saltBytes := make([]byte, PASSPHRASE_SALT_SIZE)
+	//------------------------------------------------------------
+	for {
+		//------------------------------------------------------------
+		startTime := time.Now()
+		//------------------------------------------------------------
+		if _, err := DeriveKey([]byte("benchmark"), saltBytes, params); err != nil {
+			return params, 0, err
+		}
+		//------------------------------------------------------------
+		duration := time.Since(startTime)
+		//------------------------------------------------------------
+		if duration >= target {
+			return params, duration, nil
+		}
+		//------------------------------------------------------------
+		if params.KDF == KDFArgon2id {
+			//------------------------------------------------------------
+			if params.Time >= KDF_MAX_TIME {
+				return params, duration, nil
+			}
+			//------------------------------------------------------------
+			// the time scales linearly with passes so estimate rather than step one pass at a time
+			estimate := uint32(float64(params.Time) * float64(target) / float64(max(duration, 1)))
+			params.Time = min(max(params.Time+1, estimate), KDF_MAX_TIME)
+			//------------------------------------------------------------
+		} else {
+			//------------------------------------------------------------
+			nextParams := params
+			nextParams.LogN++
+			//------------------------------------------------------------
+			if nextParams.Validate() != nil {
+				return params, duration, nil
+			}
+			//------------------------------------------------------------
+			params = nextParams
+			//------------------------------------------------------------
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,202 @@
+//------------------------------------------------------------
+
+package crypto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+var passphraseTestParams = []KDFParams{
+	{KDF: KDFArgon2id, Time: 1, Memory: 64, Threads: 1},
+	{KDF: KDFScrypt, LogN: 10, R: 8, P: 1},
+}
+
+//--------------------------------------------------
+// DeriveKey
+//--------------------------------------------------
+
+func TestDeriveKey(t *testing.T) {
+	//------------------------------------------------------------
+	// scrypt test vector from RFC 7914
+	keyBytes, err := DeriveKey([]byte("password"), []byte("NaCl"), KDFParams{KDF: KDFScrypt, LogN: 10, R: 8, P: 16})
+	//------------------------------------------------------------
+	expected := "fdbabe1c9d3472007856e7190d01e9fe7c6ad7cbc8237830e77376634b373162"
+	//------------------------------------------------------------
+	if err != nil {
+		t.Error(err)
+	} else if hex.EncodeToString(keyBytes) != expected {
+		t.Errorf("keyBytes = %x but should = %s", keyBytes, expected)
+	}
+	//------------------------------------------------------------
+	for _, params := range passphraseTestParams {
+		//------------------------------------------------------------
+		keyBytes1, _ := DeriveKey([]byte("password"), []byte("salt1234salt1234"), params)
+		keyBytes2, _ := DeriveKey([]byte("password"), []byte("salt1234salt1234"), params)
+		keyBytes3, _ := DeriveKey([]byte("password"), []byte("salt5678salt5678"), params)
+		//------------------------------------------------------------
+		if len(keyBytes1) != 32 || !bytes.Equal(keyBytes1, keyBytes2) || bytes.Equal(keyBytes1, keyBytes3) {
+			t.Errorf("%v keys should be 32 bytes, repeatable and depend on the salt", params)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	invalidParams := []KDFParams{
+		{},
+		{KDF: KDFArgon2id, Time: 0, Memory: 64, Threads: 1},
+		{KDF: KDFArgon2id, Time: 1, Memory: 4, Threads: 1},
+		{KDF: KDFArgon2id, Time: 1, Memory: KDF_MAX_MEMORY + 1, Threads: 1},
+		{KDF: KDFScrypt, LogN: KDF_MAX_SCRYPT_N + 1, R: 8, P: 1},
+		{KDF: KDFScrypt, LogN: 10, R: 0, P: 1},
+		{KDF: KDFScrypt, LogN: 21, R: 8, P: 1},
+		{KDF: KDFScrypt, LogN: 10, R: 8, P: 1 << 11},
+	}
+	//------------------------------------------------------------
+	for _, params := range invalidParams {
+		if _, err := DeriveKey([]byte("password"), []byte("salt"), params); !errors.Is(err, ErrKDFParams) {
+			t.Errorf("%v err = %v but should = %v", params, err, ErrKDFParams)
+		}
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+// EncryptPassphrase / DecryptPassphrase
+//--------------------------------------------------
+
+func TestEncryptPassphrase(t *testing.T) {
+	//------------------------------------------------------------
+	for _, params := range passphraseTestParams {
+		//------------------------------------------------------------
+		cipherString, err := EncryptPassphrase("DB_PASSWORD=secret", "correct horse", params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		//------------------------------------------------------------
+		if !strings.HasPrefix(cipherString, PASSPHRASE_PREFIX) {
+			t.Errorf("cipherString = %q should start with %q", cipherString, PASSPHRASE_PREFIX)
+		}
+		//------------------------------------------------------------
+		if result, err := DecryptPassphrase(cipherString, "correct horse"); err != nil || result != "DB_PASSWORD=secret" {
+			t.Errorf("%v result = %q, err = %v", params, result, err)
+		}
+		//------------------------------------------------------------
+		if _, err := DecryptPassphrase(cipherString, "wrong horse"); err == nil {
+			t.Errorf("%v wrong passphrase should fail", params)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	cipherString1, _ := EncryptPassphrase("test1234", "passphrase", passphraseTestParams[0])
+	cipherString2, _ := EncryptPassphrase("test1234", "passphrase", passphraseTestParams[0])
+	//------------------------------------------------------------
+	if cipherString1 == cipherString2 {
+		t.Error("encrypting twice should use different salts")
+	}
+	//------------------------------------------------------------
+	if _, err := DecryptPassphrase("enc:AAAA", "passphrase"); !errors.Is(err, ErrPassphraseInvalid) {
+		t.Errorf("err = %v but should = %v", err, ErrPassphraseInvalid)
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+
+func TestDecryptPassphraseHeader(t *testing.T) {
+	//------------------------------------------------------------
+	cipherBytes, err := EncryptPassphraseBytes([]byte("test1234"), []byte("passphrase"), passphraseTestParams[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	params, saltBytes, headerSize, err := ParsePassphraseHeader(cipherBytes)
+	//------------------------------------------------------------
+	if err != nil || params != passphraseTestParams[0] || len(saltBytes) != PASSPHRASE_SALT_SIZE || headerSize != 15+PASSPHRASE_SALT_SIZE {
+		t.Errorf("params = %v, salt = %x, headerSize = %d, err = %v", params, saltBytes, headerSize, err)
+	}
+	//------------------------------------------------------------
+	// raising the cost in the header changes the key and the AAD so must not open
+	tamperedBytes := bytes.Clone(cipherBytes)
+	tamperedBytes[5]++
+	//------------------------------------------------------------
+	if _, err := DecryptPassphraseBytes(tamperedBytes, []byte("passphrase")); err == nil {
+		t.Error("tampered header should fail")
+	}
+	//------------------------------------------------------------
+	// a crafted header asking for too much memory is refused before deriving
+	tamperedBytes = bytes.Clone(cipherBytes)
+	copy(tamperedBytes[6:10], []byte{0xff, 0xff, 0xff, 0xff})
+	//------------------------------------------------------------
+	if _, err := DecryptPassphraseBytes(tamperedBytes, []byte("passphrase")); !errors.Is(err, ErrKDFParams) {
+		t.Errorf("err = %v but should = %v", err, ErrKDFParams)
+	}
+	//------------------------------------------------------------
+	// scrypt with log2(N) 24 and r 2^20 would need petabytes
+	craftedBytes := []byte{PASSPHRASE_VERSION, KDFScrypt, 0, 0, 0, 24, 0, 0x10, 0, 0, 0, 0, 0, 1, PASSPHRASE_SALT_SIZE}
+	craftedBytes = append(craftedBytes, make([]byte, PASSPHRASE_SALT_SIZE+44)...)
+	craftedString := PASSPHRASE_PREFIX + base64.RawURLEncoding.EncodeToString(craftedBytes)
+	//------------------------------------------------------------
+	if _, err := DecryptPassphrase(craftedString, "passphrase"); !errors.Is(err, ErrKDFParams) {
+		t.Errorf("err = %v but should = %v", err, ErrKDFParams)
+	}
+	//------------------------------------------------------------
+	for _, r := range []uint32{1, 1 << 10} {
+		//------------------------------------------------------------
+		binary.BigEndian.PutUint32(craftedBytes[6:10], r)
+		//------------------------------------------------------------
+		if _, err := DecryptPassphraseBytes(craftedBytes, []byte("passphrase")); !errors.Is(err, ErrKDFParams) {
+			t.Errorf("r = %d err = %v but should = %v", r, err, ErrKDFParams)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if _, err := DecryptPassphraseBytes(cipherBytes[:20], []byte("passphrase")); err == nil {
+		t.Error("truncated data should fail")
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+// BenchmarkKDF
+//--------------------------------------------------
+
+func TestBenchmarkKDF(t *testing.T) {
+	//------------------------------------------------------------
+	for _, params := range passphraseTestParams {
+		//------------------------------------------------------------
+		resultParams, duration, err := BenchmarkKDF(params, 20*time.Millisecond)
+		//------------------------------------------------------------
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		//------------------------------------------------------------
+		if duration < 20*time.Millisecond && resultParams.Time < KDF_MAX_TIME && resultParams.LogN < KDF_MAX_SCRYPT_N {
+			t.Errorf("%v duration = %v should reach the target", resultParams, duration)
+		}
+		//------------------------------------------------------------
+		if resultParams.KDF != params.KDF || resultParams.Memory != params.Memory || resultParams.R != params.R {
+			t.Errorf("resultParams = %v should only change the cost", resultParams)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if _, _, err := BenchmarkKDF(KDFParams{}, time.Millisecond); !errors.Is(err, ErrKDFParams) {
+		t.Errorf("err = %v but should = %v", err, ErrKDFParams)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,293 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// password hashes are stored as strings in the PHC format so the algorithm and parameters travel with the hash:
+//
+//	$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>
+//
+// with the salt and hash in unpadded standard base64, bcrypt hashes use their usual modular crypt form
+// ($2a$, $2b$ or $2y$ followed by the cost), these are only produced when asked for but are always
+// verified so existing bcrypt hashes can be migrated with VerifyAndUpgradePassword
+
+const (
+	PASSWORD_ARGON2ID = "argon2id"
+	PASSWORD_BCRYPT   = "bcrypt"
+)
+
+const PASSWORD_SALT_SIZE = 16
+
+const PASSWORD_HASH_SIZE = 32
+
+var ErrPasswordMismatch = errors.New("password does not match")
+var ErrPasswordHash = errors.New("invalid password hash")
+
+//------------------------------------------------------------
+
+type PasswordParams struct {
+	Algorithm string
+	// Argon2id
+	Time    uint32
+	Memory  uint32 // KiB
+	Threads uint8
+	// bcrypt
+	Cost int
+}
+
+// DefaultPasswordParams uses the same Argon2id parameters as DefaultArgon2idParams
+var DefaultPasswordParams = PasswordParams{
+	Algorithm: PASSWORD_ARGON2ID,
+	Time:      DefaultArgon2idParams.Time,
+	Memory:    DefaultArgon2idParams.Memory,
+	Threads:   DefaultArgon2idParams.Threads,
+}
+
+var DefaultBcryptParams = PasswordParams{Algorithm: PASSWORD_BCRYPT, Cost: 12}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Validate method
+//------------------------------------------------------------
+
+func (params PasswordParams) Validate() error {
+	//------------------------------------------------------------
+	switch params.Algorithm {
+	case PASSWORD_ARGON2ID:
+		return KDFParams{KDF: KDFArgon2id, Time: params.Time, Memory: params.Memory, Threads: params.Threads}.Validate()
+	case PASSWORD_BCRYPT:
+		//------------------------------------------------------------
+		if params.Cost < bcrypt.MinCost || params.Cost > bcrypt.MaxCost {
+			return fmt.Errorf("%w: bcrypt cost must be %d to %d", ErrKDFParams, bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		//------------------------------------------------------------
+		return nil
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return fmt.Errorf("%w: unknown password algorithm %q", ErrKDFParams, params.Algorithm)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// HashPassword
+//------------------------------------------------------------
+
+// HashPassword returns a PHC string for password with a random salt (default DefaultPasswordParams)
+func HashPassword(password string, Params ...PasswordParams) (string, error) {
+	//------------------------------------------------------------
+	params := DefaultPasswordParams
+	if len(Params) > 0 {
+		params = Params[0]
+	}
+	//------------------------------------------------------------
+	if err := params.Validate(); err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	if params.Algorithm == PASSWORD_BCRYPT {
+		//------------------------------------------------------------
+		// bcrypt returns ErrPasswordTooLong rather than ignoring bytes after the 72nd
+		hashBytes, err := bcrypt.GenerateFromPassword([]byte(password), params.Cost)
+		if err != nil {
+			return "", err
+		}
+		//------------------------------------------------------------
+		return string(hashBytes), nil
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	saltBytes := make([]byte, PASSWORD_SALT_SIZE)
+	//------------------------------------------------------------
+	if _, err := rand.Read(saltBytes); err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	hashBytes := argon2.IDKey([]byte(password), saltBytes, params.Time, params.Memory, params.Threads, PASSWORD_HASH_SIZE)
+	//------------------------------------------------------------
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		PASSWORD_ARGON2ID, argon2.Version, params.Memory, params.Time, params.Threads,
+		base64.RawStdEncoding.EncodeToString(saltBytes),
+		base64.RawStdEncoding.EncodeToString(hashBytes),
+	), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// VerifyPassword
+//------------------------------------------------------------
+
+// VerifyPassword checks password against a hash from HashPassword (or a bcrypt hash),
+// returning ErrPasswordMismatch if it does not match, the comparison is constant time
+func VerifyPassword(password string, hashString string) error {
+	//------------------------------------------------------------
+	params, saltBytes, hashBytes, err := ParsePasswordHash(hashString)
+	if err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	if params.Algorithm == PASSWORD_BCRYPT {
+		//------------------------------------------------------------
+		err = bcrypt.CompareHashAndPassword([]byte(hashString), []byte(password))
+		//------------------------------------------------------------
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrPasswordMismatch
+		}
+		//------------------------------------------------------------
+		return err
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	passwordBytes := argon2.IDKey([]byte(password), saltBytes, params.Time, params.Memory, params.Threads, uint32(len(hashBytes)))
+	//------------------------------------------------------------
+	if subtle.ConstantTimeCompare(passwordBytes, hashBytes) != 1 {
+		return ErrPasswordMismatch
+	}
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// PasswordNeedsRehash
+//------------------------------------------------------------
+
+// PasswordNeedsRehash reports whether hashString uses a different algorithm or weaker parameters
+// than Params (default DefaultPasswordParams), stronger hashes are left alone
+func PasswordNeedsRehash(hashString string, Params ...PasswordParams) bool {
+	//------------------------------------------------------------
+	params := DefaultPasswordParams
+	if len(Params) > 0 {
+		params = Params[0]
+	}
+	//------------------------------------------------------------
+	hashParams, saltBytes, hashBytes, err := ParsePasswordHash(hashString)
+	if err != nil || hashParams.Algorithm != params.Algorithm {
+		return true
+	}
+	//------------------------------------------------------------
+	if hashParams.Algorithm == PASSWORD_BCRYPT {
+		return hashParams.Cost < params.Cost
+	}
+	//------------------------------------------------------------
+	return hashParams.Time < params.Time ||
+		hashParams.Memory < params.Memory ||
+		hashParams.Threads < params.Threads ||
+		len(saltBytes) < PASSWORD_SALT_SIZE ||
+		len(hashBytes) < PASSWORD_HASH_SIZE
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// VerifyAndUpgradePassword
+//------------------------------------------------------------
+
+// VerifyAndUpgradePassword verifies password and, when it matches and PasswordNeedsRehash is true,
+// returns a new hash made with Params for the caller to store in place of hashString,
+// newHash is "" when the stored hash is already current, e.g. at login:
+//
+//	newHash, err := crypto.VerifyAndUpgradePassword(password, storedHash)
+//	if err != nil { ... reject ... }
+//	if newHash != "" { ... UPDATE users SET password_hash = newHash ... }
+func VerifyAndUpgradePassword(password string, hashString string, Params ...PasswordParams) (string, error) {
+	//------------------------------------------------------------
+	if err := VerifyPassword(password, hashString); err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	if !PasswordNeedsRehash(hashString, Params...) {
+		return "", nil
+	}
+	//------------------------------------------------------------
+	return HashPassword(password, Params...)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// ParsePasswordHash
+//------------------------------------------------------------
+
+// ParsePasswordHash returns the parameters, salt and hash of hashString (salt and hash are nil for bcrypt),
+// Argon2id parameters are checked against the KDF limits so a stored hash cannot demand unbounded memory
+func ParsePasswordHash(hashString string) (PasswordParams, []byte, []byte, error) {
+	//------------------------------------------------------------
+	var params PasswordParams
+	//------------------------------------------------------------
+	if strings.HasPrefix(hashString, "$2a$") || strings.HasPrefix(hashString, "$2b$") || strings.HasPrefix(hashString, "$2y$") {
+		//------------------------------------------------------------
+		cost, err := bcrypt.Cost([]byte(hashString))
+		if err != nil {
+			return params, nil, nil, fmt.Errorf("%w: %v", ErrPasswordHash, err)
+		}
+		//------------------------------------------------------------
+		return PasswordParams{Algorithm: PASSWORD_BCRYPT, Cost: cost}, nil, nil, nil
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	// "", "argon2id", "v=19", "m=65536,t=3,p=4", salt, hash
+	hashParts := strings.Split(hashString, "$")
+	//------------------------------------------------------------
+	if len(hashParts) != 6 || hashParts[0] != "" || hashParts[1] != PASSWORD_ARGON2ID {
+		return params, nil, nil, ErrPasswordHash
+	}
+	//------------------------------------------------------------
+	if hashParts[2] != fmt.Sprintf("v=%d", argon2.Version) {
+		return params, nil, nil, fmt.Errorf("%w: unsupported Argon2 version %q", ErrPasswordHash, hashParts[2])
+	}
+	//------------------------------------------------------------
+	params.Algorithm = PASSWORD_ARGON2ID
+	//------------------------------------------------------------
+	if _, err := fmt.Sscanf(hashParts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: %v", ErrPasswordHash, err)
+	}
+	//------------------------------------------------------------
+	// Sscanf accepts leading zeros and spaces so the parameters must read back unchanged
+	if fmt.Sprintf("m=%d,t=%d,p=%d", params.Memory, params.Time, params.Threads) != hashParts[3] {
+		return params, nil, nil, ErrPasswordHash
+	}
+	//------------------------------------------------------------
+	if err := params.Validate(); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: %v", ErrPasswordHash, err)
+	}
+	//------------------------------------------------------------
+	saltBytes, err := base64.RawStdEncoding.DecodeString(hashParts[4])
+	if err != nil || len(saltBytes) < 8 {
+		return params, nil, nil, fmt.Errorf("%w: salt must be at least 8 bytes", ErrPasswordHash)
+	}
+	//------------------------------------------------------------
+	hashBytes, err := base64.RawStdEncoding.DecodeString(hashParts[5])
+	if err != nil || len(hashBytes) < 16 {
+		return params, nil, nil, fmt.Errorf("%w: hash must be at least 16 bytes", ErrPasswordHash)
+	}
+	//------------------------------------------------------------
+	return params, saltBytes, hashBytes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,169 @@
+//------------------------------------------------------------
+
+package crypto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+var passwordTestParams = []PasswordParams{
+	{Algorithm: PASSWORD_ARGON2ID, Time: 1, Memory: 64, Threads: 1},
+	{Algorithm: PASSWORD_BCRYPT, Cost: 4},
+}
+
+//--------------------------------------------------
+// HashPassword / VerifyPassword
+//--------------------------------------------------
+
+func TestHashPassword(t *testing.T) {
+	//------------------------------------------------------------
+	for _, params := range passwordTestParams {
+		//------------------------------------------------------------
+		hashString1, err := HashPassword("correct horse", params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashString2, _ := HashPassword("correct horse", params)
+		//------------------------------------------------------------
+		if hashString1 == hashString2 {
+			t.Errorf("%s hashes of the same password should differ", params.Algorithm)
+		}
+		//------------------------------------------------------------
+		if err := VerifyPassword("correct horse", hashString1); err != nil {
+			t.Errorf("%s err = %v but should = nil", params.Algorithm, err)
+		}
+		//------------------------------------------------------------
+		if err := VerifyPassword("wrong horse", hashString1); !errors.Is(err, ErrPasswordMismatch) {
+			t.Errorf("%s err = %v but should = %v", params.Algorithm, err, ErrPasswordMismatch)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	hashString, _ := HashPassword("password", passwordTestParams[0])
+	//------------------------------------------------------------
+	if !strings.HasPrefix(hashString, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("hashString = %q should be in PHC format", hashString)
+	}
+	//------------------------------------------------------------
+	if _, err := HashPassword(strings.Repeat("x", 73), passwordTestParams[1]); err == nil {
+		t.Error("bcrypt passwords over 72 bytes should be rejected")
+	}
+	//------------------------------------------------------------
+	if _, err := HashPassword("password", PasswordParams{Algorithm: "md5"}); !errors.Is(err, ErrKDFParams) {
+		t.Errorf("err = %v but should = %v", err, ErrKDFParams)
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+
+func TestVerifyPassword(t *testing.T) {
+	//------------------------------------------------------------
+	// made by the reference implementation for password "password" and salt "somesalt"
+	hashString := "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc"
+	//------------------------------------------------------------
+	if err := VerifyPassword("password", hashString); err != nil {
+		t.Errorf("err = %v but should = nil", err)
+	}
+	//------------------------------------------------------------
+	bcryptString, _ := HashPassword("password", passwordTestParams[1])
+	//------------------------------------------------------------
+	if err := VerifyPassword("password", bcryptString); err != nil {
+		t.Errorf("err = %v but should = nil", err)
+	}
+	//------------------------------------------------------------
+	invalidHashes := []string{
+		"",
+		"password",
+		"$argon2i$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+		"$argon2id$v=16$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+		"$argon2id$v=19$m=065536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+		"$argon2id$v=19$m=99999999,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+		"$argon2id$v=19$m=65536,t=2,p=1$c29tZQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+		"$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1a",
+		bcryptString[:20],
+	}
+	//------------------------------------------------------------
+	for _, invalidHash := range invalidHashes {
+		if err := VerifyPassword("password", invalidHash); err == nil || errors.Is(err, ErrPasswordMismatch) {
+			t.Errorf("%q err = %v but should be an invalid hash error", invalidHash, err)
+		}
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+// PasswordNeedsRehash
+//--------------------------------------------------
+
+func TestPasswordNeedsRehash(t *testing.T) {
+	//------------------------------------------------------------
+	argon2Hash, _ := HashPassword("password", passwordTestParams[0])
+	bcryptHash, _ := HashPassword("password", passwordTestParams[1])
+	//------------------------------------------------------------
+	testCases := []struct {
+		hashString string
+		params     PasswordParams
+		expected   bool
+	}{
+		{argon2Hash, passwordTestParams[0], false},
+		{argon2Hash, PasswordParams{Algorithm: PASSWORD_ARGON2ID, Time: 2, Memory: 64, Threads: 1}, true},
+		{argon2Hash, PasswordParams{Algorithm: PASSWORD_ARGON2ID, Time: 1, Memory: 128, Threads: 1}, true},
+		{argon2Hash, PasswordParams{Algorithm: PASSWORD_ARGON2ID, Time: 1, Memory: 32, Threads: 1}, false},
+		{argon2Hash, passwordTestParams[1], true},
+		{bcryptHash, passwordTestParams[1], false},
+		{bcryptHash, PasswordParams{Algorithm: PASSWORD_BCRYPT, Cost: 5}, true},
+		{bcryptHash, passwordTestParams[0], true},
+		{"invalid", passwordTestParams[0], true},
+	}
+	//------------------------------------------------------------
+	for index, testCase := range testCases {
+		if result := PasswordNeedsRehash(testCase.hashString, testCase.params); result != testCase.expected {
+			t.Errorf("%d result = %v but should = %v", index, result, testCase.expected)
+		}
+	}
+	//------------------------------------------------------------
+	if !PasswordNeedsRehash(argon2Hash) {
+		t.Error("hash below DefaultPasswordParams should need a rehash")
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+// VerifyAndUpgradePassword
+//--------------------------------------------------
+
+func TestVerifyAndUpgradePassword(t *testing.T) {
+	//------------------------------------------------------------
+	// a bcrypt hash is migrated to Argon2id on the next successful login
+	storedHash, _ := HashPassword("password", passwordTestParams[1])
+	//------------------------------------------------------------
+	if newHash, err := VerifyAndUpgradePassword("wrong", storedHash, passwordTestParams[0]); !errors.Is(err, ErrPasswordMismatch) || newHash != "" {
+		t.Errorf("newHash = %q, err = %v but should = \"\", %v", newHash, err, ErrPasswordMismatch)
+	}
+	//------------------------------------------------------------
+	newHash, err := VerifyAndUpgradePassword("password", storedHash, passwordTestParams[0])
+	//------------------------------------------------------------
+	if err != nil || !strings.HasPrefix(newHash, "$argon2id$") {
+		t.Fatalf("newHash = %q, err = %v", newHash, err)
+	}
+	//------------------------------------------------------------
+	if err := VerifyPassword("password", newHash); err != nil {
+		t.Errorf("err = %v but should = nil", err)
+	}
+	//------------------------------------------------------------
+	if newHash, err = VerifyAndUpgradePassword("password", newHash, passwordTestParams[0]); err != nil || newHash != "" {
+		t.Errorf("newHash = %q, err = %v but should = \"\", nil", newHash, err)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,289 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// random values use crypto/rand, characters are picked with rand.Int which rejects out of range
+// values rather than using a modulo, so every character of a set is equally likely
+
+const (
+	CHARSET_LOWER   = "abcdefghijklmnopqrstuvwxyz"
+	CHARSET_UPPER   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	CHARSET_DIGITS  = "0123456789"
+	CHARSET_SYMBOLS = "!#$%&*+-.:;=?@^_~"
+	// Crockford's base32 alphabet leaves out I, L, O and U so codes are easy to read aloud and type
+	CHARSET_RECOVERY = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+const CHARSET_ALPHANUMERIC = CHARSET_LOWER + CHARSET_UPPER + CHARSET_DIGITS
+
+var ErrCharset = errors.New("character set must have at least 2 characters and no duplicates")
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// RandomBytes
+//------------------------------------------------------------
+
+func RandomBytes(size int) ([]byte, error) {
+	//------------------------------------------------------------
+	if size < 0 {
+		return nil, errors.New("size must not be negative")
+	}
+	//------------------------------------------------------------
+	randomBytes := make([]byte, size)
+	//------------------------------------------------------------
+	if _, err := rand.Read(randomBytes); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return randomBytes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// GenerateToken
+//------------------------------------------------------------
+
+// GenerateToken returns size random bytes (default 32) as unpadded base64url, safe for URLs and headers
+func GenerateToken(Size ...int) (string, error) {
+	//------------------------------------------------------------
+	size := 32
+	if len(Size) > 0 {
+		size = Size[0]
+	}
+	//------------------------------------------------------------
+	// below 16 bytes (128 bits) a token could be guessed
+	if size < 16 {
+		return "", errors.New("token size must be at least 16 bytes")
+	}
+	//------------------------------------------------------------
+	tokenBytes, err := RandomBytes(size)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// RandomString
+//------------------------------------------------------------
+
+// RandomString returns length characters picked uniformly from charset
+func RandomString(length int, charset string) (string, error) {
+	//------------------------------------------------------------
+	if length < 0 {
+		return "", errors.New("length must not be negative")
+	}
+	//------------------------------------------------------------
+	charsetRunes, err := charsetRunes(charset)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	resultRunes := make([]rune, length)
+	//------------------------------------------------------------
+	for index := range resultRunes {
+		//------------------------------------------------------------
+		charIndex, err := randomIndex(len(charsetRunes))
+		if err != nil {
+			return "", err
+		}
+		//------------------------------------------------------------
+		resultRunes[index] = charsetRunes[charIndex]
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return string(resultRunes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// GeneratePassword
+//------------------------------------------------------------
+
+// GeneratePassword returns a password with at least one character from each of Charsets
+// (default lower case, upper case, digits and symbols), the rest are picked from all the sets combined
+func GeneratePassword(length int, Charsets ...string) (string, error) {
+	//------------------------------------------------------------
+	if len(Charsets) == 0 {
+		Charsets = []string{CHARSET_LOWER, CHARSET_UPPER, CHARSET_DIGITS, CHARSET_SYMBOLS}
+	}
+	//------------------------------------------------------------
+	if length < len(Charsets) {
+		return "", errors.New("password length must be at least the number of character sets")
+	}
+	//------------------------------------------------------------
+	passwordRunes := make([]rune, 0, length)
+	//------------------------------------------------------------
+	var combinedCharset strings.Builder
+	//------------------------------------------------------------
+	for _, charset := range Charsets {
+		//------------------------------------------------------------
+		setString, err := RandomString(1, charset)
+		if err != nil {
+			return "", err
+		}
+		//------------------------------------------------------------
+		passwordRunes = append(passwordRunes, []rune(setString)...)
+		combinedCharset.WriteString(charset)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	// sets may share characters so the combined set is checked for duplicates here
+	restString, err := RandomString(length-len(Charsets), uniqueRunes(combinedCharset.String()))
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	passwordRunes = append(passwordRunes, []rune(restString)...)
+	//------------------------------------------------------------
+	// Fisher-Yates shuffle so the required characters are not always first
+	for index := len(passwordRunes) - 1; index > 0; index-- {
+		//------------------------------------------------------------
+		swapIndex, err := randomIndex(index + 1)
+		if err != nil {
+			return "", err
+		}
+		//------------------------------------------------------------
+		passwordRunes[index], passwordRunes[swapIndex] = passwordRunes[swapIndex], passwordRunes[index]
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return string(passwordRunes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// GenerateRecoveryCodes
+//------------------------------------------------------------
+
+// GenerateRecoveryCodes returns count distinct codes such as "7KQ2M-XH4BC" (50 bits each),
+// store them as password hashes and compare input after NormalizeRecoveryCode
+func GenerateRecoveryCodes(count int) ([]string, error) {
+	//------------------------------------------------------------
+	if count < 0 {
+		return nil, errors.New("count must not be negative")
+	}
+	//------------------------------------------------------------
+	codes := make([]string, 0, count)
+	codesMap := map[string]bool{}
+	//------------------------------------------------------------
+	for len(codes) < count {
+		//------------------------------------------------------------
+		codeString, err := RandomString(10, CHARSET_RECOVERY)
+		if err != nil {
+			return nil, err
+		}
+		//------------------------------------------------------------
+		if codesMap[codeString] {
+			continue
+		}
+		codesMap[codeString] = true
+		//------------------------------------------------------------
+		codes = append(codes, codeString[:5]+"-"+codeString[5:])
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return codes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NormalizeRecoveryCode
+//------------------------------------------------------------
+
+// NormalizeRecoveryCode upper cases a typed code, removes spaces and dashes and maps the
+// look-alike letters O, I and L to 0 and 1, e.g. "7kq2m xh4bc" becomes "7KQ2M-XH4BC"
+func NormalizeRecoveryCode(codeString string) string {
+	//------------------------------------------------------------
+	codeString = strings.NewReplacer(" ", "", "-", "", "O", "0", "I", "1", "L", "1").Replace(strings.ToUpper(codeString))
+	//------------------------------------------------------------
+	if len(codeString) != 10 {
+		return codeString
+	}
+	//------------------------------------------------------------
+	return codeString[:5] + "-" + codeString[5:]
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// randomIndex
+//------------------------------------------------------------
+
+func randomIndex(max int) (int, error) {
+	//------------------------------------------------------------
+	randomInt, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		return 0, err
+	}
+	//------------------------------------------------------------
+	return int(randomInt.Int64()), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// charsetRunes
+//------------------------------------------------------------
+
+// a repeated character would be picked more often than the others
+func charsetRunes(charset string) ([]rune, error) {
+	//------------------------------------------------------------
+	runes := []rune(charset)
+	//------------------------------------------------------------
+	if len(runes) < 2 || uniqueRunes(charset) != charset {
+		return nil, ErrCharset
+	}
+	//------------------------------------------------------------
+	return runes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// uniqueRunes
+//------------------------------------------------------------
+
+func uniqueRunes(charset string) string {
+	//------------------------------------------------------------
+	var uniqueString strings.Builder
+	runesMap := map[rune]bool{}
+	//------------------------------------------------------------
+	for _, char := range charset {
+		if !runesMap[char] {
+			runesMap[char] = true
+			uniqueString.WriteRune(char)
+		}
+	}
+	//------------------------------------------------------------
+	return uniqueString.String()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
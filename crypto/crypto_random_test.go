@@ -0,0 +1,147 @@
+//------------------------------------------------------------
+
+package crypto
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//--------------------------------------------------
+// GenerateToken
+//--------------------------------------------------
+
+func TestGenerateToken(t *testing.T) {
+	//------------------------------------------------------------
+	token1, err := GenerateToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	token2, _ := GenerateToken()
+	//------------------------------------------------------------
+	tokenBytes, err := base64.RawURLEncoding.DecodeString(token1)
+	//------------------------------------------------------------
+	if err != nil || len(tokenBytes) != 32 || token1 == token2 {
+		t.Errorf("token1 = %q should be 32 random bytes in base64url", token1)
+	}
+	//------------------------------------------------------------
+	if token, _ := GenerateToken(48); len(token) != 64 {
+		t.Errorf("len(token) = %d but should = 64", len(token))
+	}
+	//------------------------------------------------------------
+	if _, err := GenerateToken(8); err == nil {
+		t.Error("token size below 16 bytes should be rejected")
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+// RandomString
+//--------------------------------------------------
+
+func TestRandomString(t *testing.T) {
+	//------------------------------------------------------------
+	// with 4 characters and 40000 picks each count should be near 10000
+	resultString, err := RandomString(40000, "abcd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	for _, char := range "abcd" {
+		if count := strings.Count(resultString, string(char)); count < 9000 || count > 11000 {
+			t.Errorf("%c count = %d but should be near 10000", char, count)
+		}
+	}
+	//------------------------------------------------------------
+	if resultString, _ = RandomString(8, "αβγδ"); len([]rune(resultString)) != 8 || strings.Trim(resultString, "αβγδ") != "" {
+		t.Errorf("resultString = %q should be 8 characters from the set", resultString)
+	}
+	//------------------------------------------------------------
+	for _, charset := range []string{"", "a", "abca"} {
+		if _, err := RandomString(8, charset); !errors.Is(err, ErrCharset) {
+			t.Errorf("%q err = %v but should = %v", charset, err, ErrCharset)
+		}
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+// GeneratePassword
+//--------------------------------------------------
+
+func TestGeneratePassword(t *testing.T) {
+	//------------------------------------------------------------
+	for count := 0; count < 50; count++ {
+		//------------------------------------------------------------
+		password, err := GeneratePassword(4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		//------------------------------------------------------------
+		for _, charset := range []string{CHARSET_LOWER, CHARSET_UPPER, CHARSET_DIGITS, CHARSET_SYMBOLS} {
+			if !strings.ContainsAny(password, charset) {
+				t.Fatalf("password %q should contain one of %q", password, charset)
+			}
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	password, err := GeneratePassword(20, CHARSET_DIGITS, "0123456789abcdef")
+	//------------------------------------------------------------
+	if err != nil || len(password) != 20 || strings.Trim(password, "0123456789abcdef") != "" || !strings.ContainsAny(password, CHARSET_DIGITS) {
+		t.Errorf("password = %q, err = %v should be 20 hex characters", password, err)
+	}
+	//------------------------------------------------------------
+	if _, err := GeneratePassword(3); err == nil {
+		t.Error("length below the number of character sets should be rejected")
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+// GenerateRecoveryCodes
+//--------------------------------------------------
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	//------------------------------------------------------------
+	codes, err := GenerateRecoveryCodes(10)
+	//------------------------------------------------------------
+	if err != nil || len(codes) != 10 {
+		t.Fatalf("len(codes) = %d, err = %v but should = 10, nil", len(codes), err)
+	}
+	//------------------------------------------------------------
+	codesMap := map[string]bool{}
+	//------------------------------------------------------------
+	for _, code := range codes {
+		//------------------------------------------------------------
+		if len(code) != 11 || code[5] != '-' || strings.Trim(strings.Replace(code, "-", "", 1), CHARSET_RECOVERY) != "" {
+			t.Errorf("code = %q should be in the form XXXXX-XXXXX", code)
+		}
+		//------------------------------------------------------------
+		if NormalizeRecoveryCode(strings.ToLower(strings.Replace(code, "-", " ", 1))) != code {
+			t.Errorf("code = %q should survive normalizing", code)
+		}
+		//------------------------------------------------------------
+		codesMap[code] = true
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if len(codesMap) != 10 {
+		t.Error("codes should be distinct")
+	}
+	//------------------------------------------------------------
+	if result := NormalizeRecoveryCode(" oi l2m-xh4bc "); result != "0112M-XH4BC" {
+		t.Errorf("result = %q but should = %q", result, "0112M-XH4BC")
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
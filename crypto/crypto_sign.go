@@ -0,0 +1,107 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+var ErrSignature = errors.New("invalid signature")
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// GenerateSigningKey
+//------------------------------------------------------------
+
+// GenerateSigningKey returns a new Ed25519 key pair
+func GenerateSigningKey() (ed25519.PublicKey, ed25519.PrivateKey, error) {
+	//------------------------------------------------------------
+	return ed25519.GenerateKey(rand.Reader)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Sign
+//------------------------------------------------------------
+
+// Sign returns the 64 byte Ed25519 signature of dataBytes
+func Sign(dataBytes []byte, privateKey ed25519.PrivateKey) ([]byte, error) {
+	//------------------------------------------------------------
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("Ed25519 private key must be 64 bytes")
+	}
+	//------------------------------------------------------------
+	return ed25519.Sign(privateKey, dataBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Verify
+//------------------------------------------------------------
+
+// Verify reports whether signatureBytes is a valid Ed25519 signature of dataBytes
+func Verify(dataBytes []byte, signatureBytes []byte, publicKey ed25519.PublicKey) bool {
+	//------------------------------------------------------------
+	if len(publicKey) != ed25519.PublicKeySize {
+		return false
+	}
+	//------------------------------------------------------------
+	return ed25519.Verify(publicKey, dataBytes, signatureBytes)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// SignString
+//------------------------------------------------------------
+
+// SignString returns the base64 signature of dataString
+func SignString(dataString string, privateKey ed25519.PrivateKey) (string, error) {
+	//------------------------------------------------------------
+	signatureBytes, err := Sign([]byte(dataString), privateKey)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return base64.StdEncoding.EncodeToString(signatureBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// VerifyString
+//------------------------------------------------------------
+
+// VerifyString checks a base64 signature from SignString, returning ErrSignature if it does not match
+func VerifyString(dataString string, signatureBase64 string, publicKey ed25519.PublicKey) error {
+	//------------------------------------------------------------
+	signatureBytes, err := base64.StdEncoding.DecodeString(signatureBase64)
+	if err != nil {
+		return ErrSignature
+	}
+	//------------------------------------------------------------
+	if !Verify([]byte(dataString), signatureBytes, publicKey) {
+		return ErrSignature
+	}
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
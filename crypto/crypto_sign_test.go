@@ -0,0 +1,91 @@
+//------------------------------------------------------------
+
+package crypto
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//--------------------------------------------------
+// Sign / Verify
+//--------------------------------------------------
+
+func TestSign(t *testing.T) {
+	//------------------------------------------------------------
+	// test 1 from RFC 8032
+	seedBytes, _ := hex.DecodeString("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60")
+	privateKey := ed25519.NewKeyFromSeed(seedBytes)
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	//------------------------------------------------------------
+	expected := "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b"
+	//------------------------------------------------------------
+	signatureBytes, err := Sign(nil, privateKey)
+	//------------------------------------------------------------
+	if err != nil {
+		t.Error(err)
+	} else if hex.EncodeToString(signatureBytes) != expected {
+		t.Errorf("signatureBytes = %x but expected %s", signatureBytes, expected)
+	}
+	//------------------------------------------------------------
+	if !Verify(nil, signatureBytes, publicKey) {
+		t.Error("signature should verify")
+	}
+	if Verify([]byte("x"), signatureBytes, publicKey) {
+		t.Error("signature of other data should not verify")
+	}
+	if Verify(nil, signatureBytes, publicKey[:31]) {
+		t.Error("short public key should not verify")
+	}
+	//------------------------------------------------------------
+	if _, err := Sign(nil, privateKey[:32]); err == nil {
+		t.Error("short private key should fail")
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+
+func TestSignString(t *testing.T) {
+	//------------------------------------------------------------
+	publicKey, privateKey, err := GenerateSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	signatureBase64, err := SignString("release-1.2.3.tar.gz", privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	if err := VerifyString("release-1.2.3.tar.gz", signatureBase64, publicKey); err != nil {
+		t.Error(err)
+	}
+	//------------------------------------------------------------
+	otherPublicKey, _, _ := GenerateSigningKey()
+	//------------------------------------------------------------
+	for _, testCase := range []struct{ dataString, signatureBase64 string }{
+		{"release-1.2.4.tar.gz", signatureBase64},
+		{"release-1.2.3.tar.gz", "!!!"},
+		{"release-1.2.3.tar.gz", signatureBase64[:20]},
+	} {
+		if err := VerifyString(testCase.dataString, testCase.signatureBase64, publicKey); !errors.Is(err, ErrSignature) {
+			t.Errorf("%q err = %v but should = %v", testCase.dataString, err, ErrSignature)
+		}
+	}
+	//------------------------------------------------------------
+	if err := VerifyString("release-1.2.3.tar.gz", signatureBase64, otherPublicKey); !errors.Is(err, ErrSignature) {
+		t.Errorf("err = %v but should = %v", err, ErrSignature)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
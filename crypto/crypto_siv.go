@@ -0,0 +1,298 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// deterministic encryption with AES-SIV (RFC 5297), the same plaintext, key and AAD always give the same
+// ciphertext so an encrypted column can be searched with WHERE column = ?, this also shows which rows
+// share a value so it should only be used for columns that need equality lookups (see BlindIndex)
+//
+//	synthetic IV (16 bytes) | AES-CTR ciphertext
+//
+// the key is split in half, the first half for S2V (AES-CMAC) and the second for CTR, so a 64 byte
+// key gives AES-256 (e.g. DeriveSubkey(masterKey, "users.email siv", 64)), 32 and 48 byte keys also work
+//
+// each AAD is a separate S2V component, e.g. the table and column names
+//
+// the text form is SIV_PREFIX followed by the unpadded base64url ciphertext
+
+const SIV_PREFIX = "siv:"
+
+const SIV_TAG_SIZE = aes.BlockSize
+
+var ErrSIVInvalid = errors.New("invalid AES-SIV ciphertext")
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// EncryptDeterministicBytes
+//------------------------------------------------------------
+
+// EncryptDeterministicBytes encrypts dataBytes with AES-SIV
+func EncryptDeterministicBytes(dataBytes []byte, key []byte, AAD ...[]byte) ([]byte, error) {
+	//------------------------------------------------------------
+	macBlock, ctrBlock, err := sivCiphers(key)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	// the plaintext is the last S2V component, RFC 5297 allows up to 126 in total
+	if len(AAD) > 125 {
+		return nil, errors.New("AES-SIV allows at most 125 AAD values")
+	}
+	//------------------------------------------------------------
+	ivBytes := sivS2V(macBlock, AAD, dataBytes)
+	//------------------------------------------------------------
+	cipherBytes := make([]byte, SIV_TAG_SIZE+len(dataBytes))
+	copy(cipherBytes, ivBytes)
+	//------------------------------------------------------------
+	cipher.NewCTR(ctrBlock, sivCounter(ivBytes)).XORKeyStream(cipherBytes[SIV_TAG_SIZE:], dataBytes)
+	//------------------------------------------------------------
+	return cipherBytes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// DecryptDeterministicBytes
+//------------------------------------------------------------
+
+// DecryptDeterministicBytes decrypts the output of EncryptDeterministicBytes, the AAD must match
+func DecryptDeterministicBytes(cipherBytes []byte, key []byte, AAD ...[]byte) ([]byte, error) {
+	//------------------------------------------------------------
+	macBlock, ctrBlock, err := sivCiphers(key)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if len(cipherBytes) < SIV_TAG_SIZE || len(AAD) > 125 {
+		return nil, ErrSIVInvalid
+	}
+	//------------------------------------------------------------
+	ivBytes := cipherBytes[:SIV_TAG_SIZE]
+	dataBytes := make([]byte, len(cipherBytes)-SIV_TAG_SIZE)
+	//------------------------------------------------------------
+	cipher.NewCTR(ctrBlock, sivCounter(ivBytes)).XORKeyStream(dataBytes, cipherBytes[SIV_TAG_SIZE:])
+	//------------------------------------------------------------
+	// the synthetic IV is the tag, the plaintext is only returned if it matches
+	if subtle.ConstantTimeCompare(sivS2V(macBlock, AAD, dataBytes), ivBytes) != 1 {
+		clear(dataBytes)
+		return nil, ErrSIVInvalid
+	}
+	//------------------------------------------------------------
+	return dataBytes, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// EncryptDeterministic
+//------------------------------------------------------------
+
+// EncryptDeterministic encrypts dataString with AES-SIV and returns the text form
+func EncryptDeterministic(dataString string, key []byte, AAD ...[]byte) (string, error) {
+	//------------------------------------------------------------
+	cipherBytes, err := EncryptDeterministicBytes([]byte(dataString), key, AAD...)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return SIV_PREFIX + base64.RawURLEncoding.EncodeToString(cipherBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// DecryptDeterministic
+//------------------------------------------------------------
+
+func DecryptDeterministic(cipherString string, key []byte, AAD ...[]byte) (string, error) {
+	//------------------------------------------------------------
+	if !strings.HasPrefix(cipherString, SIV_PREFIX) {
+		return "", ErrSIVInvalid
+	}
+	//------------------------------------------------------------
+	cipherBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(cipherString, SIV_PREFIX))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrSIVInvalid, err)
+	}
+	//------------------------------------------------------------
+	dataBytes, err := DecryptDeterministicBytes(cipherBytes, key, AAD...)
+	if err != nil {
+		return "", err
+	}
+	//------------------------------------------------------------
+	return string(dataBytes), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// sivCiphers
+//------------------------------------------------------------
+
+func sivCiphers(key []byte) (cipher.Block, cipher.Block, error) {
+	//------------------------------------------------------------
+	if len(key) != 32 && len(key) != 48 && len(key) != 64 {
+		return nil, nil, errors.New("AES-SIV key must be 32, 48 or 64 bytes")
+	}
+	//------------------------------------------------------------
+	macBlock, err := aes.NewCipher(key[:len(key)/2])
+	if err != nil {
+		return nil, nil, err
+	}
+	//------------------------------------------------------------
+	ctrBlock, err := aes.NewCipher(key[len(key)/2:])
+	if err != nil {
+		return nil, nil, err
+	}
+	//------------------------------------------------------------
+	return macBlock, ctrBlock, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// sivCounter
+//------------------------------------------------------------
+
+// the top bit of the last two 32 bit words is cleared so CTR implementations with 32 or 64 bit counters agree
+func sivCounter(ivBytes []byte) []byte {
+	//------------------------------------------------------------
+	counterBytes := append([]byte{}, ivBytes...)
+	//------------------------------------------------------------
+	counterBytes[8] &= 0x7f
+	counterBytes[12] &= 0x7f
+	//------------------------------------------------------------
+	return counterBytes
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// sivS2V
+//------------------------------------------------------------
+
+// sivS2V turns the AAD values and the plaintext into one 16 byte value (RFC 5297 section 2.4)
+func sivS2V(block cipher.Block, AAD [][]byte, dataBytes []byte) []byte {
+	//------------------------------------------------------------
+	dBytes := aesCMAC(block, make([]byte, aes.BlockSize))
+	//------------------------------------------------------------
+	for _, aadBytes := range AAD {
+		//------------------------------------------------------------
+		sivDouble(dBytes)
+		subtle.XORBytes(dBytes, dBytes, aesCMAC(block, aadBytes))
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	var tBytes []byte
+	//------------------------------------------------------------
+	if len(dataBytes) >= aes.BlockSize {
+		//------------------------------------------------------------
+		// xorend: D is XORed into the last 16 bytes of the plaintext
+		tBytes = append([]byte{}, dataBytes...)
+		tailBytes := tBytes[len(tBytes)-aes.BlockSize:]
+		subtle.XORBytes(tailBytes, tailBytes, dBytes)
+		//------------------------------------------------------------
+	} else {
+		//------------------------------------------------------------
+		sivDouble(dBytes)
+		//------------------------------------------------------------
+		tBytes = make([]byte, aes.BlockSize)
+		copy(tBytes, dataBytes)
+		tBytes[len(dataBytes)] = 0x80
+		//------------------------------------------------------------
+		subtle.XORBytes(tBytes, tBytes, dBytes)
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return aesCMAC(block, tBytes)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// sivDouble
+//------------------------------------------------------------
+
+// sivDouble multiplies a 16 byte block by x in GF(2^128) in place
+func sivDouble(blockBytes []byte) {
+	//------------------------------------------------------------
+	carry := blockBytes[0] >> 7
+	//------------------------------------------------------------
+	for index := 0; index < len(blockBytes)-1; index++ {
+		blockBytes[index] = blockBytes[index]<<1 | blockBytes[index+1]>>7
+	}
+	//------------------------------------------------------------
+	// constant time reduction by x^128 + x^7 + x^2 + x + 1
+	blockBytes[len(blockBytes)-1] = blockBytes[len(blockBytes)-1]<<1 ^ (0x87 & -carry)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// aesCMAC
+//------------------------------------------------------------
+
+// aesCMAC returns the AES-CMAC (RFC 4493) of dataBytes
+func aesCMAC(block cipher.Block, dataBytes []byte) []byte {
+	//------------------------------------------------------------
+	// subkeys: K1 = dbl(AES(0)), K2 = dbl(K1)
+	subkeyBytes := make([]byte, aes.BlockSize)
+	block.Encrypt(subkeyBytes, subkeyBytes)
+	sivDouble(subkeyBytes)
+	//------------------------------------------------------------
+	lastBytes := make([]byte, aes.BlockSize)
+	fullBlocks := len(dataBytes) / aes.BlockSize
+	//------------------------------------------------------------
+	if len(dataBytes) > 0 && len(dataBytes)%aes.BlockSize == 0 {
+		//------------------------------------------------------------
+		fullBlocks--
+		copy(lastBytes, dataBytes[fullBlocks*aes.BlockSize:])
+		//------------------------------------------------------------
+	} else {
+		//------------------------------------------------------------
+		sivDouble(subkeyBytes)
+		//------------------------------------------------------------
+		remainingBytes := dataBytes[fullBlocks*aes.BlockSize:]
+		copy(lastBytes, remainingBytes)
+		lastBytes[len(remainingBytes)] = 0x80
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	subtle.XORBytes(lastBytes, lastBytes, subkeyBytes)
+	//------------------------------------------------------------
+	macBytes := make([]byte, aes.BlockSize)
+	//------------------------------------------------------------
+	for index := 0; index < fullBlocks; index++ {
+		subtle.XORBytes(macBytes, macBytes, dataBytes[index*aes.BlockSize:(index+1)*aes.BlockSize])
+		block.Encrypt(macBytes, macBytes)
+	}
+	//------------------------------------------------------------
+	subtle.XORBytes(macBytes, macBytes, lastBytes)
+	block.Encrypt(macBytes, macBytes)
+	//------------------------------------------------------------
+	return macBytes
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -0,0 +1,151 @@
+//------------------------------------------------------------
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/timbrockley/golang-main/database/sqldb"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//--------------------------------------------------
+// aesCMAC
+//--------------------------------------------------
+
+func TestAESCMAC(t *testing.T) {
+	//------------------------------------------------------------
+	// RFC 4493 section 4
+	key, _ := hex.DecodeString("2b7e151628aed2a6abf7158809cf4f3c")
+	block, _ := aes.NewCipher(key)
+	//------------------------------------------------------------
+	testCases := map[string]string{
+		"":                                 "bb1d6929e95937287fa37d129b756746",
+		"6bc1bee22e409f96e93d7e117393172a": "070a16b46b4d4144f79bdd9dd04a287c",
+	}
+	//------------------------------------------------------------
+	for dataHex, expected := range testCases {
+		//------------------------------------------------------------
+		dataBytes, _ := hex.DecodeString(dataHex)
+		//------------------------------------------------------------
+		if result := hex.EncodeToString(aesCMAC(block, dataBytes)); result != expected {
+			t.Errorf("%q result = %s but should = %s", dataHex, result, expected)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+// EncryptDeterministicBytes / DecryptDeterministicBytes
+//--------------------------------------------------
+
+func TestEncryptDeterministicBytes(t *testing.T) {
+	//------------------------------------------------------------
+	// RFC 5297 appendix A.1
+	key, _ := hex.DecodeString("fffefdfcfbfaf9f8f7f6f5f4f3f2f1f0f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff")
+	aadBytes, _ := hex.DecodeString("101112131415161718191a1b1c1d1e1f2021222324252627")
+	dataBytes, _ := hex.DecodeString("112233445566778899aabbccddee")
+	//------------------------------------------------------------
+	expected := "85632d07c6e8f37f950acd320a2ecc9340c02b9690c4dc04daef7f6afe5c"
+	//------------------------------------------------------------
+	cipherBytes, err := EncryptDeterministicBytes(dataBytes, key, aadBytes)
+	//------------------------------------------------------------
+	if err != nil || hex.EncodeToString(cipherBytes) != expected {
+		t.Errorf("cipherBytes = %x, err = %v but should = %s", cipherBytes, err, expected)
+	}
+	//------------------------------------------------------------
+	if result, err := DecryptDeterministicBytes(cipherBytes, key, aadBytes); err != nil || !bytes.Equal(result, dataBytes) {
+		t.Errorf("result = %x, err = %v but should = %x", result, err, dataBytes)
+	}
+	//------------------------------------------------------------
+	if _, err := DecryptDeterministicBytes(cipherBytes, key); !errors.Is(err, ErrSIVInvalid) {
+		t.Errorf("err = %v but should = %v", err, ErrSIVInvalid)
+	}
+	//------------------------------------------------------------
+	cipherBytes[len(cipherBytes)-1] ^= 1
+	//------------------------------------------------------------
+	if _, err := DecryptDeterministicBytes(cipherBytes, key, aadBytes); !errors.Is(err, ErrSIVInvalid) {
+		t.Errorf("err = %v but should = %v", err, ErrSIVInvalid)
+	}
+	//------------------------------------------------------------
+	if _, err := EncryptDeterministicBytes(dataBytes, key[:16]); err == nil {
+		t.Error("16 byte key should be rejected")
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+// EncryptDeterministic / DecryptDeterministic
+//--------------------------------------------------
+
+func TestEncryptDeterministic(t *testing.T) {
+	//------------------------------------------------------------
+	masterKey, _ := GenerateKey()
+	key, _ := DeriveSubkey(masterKey, "users.email siv", 64)
+	aadBytes := []byte("users.email")
+	//------------------------------------------------------------
+	// lengths either side of the 16 byte S2V boundary
+	for _, dataString := range []string{"", "a@example.com", "abc@example.com!", "someone.else@example.com"} {
+		//------------------------------------------------------------
+		cipherString1, err := EncryptDeterministic(dataString, key, aadBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cipherString2, _ := EncryptDeterministic(dataString, key, aadBytes)
+		cipherString3, _ := EncryptDeterministic(dataString, key, []byte("users.phone"))
+		//------------------------------------------------------------
+		if cipherString1 != cipherString2 || cipherString1 == cipherString3 {
+			t.Errorf("%q ciphertext should be repeatable and depend on the AAD", dataString)
+		}
+		//------------------------------------------------------------
+		if result, err := DecryptDeterministic(cipherString1, key, aadBytes); err != nil || result != dataString {
+			t.Errorf("result = %q, err = %v but should = %q", result, err, dataString)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	for _, cipherString := range []string{"", "siv:", "siv:!!", "enc:AAAA"} {
+		if _, err := DecryptDeterministic(cipherString, key); !errors.Is(err, ErrSIVInvalid) {
+			t.Errorf("%q err = %v but should = %v", cipherString, err, ErrSIVInvalid)
+		}
+	}
+	//------------------------------------------------------------
+	// equality lookups on the encrypted column
+	conn, err := sqldb.Connect(sqldb.SQLdb{Database: ":memory:"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	//------------------------------------------------------------
+	if _, err = conn.Exec("CREATE TABLE users(id INTEGER PRIMARY KEY, email TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	for id, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		emailString, _ := EncryptDeterministic(email, key, aadBytes)
+		if _, err = conn.Exec("INSERT INTO users(id, email) VALUES(?, ?)", id+1, emailString); err != nil {
+			t.Fatal(err)
+		}
+	}
+	//------------------------------------------------------------
+	emailString, _ := EncryptDeterministic("b@example.com", key, aadBytes)
+	records, err := conn.QueryRecords("SELECT id FROM users WHERE email = ?", emailString)
+	//------------------------------------------------------------
+	if err != nil || len(records) != 1 || fmt.Sprint(records[0]["id"]) != "2" {
+		t.Errorf("records = %v, err = %v but should find id 2", records, err)
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
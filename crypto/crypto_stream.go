@@ -0,0 +1,562 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This software is licensed under the MIT License.
+
+*/
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/fslock"
+	"golang.org/x/crypto/hkdf"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+// streams use the STREAM construction so data is sealed one chunk at a time:
+//
+//	header: version (1 byte) | algorithm (1 byte) | chunk size (uint32 big endian) | salt (16 bytes)
+//	chunks: ciphertext and tag of each chunk of plaintext, every chunk is full size except the last
+//
+// a per stream key and nonce prefix are derived from the key and salt with HKDF-SHA256 (the header is the info),
+// each chunk nonce is the prefix | chunk counter (uint32 big endian) | last chunk flag (1 byte), so removing,
+// reordering or truncating chunks fails authentication
+
+const STREAM_VERSION = 1
+
+const STREAM_CHUNK_SIZE = 64 * 1024
+
+const STREAM_MAX_CHUNK_SIZE = 16 * 1024 * 1024
+
+const STREAM_SALT_SIZE = 16
+
+const streamHeaderSize = 6 + STREAM_SALT_SIZE
+
+var ErrStreamInvalid = errors.New("invalid stream header")
+var ErrStreamAuth = errors.New("stream authentication failed (corrupt, truncated or reordered)")
+var ErrStreamClosed = errors.New("stream is closed")
+
+//------------------------------------------------------------
+
+type StreamOption func(*StreamOptions)
+
+type StreamOptions struct {
+	Algorithm byte
+	ChunkSize int
+}
+
+var DefaultStreamOptions = StreamOptions{
+	Algorithm: AlgorithmAES256GCM,
+	ChunkSize: STREAM_CHUNK_SIZE,
+}
+
+//------------------------------------------------------------
+
+func WithStreamAlgorithm(algorithm byte) StreamOption {
+	return func(options *StreamOptions) { options.Algorithm = algorithm }
+}
+
+func WithChunkSize(chunkSize int) StreamOption {
+	return func(options *StreamOptions) { options.ChunkSize = chunkSize }
+}
+
+//------------------------------------------------------------
+
+func NewStreamOptions(options ...StreamOption) StreamOptions {
+	streamOptions := DefaultStreamOptions
+	for _, optionFunc := range options {
+		optionFunc(&streamOptions)
+	}
+	return streamOptions
+}
+
+//------------------------------------------------------------
+
+type streamCipher struct {
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+}
+
+type streamWriter struct {
+	writer    io.Writer
+	stream    *streamCipher
+	chunkSize int
+	buffer    []byte
+	closed    bool
+	err       error
+}
+
+type streamReader struct {
+	reader    io.Reader
+	stream    *streamCipher
+	chunkSize int
+	buffer    []byte
+	lookahead []byte
+	plain     []byte
+	done      bool
+	err       error
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// NewEncryptWriter
+//------------------------------------------------------------
+
+// NewEncryptWriter returns a writer that encrypts to writer, Close must be called to write the last chunk
+// (it does not close writer)
+func NewEncryptWriter(writer io.Writer, keyBytes []byte, options ...StreamOption) (io.WriteCloser, error) {
+	//------------------------------------------------------------
+	streamOptions := NewStreamOptions(options...)
+	//------------------------------------------------------------
+	if streamOptions.ChunkSize < 1 || streamOptions.ChunkSize > STREAM_MAX_CHUNK_SIZE {
+		return nil, fmt.Errorf("chunk size must be 1 to %d bytes", STREAM_MAX_CHUNK_SIZE)
+	}
+	//------------------------------------------------------------
+	headerBytes := make([]byte, streamHeaderSize)
+	headerBytes[0] = STREAM_VERSION
+	headerBytes[1] = streamOptions.Algorithm
+	binary.BigEndian.PutUint32(headerBytes[2:6], uint32(streamOptions.ChunkSize))
+	//------------------------------------------------------------
+	if _, err := rand.Read(headerBytes[6:]); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	stream, err := newStreamCipher(headerBytes, keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if _, err = writer.Write(headerBytes); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return &streamWriter{
+		writer:    writer,
+		stream:    stream,
+		chunkSize: streamOptions.ChunkSize,
+		buffer:    make([]byte, 0, streamOptions.ChunkSize+stream.aead.Overhead()),
+	}, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// NewDecryptReader
+//------------------------------------------------------------
+
+// NewDecryptReader returns a reader that decrypts from reader, errors are returned before any unauthenticated data
+// and io.EOF is only returned after the last chunk has been verified
+func NewDecryptReader(reader io.Reader, keyBytes []byte) (io.Reader, error) {
+	//------------------------------------------------------------
+	headerBytes := make([]byte, streamHeaderSize)
+	//------------------------------------------------------------
+	if _, err := io.ReadFull(reader, headerBytes); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrStreamInvalid, err)
+	}
+	//------------------------------------------------------------
+	if headerBytes[0] != STREAM_VERSION {
+		return nil, fmt.Errorf("%w: version %d", ErrStreamInvalid, headerBytes[0])
+	}
+	//------------------------------------------------------------
+	chunkSize := int(binary.BigEndian.Uint32(headerBytes[2:6]))
+	//------------------------------------------------------------
+	if chunkSize < 1 || chunkSize > STREAM_MAX_CHUNK_SIZE {
+		return nil, fmt.Errorf("%w: chunk size %d", ErrStreamInvalid, chunkSize)
+	}
+	//------------------------------------------------------------
+	stream, err := newStreamCipher(headerBytes, keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	// one byte more than a sealed chunk is read to find out if the chunk is the last one
+	return &streamReader{
+		reader:    reader,
+		stream:    stream,
+		chunkSize: chunkSize,
+		buffer:    make([]byte, chunkSize+stream.aead.Overhead()+1),
+	}, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// newStreamCipher
+//------------------------------------------------------------
+
+func newStreamCipher(headerBytes []byte, keyBytes []byte) (*streamCipher, error) {
+	//------------------------------------------------------------
+	nonceSize, err := algorithmNonceSize(headerBytes[1])
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if len(keyBytes) != 32 {
+		return nil, errors.New("key must be 32 bytes")
+	}
+	//------------------------------------------------------------
+	// 32 byte chunk key followed by the nonce prefix (the nonce less 4 counter bytes and the flag)
+	derivedBytes := make([]byte, 32+nonceSize-5)
+	//------------------------------------------------------------
+	if _, err = io.ReadFull(hkdf.New(sha256.New, keyBytes, headerBytes[6:], append([]byte("stream"), headerBytes[:6]...)), derivedBytes); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	cipherAEAD, err := newAEAD(headerBytes[1], derivedBytes[:32])
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return &streamCipher{aead: cipherAEAD, prefix: derivedBytes[32:]}, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// nonce method
+//------------------------------------------------------------
+
+func (stream *streamCipher) nonce(last bool) []byte {
+	//------------------------------------------------------------
+	nonceBytes := binary.BigEndian.AppendUint32(bytes.Clone(stream.prefix), stream.counter)
+	//------------------------------------------------------------
+	if last {
+		return append(nonceBytes, 1)
+	}
+	//------------------------------------------------------------
+	return append(nonceBytes, 0)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// next method
+//------------------------------------------------------------
+
+func (stream *streamCipher) next() error {
+	//------------------------------------------------------------
+	if stream.counter == math.MaxUint32 {
+		return errors.New("stream is too long for the chunk size")
+	}
+	//------------------------------------------------------------
+	stream.counter++
+	//------------------------------------------------------------
+	return nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// Write method
+//------------------------------------------------------------
+
+func (writer *streamWriter) Write(dataBytes []byte) (int, error) {
+	//------------------------------------------------------------
+	if writer.closed {
+		return 0, ErrStreamClosed
+	}
+	//------------------------------------------------------------
+	if writer.err != nil {
+		return 0, writer.err
+	}
+	//------------------------------------------------------------
+	count := 0
+	//------------------------------------------------------------
+	for len(dataBytes) > 0 {
+		//------------------------------------------------------------
+		// a full chunk is only sealed once more data arrives as the last chunk must carry the last flag
+		if len(writer.buffer) == writer.chunkSize {
+			if writer.err = writer.flush(false); writer.err != nil {
+				return count, writer.err
+			}
+		}
+		//------------------------------------------------------------
+		size := min(len(dataBytes), writer.chunkSize-len(writer.buffer))
+		//------------------------------------------------------------
+		writer.buffer = append(writer.buffer, dataBytes[:size]...)
+		dataBytes = dataBytes[size:]
+		count += size
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	return count, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Close method
+//------------------------------------------------------------
+
+func (writer *streamWriter) Close() error {
+	//------------------------------------------------------------
+	if writer.closed {
+		return writer.err
+	}
+	//------------------------------------------------------------
+	writer.closed = true
+	//------------------------------------------------------------
+	if writer.err == nil {
+		writer.err = writer.flush(true)
+	}
+	//------------------------------------------------------------
+	return writer.err
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// flush method
+//------------------------------------------------------------
+
+func (writer *streamWriter) flush(last bool) error {
+	//------------------------------------------------------------
+	sealedBytes := writer.stream.aead.Seal(writer.buffer[:0], writer.stream.nonce(last), writer.buffer, nil)
+	//------------------------------------------------------------
+	if _, err := writer.writer.Write(sealedBytes); err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	writer.buffer = writer.buffer[:0]
+	//------------------------------------------------------------
+	if last {
+		return nil
+	}
+	//------------------------------------------------------------
+	return writer.stream.next()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// Read method
+//------------------------------------------------------------
+
+func (reader *streamReader) Read(dataBytes []byte) (int, error) {
+	//------------------------------------------------------------
+	for len(reader.plain) == 0 {
+		//------------------------------------------------------------
+		if reader.err != nil {
+			return 0, reader.err
+		}
+		//------------------------------------------------------------
+		if reader.done {
+			return 0, io.EOF
+		}
+		//------------------------------------------------------------
+		reader.err = reader.readChunk()
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	count := copy(dataBytes, reader.plain)
+	reader.plain = reader.plain[count:]
+	//------------------------------------------------------------
+	return count, nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// readChunk method
+//------------------------------------------------------------
+
+func (reader *streamReader) readChunk() error {
+	//------------------------------------------------------------
+	// the look ahead byte is restored here as the previous plaintext was decrypted in place over it
+	carry := copy(reader.buffer, reader.lookahead)
+	reader.lookahead = nil
+	//------------------------------------------------------------
+	count, err := io.ReadFull(reader.reader, reader.buffer[carry:])
+	count += carry
+	//------------------------------------------------------------
+	sealedSize := len(reader.buffer) - 1
+	last := true
+	//------------------------------------------------------------
+	switch {
+	case err == nil:
+		last = false
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		sealedSize = count
+	default:
+		return err
+	}
+	//------------------------------------------------------------
+	if !last {
+		reader.lookahead = []byte{reader.buffer[sealedSize]}
+	}
+	//------------------------------------------------------------
+	plainBytes, err := reader.stream.aead.Open(reader.buffer[:0], reader.stream.nonce(last), reader.buffer[:sealedSize], nil)
+	if err != nil {
+		return ErrStreamAuth
+	}
+	//------------------------------------------------------------
+	reader.plain = plainBytes
+	//------------------------------------------------------------
+	if last {
+		reader.done = true
+		return nil
+	}
+	//------------------------------------------------------------
+	return reader.stream.next()
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//------------------------------------------------------------
+// EncryptStream
+//------------------------------------------------------------
+
+// EncryptStream encrypts dataBytes in the stream format, e.g. to save with file.FileSave
+func EncryptStream(dataBytes []byte, keyBytes []byte, options ...StreamOption) ([]byte, error) {
+	//------------------------------------------------------------
+	var outputBuffer bytes.Buffer
+	//------------------------------------------------------------
+	writer, err := NewEncryptWriter(&outputBuffer, keyBytes, options...)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if _, err = writer.Write(dataBytes); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return outputBuffer.Bytes(), nil
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// DecryptStream
+//------------------------------------------------------------
+
+// DecryptStream decrypts the output of EncryptStream, NewEncryptWriter or EncryptFile
+func DecryptStream(cipherBytes []byte, keyBytes []byte) ([]byte, error) {
+	//------------------------------------------------------------
+	reader, err := NewDecryptReader(bytes.NewReader(cipherBytes), keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	//------------------------------------------------------------
+	return io.ReadAll(reader)
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// EncryptFile
+//------------------------------------------------------------
+
+// EncryptFile encrypts srcPath to dstPath a chunk at a time
+//
+// both paths are locked in the same way as file.FileLoad and file.FileSave, callers that also use
+// file.FileMutex should hold it around the call as it is not taken here
+func EncryptFile(srcPath string, dstPath string, keyBytes []byte, options ...StreamOption) error {
+	//------------------------------------------------------------
+	return streamFile(srcPath, dstPath, func(srcFile io.Reader, dstFile io.Writer) error {
+		//------------------------------------------------------------
+		writer, err := NewEncryptWriter(dstFile, keyBytes, options...)
+		if err != nil {
+			return err
+		}
+		//------------------------------------------------------------
+		if _, err = io.Copy(writer, srcFile); err != nil {
+			return err
+		}
+		//------------------------------------------------------------
+		return writer.Close()
+		//------------------------------------------------------------
+	})
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// DecryptFile
+//------------------------------------------------------------
+
+// DecryptFile decrypts srcPath to dstPath, if any chunk fails authentication dstPath is removed
+func DecryptFile(srcPath string, dstPath string, keyBytes []byte) error {
+	//------------------------------------------------------------
+	return streamFile(srcPath, dstPath, func(srcFile io.Reader, dstFile io.Writer) error {
+		//------------------------------------------------------------
+		reader, err := NewDecryptReader(srcFile, keyBytes)
+		if err != nil {
+			return err
+		}
+		//------------------------------------------------------------
+		_, err = io.Copy(dstFile, reader)
+		//------------------------------------------------------------
+		return err
+		//------------------------------------------------------------
+	})
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// streamFile
+//------------------------------------------------------------
+
+func streamFile(srcPath string, dstPath string, streamFunc func(io.Reader, io.Writer) error) error {
+	//------------------------------------------------------------
+	srcPath = filepath.FromSlash(srcPath)
+	dstPath = filepath.FromSlash(dstPath)
+	//------------------------------------------------------------
+	if filepath.Clean(srcPath) == filepath.Clean(dstPath) {
+		return errors.New("source and destination must be different files")
+	}
+	//------------------------------------------------------------
+	srcFile, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+	//------------------------------------------------------------
+	srcLock := fslock.New(srcPath)
+	srcLock.Lock()
+	defer srcLock.Unlock()
+	//------------------------------------------------------------
+	dstLock := fslock.New(dstPath)
+	dstLock.Lock()
+	defer dstLock.Unlock()
+	//------------------------------------------------------------
+	dstFile, err := os.Create(dstPath)
+	if err != nil {
+		return err
+	}
+	//------------------------------------------------------------
+	err = streamFunc(srcFile, dstFile)
+	//------------------------------------------------------------
+	if closeErr := dstFile.Close(); err == nil {
+		err = closeErr
+	}
+	//------------------------------------------------------------
+	if err != nil {
+		os.Remove(dstPath)
+	}
+	//------------------------------------------------------------
+	return err
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
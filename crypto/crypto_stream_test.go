@@ -0,0 +1,212 @@
+//------------------------------------------------------------
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+	"testing/iotest"
+
+	"github.com/timbrockley/golang-main/file"
+)
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
+
+//--------------------------------------------------
+// EncryptStream / DecryptStream
+//--------------------------------------------------
+
+func TestStream(t *testing.T) {
+	//------------------------------------------------------------
+	keyBytes, _ := GenerateKey()
+	//------------------------------------------------------------
+	for _, size := range []int{0, 1, 15, 16, 17, 32, 33, 1000} {
+		//------------------------------------------------------------
+		dataBytes := make([]byte, size)
+		rand.Read(dataBytes)
+		//------------------------------------------------------------
+		cipherBytes, err := EncryptStream(dataBytes, keyBytes, WithChunkSize(16))
+		if err != nil {
+			t.Fatal(err)
+		}
+		//------------------------------------------------------------
+		// every chunk is sealed separately, the last chunk may be empty only if there is no data
+		chunkCount := max((size+15)/16, 1)
+		if expected := streamHeaderSize + size + chunkCount*16; len(cipherBytes) != expected {
+			t.Errorf("size %d len(cipherBytes) = %d but should = %d", size, len(cipherBytes), expected)
+		}
+		//------------------------------------------------------------
+		if result, err := DecryptStream(cipherBytes, keyBytes); err != nil || !bytes.Equal(result, dataBytes) {
+			t.Errorf("size %d result = %x, err = %v", size, result, err)
+		}
+		//------------------------------------------------------------
+		// readers returning one byte at a time must see the same result
+		reader, _ := NewDecryptReader(iotest.OneByteReader(bytes.NewReader(cipherBytes)), keyBytes)
+		if result, err := io.ReadAll(iotest.OneByteReader(reader)); err != nil || !bytes.Equal(result, dataBytes) {
+			t.Errorf("size %d one byte result = %x, err = %v", size, result, err)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+
+func TestStreamWriter(t *testing.T) {
+	//------------------------------------------------------------
+	keyBytes, _ := GenerateKey()
+	//------------------------------------------------------------
+	var outputBuffer bytes.Buffer
+	//------------------------------------------------------------
+	writer, err := NewEncryptWriter(&outputBuffer, keyBytes, WithChunkSize(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	for _, part := range []string{"abc", "defghij", "klmnopqrstuvwxyz", "", "0123"} {
+		if count, err := writer.Write([]byte(part)); err != nil || count != len(part) {
+			t.Errorf("count = %d, err = %v", count, err)
+		}
+	}
+	//------------------------------------------------------------
+	if err = writer.Close(); err != nil {
+		t.Error(err)
+	}
+	if _, err = writer.Write([]byte("x")); !errors.Is(err, ErrStreamClosed) {
+		t.Errorf("err = %v but should = %v", err, ErrStreamClosed)
+	}
+	//------------------------------------------------------------
+	if result, err := DecryptStream(outputBuffer.Bytes(), keyBytes); err != nil || string(result) != "abcdefghijklmnopqrstuvwxyz0123" {
+		t.Errorf("result = %q, err = %v", result, err)
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+
+func TestStreamTamper(t *testing.T) {
+	//------------------------------------------------------------
+	keyBytes, _ := GenerateKey()
+	//------------------------------------------------------------
+	dataBytes := bytes.Repeat([]byte("0123456789abcdef"), 4)
+	//------------------------------------------------------------
+	// 64 bytes in 16 byte chunks gives 4 sealed chunks of 32 bytes
+	cipherBytes, _ := EncryptStream(dataBytes, keyBytes, WithChunkSize(16))
+	chunk := func(index int) []byte {
+		return cipherBytes[streamHeaderSize+index*32 : streamHeaderSize+(index+1)*32]
+	}
+	//------------------------------------------------------------
+	testCases := map[string][]byte{
+		"truncated at chunk": cipherBytes[:streamHeaderSize+3*32],
+		"truncated in chunk": cipherBytes[:len(cipherBytes)-1],
+		"no chunks":          cipherBytes[:streamHeaderSize],
+		"reordered":          bytes.Join([][]byte{cipherBytes[:streamHeaderSize], chunk(1), chunk(0), chunk(2), chunk(3)}, nil),
+		"chunk dropped":      bytes.Join([][]byte{cipherBytes[:streamHeaderSize], chunk(0), chunk(2), chunk(3)}, nil),
+		"appended":           append(bytes.Clone(cipherBytes), chunk(3)...),
+	}
+	//------------------------------------------------------------
+	for index := range cipherBytes {
+		tamperedBytes := bytes.Clone(cipherBytes)
+		tamperedBytes[index] ^= 1
+		testCases[fmt.Sprintf("byte %d", index)] = tamperedBytes
+	}
+	//------------------------------------------------------------
+	for name, tamperedBytes := range testCases {
+		if _, err := DecryptStream(tamperedBytes, keyBytes); err == nil {
+			t.Errorf("%q should fail", name)
+		}
+	}
+	//------------------------------------------------------------
+	// data before a bad chunk is still returned, but the error always comes before io.EOF
+	reader, _ := NewDecryptReader(bytes.NewReader(cipherBytes[:streamHeaderSize+3*32]), keyBytes)
+	result, err := io.ReadAll(reader)
+	//------------------------------------------------------------
+	if !errors.Is(err, ErrStreamAuth) || !bytes.Equal(result, dataBytes[:32]) {
+		t.Errorf("result = %q, err = %v", result, err)
+	}
+	//------------------------------------------------------------
+	otherKeyBytes, _ := GenerateKey()
+	//------------------------------------------------------------
+	if _, err := DecryptStream(cipherBytes, otherKeyBytes); !errors.Is(err, ErrStreamAuth) {
+		t.Errorf("err = %v but should = %v", err, ErrStreamAuth)
+	}
+	//------------------------------------------------------------
+	if _, err := DecryptStream(cipherBytes[:10], keyBytes); !errors.Is(err, ErrStreamInvalid) {
+		t.Errorf("err = %v but should = %v", err, ErrStreamInvalid)
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------
+// EncryptFile / DecryptFile
+//--------------------------------------------------
+
+func TestEncryptFile(t *testing.T) {
+	//------------------------------------------------------------
+	keyBytes, _ := GenerateKey()
+	//------------------------------------------------------------
+	tempPath := t.TempDir()
+	plainPath := filepath.Join(tempPath, "plain.txt")
+	cipherPath := filepath.Join(tempPath, "plain.txt.enc")
+	resultPath := filepath.Join(tempPath, "result.txt")
+	//------------------------------------------------------------
+	dataString := string(bytes.Repeat([]byte("backup line\n"), 10000))
+	//------------------------------------------------------------
+	file.FileMutex.Lock()
+	//------------------------------------------------------------
+	if err := file.FileSave(plainPath, dataString); err != nil {
+		t.Fatal(err)
+	}
+	if err := EncryptFile(plainPath, cipherPath, keyBytes); err != nil {
+		t.Fatal(err)
+	}
+	if err := DecryptFile(cipherPath, resultPath, keyBytes); err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------------------------
+	file.FileMutex.Unlock()
+	//------------------------------------------------------------
+	if result, err := file.FileLoad(resultPath); err != nil || result != dataString {
+		t.Errorf("len(result) = %d, err = %v", len(result), err)
+	}
+	//------------------------------------------------------------
+	// files written with file.FileSave open with DecryptStream and the reverse
+	cipherString, _ := file.FileLoad(cipherPath)
+	if result, err := DecryptStream([]byte(cipherString), keyBytes); err != nil || string(result) != dataString {
+		t.Errorf("len(result) = %d, err = %v", len(result), err)
+	}
+	//------------------------------------------------------------
+	cipherBytes, _ := EncryptStream([]byte("saved"), keyBytes)
+	file.FileSave(cipherPath, string(cipherBytes))
+	//------------------------------------------------------------
+	if err := DecryptFile(cipherPath, resultPath, keyBytes); err != nil {
+		t.Error(err)
+	} else if result, _ := file.FileLoad(resultPath); result != "saved" {
+		t.Errorf("result = %q but should = %q", result, "saved")
+	}
+	//------------------------------------------------------------
+	// a failed decrypt leaves no partial plaintext behind
+	file.FileSave(cipherPath, string(cipherBytes[:len(cipherBytes)-1]))
+	//------------------------------------------------------------
+	if err := DecryptFile(cipherPath, resultPath, keyBytes); !errors.Is(err, ErrStreamAuth) {
+		t.Errorf("err = %v but should = %v", err, ErrStreamAuth)
+	} else if file.FilePathExists(resultPath) {
+		t.Error("resultPath should be removed")
+	}
+	//------------------------------------------------------------
+	if err := EncryptFile(plainPath, plainPath, keyBytes); err == nil {
+		t.Error("same source and destination should fail")
+	}
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+//############################################################
+//------------------------------------------------------------
@@ -351,6 +351,21 @@ func (conn *SQLdb) Close() error {
 	//------------------------------------------------------------
 }
 
+//------------------------------------------------------------
+// Placeholder method
+//------------------------------------------------------------
+
+// Placeholder returns the bind parameter for the 1-based index ("$1" for postgres, "?" otherwise)
+func (conn *SQLdb) Placeholder(index int) string {
+	//------------------------------------------------------------
+	if strings.ToLower(conn.DBType) == "postgres" {
+		return fmt.Sprintf("$%d", index)
+	}
+	//------------------------------------------------------------
+	return "?"
+	//------------------------------------------------------------
+}
+
 //------------------------------------------------------------
 //############################################################
 //------------------------------------------------------------
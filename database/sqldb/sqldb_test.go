@@ -973,6 +973,35 @@ func TestCheckTableName(t *testing.T) {
 //################################################################################
 //--------------------------------------------------------------------------------
 
+func TestPlaceholder(t *testing.T) {
+	//------------------------------------------------------------
+	testCases := []struct {
+		dbType   string
+		index    int
+		expected string
+	}{
+		{"mysql", 1, "?"},
+		{"postgres", 2, "$2"},
+		{"sqlite", 3, "?"},
+		{"", 1, "?"},
+	}
+	//------------------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------
+		conn := SQLdb{DBType: testCase.dbType}
+		//--------------------
+		if result := conn.Placeholder(testCase.index); result != testCase.expected {
+			t.Errorf("%s result = %s but should = %s", testCase.dbType, result, testCase.expected)
+		}
+		//--------------------
+	}
+	//------------------------------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+//################################################################################
+//--------------------------------------------------------------------------------
+
 func TestNullStringToString(t *testing.T) {
 	//------------------------------------------------------------
 	var data1, data2 sql.NullString
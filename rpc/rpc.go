@@ -48,6 +48,8 @@ type RPCStruct struct {
 	//--------------------
 	Encoding string
 	//--------------------
+	Claims map[string]any
+	//--------------------
 }
 
 //--------------------------------------------------------------------------------
@@ -794,28 +796,82 @@ func GetRemoteIPAddr(httpRequest *http.Request) string {
 //################################################################################
 //--------------------------------------------------------------------------------
 
+//--------------------------------------------------------------------------------
+// rpcCodecs
+//--------------------------------------------------------------------------------
+
+// "obfuscate" is the rpc XOR obfuscation (base64 encoded) which has used that name on the wire,
+// these codecs are only seen by EncodeData and DecodeData and are tried before the conv registry
+var rpcCodecs = map[string]conv.Codec{
+	"obfuscate": conv.NewCodec("obfuscate",
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := ObfuscateData(string(dataBytes), true, false)
+			return []byte(dataString), err
+		},
+		func(dataBytes []byte) ([]byte, error) {
+			dataString, err := ObfuscateData(string(dataBytes), false, true)
+			return []byte(dataString), err
+		},
+	),
+}
+
+//--------------------------------------------------------------------------------
+// getCodecs
+//--------------------------------------------------------------------------------
+
+// getCodecs returns the codecs of a pipeline such as "obfuscate|base64" in encoding order
+func getCodecs(encoding string) ([]conv.Codec, error) {
+	//--------------------------------------------------
+	names := strings.Split(encoding, conv.PIPELINE_SEPARATOR)
+	codecs := make([]conv.Codec, 0, len(names))
+	//--------------------------------------------------
+	for _, name := range names {
+		//--------------------------------------------------
+		name = strings.TrimSpace(name)
+		//--------------------------------------------------
+		if codec, exists := rpcCodecs[name]; exists {
+			codecs = append(codecs, codec)
+			continue
+		}
+		//--------------------------------------------------
+		codec, err := conv.GetCodec(name)
+		if err != nil || name == "" {
+			return nil, errors.New("invalid encoding")
+		}
+		codecs = append(codecs, codec)
+		//--------------------------------------------------
+	}
+	//--------------------------------------------------
+	return codecs, nil
+	//--------------------------------------------------
+}
+
 //--------------------------------------------------------------------------------
 // EncodeData
 //--------------------------------------------------------------------------------
 
+// encoding names a codec or a pipeline of codecs, e.g. "obfuscate", "base64" or "hex|base64url",
+// "obfuscate" is the rpc scheme and other names are looked up in the conv codec registry
 func EncodeData(dataString string, encoding string) (string, error) {
 	//--------------------------------------------------
 	if dataString == "" || encoding == "" {
 		return dataString, nil
 	}
 	//--------------------------------------------------
-	switch encoding {
-	case "obfuscate":
-		return ObfuscateData(dataString, true, false)
-	case "base64":
-		dataString = conv.Base64_encode(dataString)
-	case "base64url":
-		dataString = conv.Base64url_encode(dataString)
-	default:
-		return "", errors.New("invalid encoding")
+	codecs, err := getCodecs(encoding)
+	if err != nil {
+		return "", err
 	}
 	//--------------------------------------------------
-	return dataString, nil
+	dataBytes := []byte(dataString)
+	//--------------------------------------------------
+	for _, codec := range codecs {
+		if dataBytes, err = codec.Encode(dataBytes); err != nil {
+			return "", err
+		}
+	}
+	//--------------------------------------------------
+	return string(dataBytes), nil
 	//--------------------------------------------------
 }
 
@@ -823,23 +879,28 @@ func EncodeData(dataString string, encoding string) (string, error) {
 // DecodeData
 //--------------------------------------------------------------------------------
 
+// encoding is the same name or pipeline given to EncodeData, a pipeline is decoded right to left
 func DecodeData(dataString string, encoding string) (string, error) {
 	//--------------------------------------------------
 	if dataString == "" || encoding == "" {
 		return dataString, nil
 	}
 	//--------------------------------------------------
-	switch encoding {
-	case "obfuscate":
-		return ObfuscateData(dataString, false, true)
-	case "base64":
-		return conv.Base64_decode(dataString)
-	case "base64url":
-		return conv.Base64url_decode(dataString)
-	default:
-		return "", errors.New("invalid encoding")
+	codecs, err := getCodecs(encoding)
+	if err != nil {
+		return "", err
+	}
+	//--------------------------------------------------
+	dataBytes := []byte(dataString)
+	//--------------------------------------------------
+	for index := len(codecs) - 1; index >= 0; index-- {
+		if dataBytes, err = codecs[index].Decode(dataBytes); err != nil {
+			return "", err
+		}
 	}
 	//--------------------------------------------------
+	return string(dataBytes), nil
+	//--------------------------------------------------
 }
 
 //--------------------------------------------------------------------------------
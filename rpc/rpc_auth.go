@@ -0,0 +1,126 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/timbrockley/golang-main/crypto"
+)
+
+//--------------------------------------------------------------------------------
+
+var ErrNoBearerToken = errors.New("bearer token is missing")
+
+//--------------------------------------------------------------------------------
+//################################################################################
+//--------------------------------------------------------------------------------
+
+//--------------------------------------------------------------------------------
+// GetBearerToken
+//--------------------------------------------------------------------------------
+
+// GetBearerToken returns the token from an "Authorization: Bearer <token>" header or "" if there is none
+func GetBearerToken(httpRequest *http.Request) string {
+	//--------------------------------------------------
+	scheme, token, found := strings.Cut(strings.TrimSpace(httpRequest.Header.Get("Authorization")), " ")
+	//--------------------------------------------------
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	//--------------------------------------------------
+	return strings.TrimSpace(token)
+	//--------------------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+// RPC_authenticate
+//--------------------------------------------------------------------------------
+
+// RPC_authenticate verifies the bearer token of the request and stores its claims in rpcInstance.Claims
+func (rpcInstance *RPCStruct) RPC_authenticate(keys []crypto.JWTKey, options ...crypto.JWTOption) error {
+	//--------------------------------------------------
+	rpcInstance.Claims = nil
+	//--------------------------------------------------
+	token := GetBearerToken(rpcInstance.HttpRequest)
+	//--------------------
+	if token == "" {
+		return ErrNoBearerToken
+	}
+	//--------------------------------------------------
+	claims, err := crypto.VerifyJWT(token, keys, options...)
+	//--------------------
+	if err != nil {
+		return err
+	}
+	//--------------------------------------------------
+	rpcInstance.Claims = claims
+	//--------------------------------------------------
+	return nil
+	//--------------------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+// RPC_send_unauthorized
+//--------------------------------------------------------------------------------
+
+// RPC_send_unauthorized sends a 401 status with a WWW-Authenticate challenge and a json error
+func (rpcInstance *RPCStruct) RPC_send_unauthorized(errorString string) {
+	//--------------------------------------------------
+	if rpcInstance.ResponseHeadersMap == nil {
+		rpcInstance.ResponseHeadersMap = map[string]string{}
+	}
+	//--------------------
+	if rpcInstance.ResponseHeadersMap["Content-Type"] == "" {
+		rpcInstance.ResponseHeadersMap["Content-Type"] = ContentTypeJSON
+	}
+	//--------------------
+	rpcInstance.ResponseHeadersMap["WWW-Authenticate"] = `Bearer error="invalid_token"`
+	//--------------------------------------------------
+	// headers must be set before the status is written
+	for headerKey, headerValue := range rpcInstance.ResponseHeadersMap {
+		rpcInstance.ResponseWriter.Header().Set(headerKey, headerValue)
+	}
+	//--------------------
+	rpcInstance.ResponseWriter.WriteHeader(http.StatusUnauthorized)
+	//--------------------------------------------------
+	rpcInstance.RPC_send_error_response(errorString)
+	//--------------------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+// JWKS_Handler
+//--------------------------------------------------------------------------------
+
+// JWKS_Handler returns a handler publishing the public keys of keys as a JWK set, e.g. at /.well-known/jwks.json
+func JWKS_Handler(keys []crypto.JWTKey) (http.HandlerFunc, error) {
+	//--------------------------------------------------
+	jwksString, err := crypto.BuildJWKS(keys)
+	//--------------------
+	if err != nil {
+		return nil, err
+	}
+	//--------------------------------------------------
+	return func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		//--------------------
+		responseWriter.Header().Set("Content-Type", "application/jwk-set+json")
+		//--------------------
+		fmt.Fprint(responseWriter, jwksString)
+		//--------------------
+	}, nil
+	//--------------------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+//################################################################################
+//--------------------------------------------------------------------------------
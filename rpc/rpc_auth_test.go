@@ -0,0 +1,138 @@
+//--------------------------------------------------------------------------------
+
+package rpc
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/timbrockley/golang-main/crypto"
+)
+
+//--------------------------------------------------------------------------------
+//################################################################################
+//--------------------------------------------------------------------------------
+
+//--------------------------------------------------------------------------------
+// GetBearerToken
+//--------------------------------------------------------------------------------
+
+func TestGetBearerToken(t *testing.T) {
+	//--------------------------------------------------
+	testCases := map[string]string{
+		"":                     "",
+		"Bearer abc.def.ghi":   "abc.def.ghi",
+		"bearer  abc.def.ghi ": "abc.def.ghi",
+		"Basic dXNlcjpwYXNz":   "",
+		"Bearer":               "",
+	}
+	//--------------------------------------------------
+	for header, expected := range testCases {
+		//--------------------
+		httpRequest := httptest.NewRequest("POST", "/rpc", nil)
+		httpRequest.Header.Set("Authorization", header)
+		//--------------------
+		if result := GetBearerToken(httpRequest); result != expected {
+			t.Errorf("%q result = %q but should = %q", header, result, expected)
+		}
+		//--------------------
+	}
+	//--------------------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+// RPC_authenticate
+//--------------------------------------------------------------------------------
+
+func TestRPC_authenticate(t *testing.T) {
+	//--------------------------------------------------
+	_, privateKey, _ := crypto.GenerateSigningKey()
+	signingKey := crypto.JWTKey{KeyID: "ed-1", Algorithm: crypto.JWT_EDDSA, Key: privateKey}
+	//--------------------------------------------------
+	// callers only need the published key set to verify tokens
+	jwksHandler, err := JWKS_Handler([]crypto.JWTKey{signingKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//--------------------
+	jwksServer := httptest.NewServer(jwksHandler)
+	defer jwksServer.Close()
+	//--------------------
+	response, err := http.Get(jwksServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwksBytes, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	//--------------------
+	keys, err := crypto.ParseJWKS(string(jwksBytes))
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("keys = %v, err = %v", keys, err)
+	}
+	//--------------------------------------------------
+	server := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		//--------------------
+		rpcInstance := RPCStruct{ResponseWriter: responseWriter, HttpRequest: httpRequest, ResponseHeadersMap: map[string]string{}}
+		//--------------------
+		if err := rpcInstance.RPC_authenticate(keys, crypto.WithAudience("rpc")); err != nil {
+			rpcInstance.RPC_send_unauthorized(err.Error())
+			return
+		}
+		//--------------------
+		rpcInstance.RPC_send_result_response(rpcInstance.Claims["sub"])
+		//--------------------
+	}))
+	defer server.Close()
+	//--------------------------------------------------
+	validToken, _ := crypto.SignJWT(crypto.NewJWTClaims("auth", "user-1", "rpc", time.Minute), signingKey)
+	otherToken, _ := crypto.SignJWT(crypto.NewJWTClaims("auth", "user-1", "web", time.Minute), signingKey)
+	//--------------------------------------------------
+	testCases := []struct {
+		token          string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{validToken, http.StatusOK, `{"result":"user-1"}`},
+		{otherToken, http.StatusUnauthorized, `{"error":"token audience is not accepted"}`},
+		{"", http.StatusUnauthorized, `{"error":"bearer token is missing"}`},
+	}
+	//--------------------------------------------------
+	for _, testCase := range testCases {
+		//--------------------
+		httpRequest, _ := http.NewRequest("POST", server.URL, nil)
+		if testCase.token != "" {
+			httpRequest.Header.Set("Authorization", "Bearer "+testCase.token)
+		}
+		//--------------------
+		response, err := http.DefaultClient.Do(httpRequest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bodyBytes, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		//--------------------
+		if response.StatusCode != testCase.expectedStatus || string(bodyBytes) != testCase.expectedBody {
+			t.Errorf("status = %d, body = %s but should = %d, %s", response.StatusCode, bodyBytes, testCase.expectedStatus, testCase.expectedBody)
+		}
+		//--------------------
+		if testCase.expectedStatus == http.StatusUnauthorized && response.Header.Get("WWW-Authenticate") == "" {
+			t.Error("WWW-Authenticate header should be set")
+		}
+		//--------------------
+	}
+	//--------------------------------------------------
+	rpcInstance := RPCStruct{HttpRequest: httptest.NewRequest("POST", "/rpc", nil)}
+	//--------------------
+	if err := rpcInstance.RPC_authenticate(keys); !errors.Is(err, ErrNoBearerToken) {
+		t.Errorf("err = %v but should = %v", err, ErrNoBearerToken)
+	}
+	//--------------------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+//################################################################################
+//--------------------------------------------------------------------------------
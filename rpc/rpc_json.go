@@ -310,14 +310,19 @@ func (jsonrpc *RPC_jsonrpcStruct) RPC_encode_jsonrpc_parse_error_response(Data .
 // RPC_decode_json
 //--------------------------------------------------------------------------------
 
-func RPC_decode_json(jsonString string) (map[string]any, error) {
+// options are passed to conv.JSON_DecodeInto, e.g. conv.WithJSONNumberMode(conv.JSONNumberInt64) keeps 64-bit IDs exact
+func RPC_decode_json(jsonString string, options ...conv.JSONDecodeOption) (map[string]any, error) {
 	//--------------------------------------------------
 	var err error
 	var jsonInterface any
 	//--------------------------------------------------
 	jsonMap := map[string]any{}
 	//--------------------------------------------------
-	jsonInterface, err = conv.JSON_decode(jsonString)
+	if len(options) > 0 {
+		jsonInterface, err = conv.JSON_DecodeInto[any](jsonString, options...)
+	} else {
+		jsonInterface, err = conv.JSON_decode(jsonString)
+	}
 	//--------------------------------------------------
 	if err == nil {
 
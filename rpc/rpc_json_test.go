@@ -5,6 +5,8 @@ package rpc
 import (
 	"fmt"
 	"testing"
+
+	"github.com/timbrockley/golang-main/conv"
 )
 
 //--------------------------------------------------------------------------------
@@ -514,6 +516,24 @@ func TestRPC_decode_json(t *testing.T) {
 		}
 	}
 	//--------------------------------------------------
+	result, err = RPC_decode_json(`{"id":9007199254740993}`, conv.WithJSONNumberMode(conv.JSONNumberInt64))
+	//--------------------------------------------------
+	if err != nil {
+		t.Error(err)
+	} else if result["id"] != int64(9007199254740993) {
+		t.Errorf("id = %#v but should = int64(9007199254740993)", result["id"])
+	}
+	//--------------------------------------------------
+	// trailing data is rejected with or without options
+	for _, jsonString := range []string{`{"id":1} xyz`, `{"id":1}{"id":2}`} {
+		if _, err = RPC_decode_json(jsonString); err == nil {
+			t.Errorf("(%q) returned error is nil but should return an error", jsonString)
+		}
+		if _, err = RPC_decode_json(jsonString, conv.WithJSONNumberMode(conv.JSONNumberInt64)); err == nil {
+			t.Errorf("(%q) returned error is nil but should return an error", jsonString)
+		}
+	}
+	//--------------------------------------------------
 }
 
 //--------------------------------------------------------------------------------
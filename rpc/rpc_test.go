@@ -1591,6 +1591,10 @@ func TestEncodeData(t *testing.T) {
 		{"ABCD", "obfuscate", "6+jp7g==", ""},
 		{"ABCD", "base64", "QUJDRA==", ""},
 		{"ABCD", "base64url", "QUJDRA", ""},
+		{"ABCD", "hex", "41424344", ""},
+		{"ABCD", "obfuscate|base64", "NitqcDdnPT0=", ""},
+		{"ABCD", "conv_obfuscate|base64", "XVxbWg==", ""},
+		{"ABCD", "base64|", "", "invalid encoding"},
 	}
 	//--------------------------------------------------
 	for _, test := range testData {
@@ -1642,6 +1646,10 @@ func TestDecodeData(t *testing.T) {
 		{"6+jp7g==", "obfuscate", "ABCD", ""},
 		{"QUJDRA==", "base64", "ABCD", ""},
 		{"QUJDRA", "base64url", "ABCD", ""},
+		{"41424344", "hex", "ABCD", ""},
+		{"NitqcDdnPT0=", "obfuscate|base64", "ABCD", ""},
+		{"XVxbWg==", "conv_obfuscate|base64", "ABCD", ""},
+		{"6+jp7g==", "|obfuscate", "", "invalid encoding"},
 	}
 	//--------------------------------------------------
 	for _, test := range testData {
@@ -1672,6 +1680,11 @@ func TestDecodeData(t *testing.T) {
 		//--------------------------------------------------
 	}
 	//--------------------------------------------------
+	// the rpc scheme is private to EncodeData and DecodeData, other packages do not see it
+	if _, err := conv.GetCodec("obfuscate"); err == nil {
+		t.Error("conv.GetCodec(\"obfuscate\") should not find the rpc codec")
+	}
+	//--------------------------------------------------
 }
 
 //----------------------------------------------------------------------
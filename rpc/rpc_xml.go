@@ -0,0 +1,67 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package rpc
+
+import (
+	"errors"
+
+	"github.com/timbrockley/golang-main/conv"
+)
+
+//--------------------------------------------------------------------------------
+
+// XML payloads are wrapped in this root element, e.g. <rpc><method>echo</method></rpc>
+const RPC_XML_ROOT = "rpc"
+
+//--------------------------------------------------------------------------------
+//################################################################################
+//--------------------------------------------------------------------------------
+
+//--------------------------------------------------------------------------------
+// RPC_encode_xml
+//--------------------------------------------------------------------------------
+
+// options are passed to conv.XML_encode, the root element defaults to RPC_XML_ROOT
+func RPC_encode_xml(xmlMap map[string]any, options ...conv.XMLOption) (string, error) {
+	//--------------------------------------------------
+	return conv.XML_encode(xmlMap, append([]conv.XMLOption{conv.WithXMLRoot(RPC_XML_ROOT)}, options...)...)
+	//--------------------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+// RPC_decode_xml
+//--------------------------------------------------------------------------------
+
+// options are passed to conv.XML_decode, e.g. conv.WithXMLInferTypes(true) decodes numbers and booleans
+func RPC_decode_xml(xmlString string, options ...conv.XMLOption) (map[string]any, error) {
+	//--------------------------------------------------
+	xmlMap := map[string]any{}
+	//--------------------------------------------------
+	xmlInterface, err := conv.XML_decode(xmlString, append([]conv.XMLOption{conv.WithXMLRoot(RPC_XML_ROOT)}, options...)...)
+	//--------------------------------------------------
+	if err == nil {
+
+		if isObject(xmlInterface) {
+
+			xmlMap = xmlInterface.(map[string]any)
+
+		} else {
+
+			err = errors.New("parse error")
+		}
+	}
+	//--------------------------------------------------
+	return xmlMap, err
+	//--------------------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+//################################################################################
+//--------------------------------------------------------------------------------
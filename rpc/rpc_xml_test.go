@@ -0,0 +1,65 @@
+//--------------------------------------------------------------------------------
+
+package rpc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/timbrockley/golang-main/conv"
+)
+
+//--------------------------------------------------------------------------------
+//################################################################################
+//--------------------------------------------------------------------------------
+
+//--------------------------------------------------------------------------------
+// RPC_encode_xml
+//--------------------------------------------------------------------------------
+
+func TestRPC_encode_xml(t *testing.T) {
+	//--------------------------------------------------
+	XML_MAP := map[string]any{"method": "echo", "params": []any{"a", "b"}}
+	EXPECTED_RESULT := `<rpc><method>echo</method><params>a</params><params>b</params></rpc>`
+	//----------------------------------------
+	result, err := RPC_encode_xml(XML_MAP)
+	//--------------------------------------------------
+	if err != nil {
+		t.Error(err)
+	} else if result != EXPECTED_RESULT {
+		t.Errorf("result = %v but should = %v", result, EXPECTED_RESULT)
+	}
+	//--------------------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+// RPC_decode_xml
+//--------------------------------------------------------------------------------
+
+func TestRPC_decode_xml(t *testing.T) {
+	//--------------------------------------------------
+	XML_STRING := `<rpc><result>true</result></rpc>`
+	EXPECTED_RESULT := map[string]any{"result": true}
+	//----------------------------------------
+	result, err := RPC_decode_xml(XML_STRING, conv.WithXMLInferTypes(true))
+	//----------------------------------------
+	resultString := fmt.Sprint(result)
+	exptectedResultString := fmt.Sprint(EXPECTED_RESULT)
+	//--------------------------------------------------
+	if err != nil {
+		t.Error(err)
+	} else if resultString != exptectedResultString {
+		t.Errorf("resultString = %v but should = %v", resultString, exptectedResultString)
+	}
+	//--------------------------------------------------
+	for _, invalidString := range []string{`<rpc>text</rpc>`, `<other><result>true</result></other>`, `<rpc>`} {
+		if _, err = RPC_decode_xml(invalidString); err == nil {
+			t.Errorf("(%q) should return an error", invalidString)
+		}
+	}
+	//--------------------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+//################################################################################
+//--------------------------------------------------------------------------------
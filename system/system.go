@@ -13,17 +13,19 @@ import (
 	"bufio"
 	"crypto/hmac"
 	"crypto/sha1"
-	"encoding/base32"
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"net"
 	"os"
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/timbrockley/golang-main/conv"
 	"github.com/timbrockley/golang-main/file"
 )
 
@@ -31,6 +33,87 @@ import (
 //###########################################################
 //------------------------------------------------------------
 
+// StringParser converts human forms such as "512MiB" or "1h30m" for ToInt, ToInt32, ToInt64 and ToFloat
+//
+// parsers are opt-in, e.g. ToInt("512MiB", BytesParser), and the first one that succeeds is used
+//
+// values are exact so numbers and byte counts above 2^53 reach ToInt64 unchanged, a value that does not fit
+// the integer type gives 0, e.g. ToInt32("3GiB", BytesParser)
+type StringParser func(string) (*big.Rat, error)
+
+// NumberParser accepts thousands separators, e.g. "1,234.5"
+var NumberParser StringParser = conv.Human_ParseNumberRat
+
+// BytesParser accepts SI and IEC sizes in bytes, e.g. "1.5GB" or "512MiB"
+var BytesParser StringParser = func(valueString string) (*big.Rat, error) {
+	size, err := conv.Human_ParseBytes(valueString)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).SetInt64(size), nil
+}
+
+// DurationParser accepts durations in seconds, e.g. "90s" or "1h30m"
+var DurationParser StringParser = func(valueString string) (*big.Rat, error) {
+	duration, err := conv.Human_ParseDuration(valueString)
+	if err != nil {
+		return nil, err
+	}
+	return big.NewRat(int64(duration), int64(time.Second)), nil
+}
+
+// HumanParser tries NumberParser, BytesParser and then DurationParser
+var HumanParser StringParser = func(valueString string) (*big.Rat, error) {
+	for _, parser := range []StringParser{NumberParser, BytesParser, DurationParser} {
+		if ratValue, err := parser(valueString); err == nil {
+			return ratValue, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", conv.ErrHumanParse, valueString)
+}
+
+//------------------------------------------------------------
+// parseString
+//------------------------------------------------------------
+
+func parseString(valueString string, parsers []StringParser) (*big.Rat, bool) {
+	//------------------------------------------------------------
+	for _, parser := range parsers {
+		if ratValue, err := parser(valueString); err == nil && ratValue != nil {
+			return ratValue, true
+		}
+	}
+	//------------------------------------------------------------
+	return nil, false
+	//------------------------------------------------------------
+}
+
+//------------------------------------------------------------
+// parseStringInt
+//------------------------------------------------------------
+
+// parseStringInt truncates the parsed value toward zero as int() does for a float,
+// a value outside bitSize bits is returned as 0
+func parseStringInt(valueString string, parsers []StringParser, bitSize int) (int64, bool) {
+	//------------------------------------------------------------
+	ratValue, ok := parseString(valueString, parsers)
+	if !ok {
+		return 0, false
+	}
+	//------------------------------------------------------------
+	intValue := new(big.Int).Quo(ratValue.Num(), ratValue.Denom())
+	//------------------------------------------------------------
+	maxValue := new(big.Int).Lsh(big.NewInt(1), uint(bitSize-1))
+	minValue := new(big.Int).Neg(maxValue)
+	//------------------------------------------------------------
+	if intValue.Cmp(minValue) < 0 || intValue.Cmp(maxValue) >= 0 {
+		return 0, true
+	}
+	//------------------------------------------------------------
+	return intValue.Int64(), true
+	//------------------------------------------------------------
+}
+
 //------------------------------------------------------------
 // ToString
 //------------------------------------------------------------
@@ -78,7 +161,7 @@ func ToBytes(value any) []byte {
 // ToInt
 //------------------------------------------------------------
 
-func ToInt(value any) int {
+func ToInt(value any, Parsers ...StringParser) int {
 	//------------------------------------------------------------
 	if value == nil {
 		return 0
@@ -86,6 +169,9 @@ func ToInt(value any) int {
 	//------------------------------------------------------------
 	switch typedValue := value.(type) {
 	case string:
+		if intValue, ok := parseStringInt(typedValue, Parsers, strconv.IntSize); ok {
+			return int(intValue)
+		}
 		valueSplit := strings.Split(typedValue, ".")
 		if len(valueSplit) > 0 {
 			int64Val, _ := strconv.ParseInt(valueSplit[0], 10, 0)
@@ -119,7 +205,7 @@ func ToInt(value any) int {
 // ToInt32
 //------------------------------------------------------------
 
-func ToInt32(value any) int32 {
+func ToInt32(value any, Parsers ...StringParser) int32 {
 	//------------------------------------------------------------
 	if value == nil {
 		return 0
@@ -127,6 +213,9 @@ func ToInt32(value any) int32 {
 	//------------------------------------------------------------
 	switch typedValue := value.(type) {
 	case string:
+		if intValue, ok := parseStringInt(typedValue, Parsers, 32); ok {
+			return int32(intValue)
+		}
 		valueSplit := strings.Split(typedValue, ".")
 		if len(valueSplit) > 0 {
 			int64Val, _ := strconv.ParseInt(valueSplit[0], 10, 0)
@@ -160,7 +249,7 @@ func ToInt32(value any) int32 {
 // ToInt64
 //------------------------------------------------------------
 
-func ToInt64(value any) int64 {
+func ToInt64(value any, Parsers ...StringParser) int64 {
 	//------------------------------------------------------------
 	if value == nil {
 		return 0
@@ -168,6 +257,9 @@ func ToInt64(value any) int64 {
 	//------------------------------------------------------------
 	switch typedValue := value.(type) {
 	case string:
+		if intValue, ok := parseStringInt(typedValue, Parsers, 64); ok {
+			return int64(intValue)
+		}
 		valueSplit := strings.Split(typedValue, ".")
 		if len(valueSplit) > 0 {
 			int64Val, _ := strconv.ParseInt(valueSplit[0], 10, 0)
@@ -201,9 +293,9 @@ func ToInt64(value any) int64 {
 // ToFloat
 //------------------------------------------------------------
 
-func ToFloat(value any) float64 {
+func ToFloat(value any, Parsers ...StringParser) float64 {
 	//------------------------------------------------------------
-	return ToFloat64(value)
+	return ToFloat64(value, Parsers...)
 	//------------------------------------------------------------
 }
 
@@ -211,9 +303,9 @@ func ToFloat(value any) float64 {
 // ToFloat32
 //------------------------------------------------------------
 
-func ToFloat32(value any) float32 {
+func ToFloat32(value any, Parsers ...StringParser) float32 {
 	//------------------------------------------------------------
-	return float32(ToFloat64(value))
+	return float32(ToFloat64(value, Parsers...))
 	//------------------------------------------------------------
 }
 
@@ -221,7 +313,7 @@ func ToFloat32(value any) float32 {
 // ToFloat64
 //------------------------------------------------------------
 
-func ToFloat64(value any) float64 {
+func ToFloat64(value any, Parsers ...StringParser) float64 {
 	//------------------------------------------------------------
 	if value == nil {
 		return 0
@@ -229,6 +321,10 @@ func ToFloat64(value any) float64 {
 	//------------------------------------------------------------
 	switch typedValue := value.(type) {
 	case string:
+		if ratValue, ok := parseString(typedValue, Parsers); ok {
+			floatValue, _ := ratValue.Float64()
+			return floatValue
+		}
 		float64Val, _ := strconv.ParseFloat(value.(string), 64)
 		return float64Val
 	case int:
@@ -654,12 +750,12 @@ func GenerateOTP(timestamp int, secret string) (string, error) {
 		timestamp >>= 8
 	}
 	//------------------------------------------------------------
-	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(secret))
+	key, err := conv.Base32_decode(secret)
 	if err != nil {
 		return "", errors.New("secret contains invalid base32 characters")
 	}
 	//------------------------------------------------------------
-	hash := hmac.New(sha1.New, key)
+	hash := hmac.New(sha1.New, []byte(key))
 	hash.Write(message)
 	hmacResult := hash.Sum(nil)
 	//------------------------------------------------------------
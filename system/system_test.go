@@ -209,6 +209,87 @@ func TestToFloat(t *testing.T) {
 	//------------------------------------------------------------
 }
 
+//------------------------------------------------------------
+// StringParser
+//------------------------------------------------------------
+
+func TestStringParser(t *testing.T) {
+	//------------------------------------------------------------
+	if result := ToInt("512MiB"); result != 0 {
+		t.Errorf("ToInt(\"512MiB\") = %d but should = %d without a parser", result, 0)
+	}
+	//------------------------------------------------------------
+	intCases := []struct {
+		value    string
+		parser   StringParser
+		expected int64
+	}{
+		{"512MiB", BytesParser, 512 << 20},
+		{"1.5GB", BytesParser, 1500000000},
+		{"1h30m", DurationParser, 5400},
+		{"1,234,567", NumberParser, 1234567},
+		{"512MiB", HumanParser, 512 << 20},
+		{"90s", HumanParser, 90},
+		{"1m", HumanParser, 60},
+		{"1,000", HumanParser, 1000},
+		{"123", HumanParser, 123},
+		{"stringVal", HumanParser, 0},
+	}
+	//------------------------------------------------------------
+	for _, testCase := range intCases {
+		//------------------------------------------------------------
+		if result := ToInt(testCase.value, testCase.parser); int64(result) != testCase.expected {
+			t.Errorf("ToInt(%q) = %d but should = %d", testCase.value, result, testCase.expected)
+		}
+		if result := ToInt64(testCase.value, testCase.parser); result != testCase.expected {
+			t.Errorf("ToInt64(%q) = %d but should = %d", testCase.value, result, testCase.expected)
+		}
+		//------------------------------------------------------------
+	}
+	//------------------------------------------------------------
+	if result := ToFloat("1.5s", DurationParser); result != 1.5 {
+		t.Errorf("ToFloat(\"1.5s\") = %f but should = %f", result, 1.5)
+	}
+	//------------------------------------------------------------
+	if result := ToFloat("1,234.5", BytesParser, NumberParser); result != 1234.5 {
+		t.Errorf("ToFloat(\"1,234.5\") = %f but should = %f", result, 1234.5)
+	}
+	//------------------------------------------------------------
+	// parsed values stay exact and are range checked rather than wrapped
+	for _, testCase := range []struct {
+		value  string
+		parser StringParser
+	}{
+		{"9007199254740993B", BytesParser},
+		{"9007199254740993", NumberParser},
+		{"9,007,199,254,740,993", NumberParser},
+		{"9007199254740993", HumanParser},
+		{"9,007,199,254,740,993", HumanParser},
+	} {
+		if result := ToInt64(testCase.value, testCase.parser); result != 9007199254740993 {
+			t.Errorf("ToInt64(%q) = %d but should = %d", testCase.value, result, int64(9007199254740993))
+		}
+	}
+	//------------------------------------------------------------
+	if result := ToInt32("3GiB", BytesParser); result != 0 {
+		t.Errorf("ToInt32(\"3GiB\") = %d but should = %d", result, 0)
+	}
+	if result := ToInt32("2GiB", BytesParser); result != 0 {
+		t.Errorf("ToInt32(\"2GiB\") = %d but should = %d", result, 0)
+	}
+	if result := ToInt32("2047MiB", BytesParser); result != 2047<<20 {
+		t.Errorf("ToInt32(\"2047MiB\") = %d but should = %d", result, 2047<<20)
+	}
+	if result := ToInt64("3GiB", BytesParser); result != 3<<30 {
+		t.Errorf("ToInt64(\"3GiB\") = %d but should = %d", result, int64(3<<30))
+	}
+	//------------------------------------------------------------
+	if result := ToInt("1.9s", DurationParser); result != 1 {
+		t.Errorf("ToInt(\"1.9s\") = %d but should = %d", result, 1)
+	}
+	//------------------------------------------------------------
+}
+
 //------------------------------------------------------------
 // ToBool
 //------------------------------------------------------------
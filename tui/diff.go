@@ -0,0 +1,147 @@
+/*
+
+Copyright 2026, Tim Brockley. All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+*/
+
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/timbrockley/golang-main/conv"
+)
+
+//--------------------------------------------------------------------------------
+
+// RenderDiff colours conv.Diff_Lines / conv.Diff_Words edits, deletions red and insertions green
+func RenderDiff(edits []conv.DiffEdit, OptionsMap ...map[string]any) string {
+	//----------------------------------------
+	var builder strings.Builder
+	//----------------------------------------
+	for _, edit := range edits {
+		switch edit.Op {
+		case conv.DiffDelete:
+			writeColoured(&builder, edit.Text, Colour(Red)+Effect(Underline))
+		case conv.DiffInsert:
+			writeColoured(&builder, edit.Text, Colour(Green)+Effect(Underline))
+		default:
+			builder.WriteString(edit.Text)
+		}
+	}
+	//----------------------------------------
+	return renderOutput(builder.String(), OptionsMap...)
+	//----------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+
+// RenderUnifiedDiff colours the output of conv.Diff_Unified line by line
+func RenderUnifiedDiff(diffString string, OptionsMap ...map[string]any) string {
+	//----------------------------------------
+	var builder strings.Builder
+	//----------------------------------------
+	for _, line := range strings.SplitAfter(diffString, "\n") {
+		//----------------------------------------
+		switch {
+		case strings.HasPrefix(line, "---") || strings.HasPrefix(line, "+++"):
+			writeColoured(&builder, line, Effect(Bold))
+		case strings.HasPrefix(line, "@@"):
+			writeColoured(&builder, line, Colour(Cyan))
+		case strings.HasPrefix(line, "-"):
+			writeColoured(&builder, line, Colour(Red))
+		case strings.HasPrefix(line, "+"):
+			writeColoured(&builder, line, Colour(Green))
+		case strings.HasPrefix(line, "\\"):
+			writeColoured(&builder, line, Effect(Dim))
+		default:
+			builder.WriteString(line)
+		}
+		//----------------------------------------
+	}
+	//----------------------------------------
+	return renderOutput(builder.String(), OptionsMap...)
+	//----------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+
+// RenderDiffChanges lists conv.Diff_Tree changes as "+ path: value", "- path: value" and "~ path: old -> new"
+func RenderDiffChanges(changes []conv.DiffChange, OptionsMap ...map[string]any) string {
+	//----------------------------------------
+	var builder strings.Builder
+	//----------------------------------------
+	for _, change := range changes {
+		//----------------------------------------
+		path := change.Path
+		if path == "" {
+			path = "/"
+		}
+		//----------------------------------------
+		switch change.Type {
+		case conv.DiffAdded:
+			writeColoured(&builder, "+ "+path+": "+formatDiffValue(change.NewValue)+"\n", Colour(Green))
+		case conv.DiffRemoved:
+			writeColoured(&builder, "- "+path+": "+formatDiffValue(change.OldValue)+"\n", Colour(Red))
+		default:
+			writeColoured(&builder, "~ "+path+": "+formatDiffValue(change.OldValue)+" -> "+formatDiffValue(change.NewValue)+"\n", Colour(Yellow))
+		}
+		//----------------------------------------
+	}
+	//----------------------------------------
+	return renderOutput(builder.String(), OptionsMap...)
+	//----------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+
+// the colour is reset before each line break so pagers and truncated lines stay clean
+func writeColoured(builder *strings.Builder, text string, colour string) {
+	//----------------------------------------
+	for _, line := range strings.SplitAfter(text, "\n") {
+		//----------------------------------------
+		content := strings.TrimSuffix(line, "\n")
+		//----------------------------------------
+		if content != "" {
+			builder.WriteString(colour + content + Reset())
+		}
+		//----------------------------------------
+		if len(content) < len(line) {
+			builder.WriteString("\n")
+		}
+		//----------------------------------------
+	}
+	//----------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+
+func formatDiffValue(value any) string {
+	//----------------------------------------
+	if jsonString, err := conv.JSON_encode(value); err == nil {
+		return jsonString
+	}
+	//----------------------------------------
+	return fmt.Sprint(value)
+	//----------------------------------------
+}
+
+//--------------------------------------------------------------------------------
+
+func renderOutput(outputString string, OptionsMap ...map[string]any) string {
+	//----------------------------------------
+	options := ParseOptions(OptionsMap...)
+	//----------------------------------------
+	if options.Writer != nil {
+		fmt.Fprint(options.Writer, outputString)
+	}
+	//----------------------------------------
+	return outputString
+	//----------------------------------------
+}
+
+//--------------------------------------------------------------------------------
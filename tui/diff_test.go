@@ -0,0 +1,79 @@
+//------------------------------------------------------------
+
+package tui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/timbrockley/golang-main/conv"
+)
+
+//------------------------------------------------------------
+
+func TestRenderDiff(t *testing.T) {
+	//----------------------------------------
+	resultString := RenderDiff(conv.Diff_Words("port: 8080", "port: 9090"))
+	//----------------------------------------
+	expectedString := "port: " + "\033[31m\033[4m8080\033[0m" + "\033[32m\033[4m9090\033[0m"
+	//----------------------------------------
+	if resultString != expectedString {
+		t.Errorf("expected: %q but got: %q", expectedString, resultString)
+	}
+	//----------------------------------------
+	resultString = RenderDiff(conv.Diff_Lines("a\n", "b\n"))
+	//----------------------------------------
+	expectedString = "\033[31m\033[4ma\033[0m\n" + "\033[32m\033[4mb\033[0m\n"
+	//----------------------------------------
+	if resultString != expectedString {
+		t.Errorf("expected: %q but got: %q", expectedString, resultString)
+	}
+	//----------------------------------------
+}
+
+//------------------------------------------------------------
+
+func TestRenderUnifiedDiff(t *testing.T) {
+	//----------------------------------------
+	var outputBuffer bytes.Buffer
+	//----------------------------------------
+	resultString := RenderUnifiedDiff(conv.Diff_Unified("a\nb", "a\nc", "old", "new", 3), map[string]any{"Writer": &outputBuffer})
+	//----------------------------------------
+	expectedString := "\033[1m--- old\033[0m\n"
+	expectedString += "\033[1m+++ new\033[0m\n"
+	expectedString += "\033[36m@@ -1,2 +1,2 @@\033[0m\n"
+	expectedString += " a\n"
+	expectedString += "\033[31m-b\033[0m\n"
+	expectedString += "\033[2m\\ No newline at end of file\033[0m\n"
+	expectedString += "\033[32m+c\033[0m\n"
+	expectedString += "\033[2m\\ No newline at end of file\033[0m\n"
+	//----------------------------------------
+	if resultString != expectedString {
+		t.Errorf("expected: %q but got: %q", expectedString, resultString)
+	}
+	if outputBuffer.String() != expectedString {
+		t.Errorf("expected: %q but got: %q", expectedString, outputBuffer.String())
+	}
+	//----------------------------------------
+}
+
+//------------------------------------------------------------
+
+func TestRenderDiffChanges(t *testing.T) {
+	//----------------------------------------
+	oldDocument := map[string]any{"port": 8080, "debug": false, "name": "app"}
+	newDocument := map[string]any{"port": 9090, "tags": []any{"a"}, "name": "app"}
+	//----------------------------------------
+	resultString := RenderDiffChanges(conv.Diff_Tree(oldDocument, newDocument))
+	//----------------------------------------
+	expectedString := "\033[31m- /debug: false\033[0m\n"
+	expectedString += "\033[33m~ /port: 8080 -> 9090\033[0m\n"
+	expectedString += "\033[32m+ /tags: [\"a\"]\033[0m\n"
+	//----------------------------------------
+	if resultString != expectedString {
+		t.Errorf("expected: %q but got: %q", expectedString, resultString)
+	}
+	//----------------------------------------
+}
+
+//------------------------------------------------------------